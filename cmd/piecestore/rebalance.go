@@ -0,0 +1,51 @@
+package piecestore
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/bnb-chain/greenfield-storage-provider/cmd/utils"
+	"github.com/bnb-chain/greenfield-storage-provider/config"
+	"github.com/bnb-chain/greenfield-storage-provider/store/piecestore/piece"
+)
+
+var PieceRebalanceCmd = &cli.Command{
+	Action: pieceRebalanceAction,
+	Name:   "piece.rebalance",
+	Usage:  "Move the pieces of the sharded piece store to the shards of current shard layout",
+	Flags: []cli.Flag{
+		utils.ConfigFileFlag,
+	},
+	Category: "PIECE STORE COMMANDS",
+	Description: `
+The piece.rebalance command scans all the shards of the sharded piece store, and moves
+the pieces which are not in the shard picked by the current shard layout to the right
+shard. It runs alongside the services, which read the pieces from the shard picked by 
+the previous shard layout until they are moved. Clear the PreviousShardLayout of the 
+piece store config after the command finishes without failed pieces.`,
+}
+
+// pieceRebalanceAction is the piece.rebalance command.
+func pieceRebalanceAction(ctx *cli.Context) error {
+	cfg := config.DefaultStorageProviderConfig
+	if ctx.IsSet(utils.ConfigFileFlag.Name) {
+		cfg = config.LoadConfig(ctx.String(utils.ConfigFileFlag.Name))
+	}
+	ps, err := piece.NewPieceStore(cfg.PieceStoreConfig)
+	if err != nil {
+		return err
+	}
+	result, err := ps.Rebalance(ctx.Context)
+	if result != nil {
+		fmt.Printf("scanned_pieces: %d, moved_pieces: %d, failed_pieces: %d\n",
+			result.Scanned, result.Moved, result.Failed)
+	}
+	if err != nil {
+		return err
+	}
+	if result.Failed > 0 {
+		return fmt.Errorf("failed to move %d pieces, please rerun the command", result.Failed)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package piecestore
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/bnb-chain/greenfield-storage-provider/cmd/utils"
+	"github.com/bnb-chain/greenfield-storage-provider/config"
+	"github.com/bnb-chain/greenfield-storage-provider/store/piecestore/client"
+	"github.com/bnb-chain/greenfield-storage-provider/store/piecestore/piece"
+)
+
+var PieceRepairCmd = &cli.Command{
+	Action: pieceRepairAction,
+	Name:   "piece.repair",
+	Usage:  "Resync the divergent replicas of the mirrored piece store",
+	Flags: []cli.Flag{
+		utils.ConfigFileFlag,
+	},
+	Category: "PIECE STORE COMMANDS",
+	Description: `
+The piece.repair command scans the pieces of all the replicas of the mirrored piece 
+store, verifies them against the piece checksums in sp-db, and copies the verified
+piece to the replicas which miss it or whose piece is corrupt. The remaining copies
+of the pieces deleted by gc are removed. The replicas which fail to be accessed are
+skipped. Run it after a failed disk or bucket is replaced.`,
+}
+
+// pieceRepairAction is the piece.repair command.
+func pieceRepairAction(ctx *cli.Context) error {
+	cfg := config.DefaultStorageProviderConfig
+	if ctx.IsSet(utils.ConfigFileFlag.Name) {
+		cfg = config.LoadConfig(ctx.String(utils.ConfigFileFlag.Name))
+	}
+	ps, err := piece.NewPieceStore(cfg.PieceStoreConfig)
+	if err != nil {
+		return err
+	}
+	spDB, err := utils.MakeSPDB(ctx, cfg.SpDBConfig)
+	if err != nil {
+		return err
+	}
+	result, err := ps.Repair(ctx.Context, client.NewRepairVerifier(spDB))
+	if result != nil {
+		fmt.Printf("scanned_pieces: %d, repaired_replicas: %d, removed_replicas: %d, failed_replicas: %d\n",
+			result.Scanned, result.Repaired, result.Removed, result.Failed)
+	}
+	if err != nil {
+		return err
+	}
+	if result.Failed > 0 {
+		return fmt.Errorf("failed to repair %d replicas, please rerun the command", result.Failed)
+	}
+	return nil
+}
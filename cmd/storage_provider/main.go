@@ -10,6 +10,7 @@ import (
 
 	"github.com/bnb-chain/greenfield-storage-provider/cmd/conf"
 	"github.com/bnb-chain/greenfield-storage-provider/cmd/p2p"
+	"github.com/bnb-chain/greenfield-storage-provider/cmd/piecestore"
 	"github.com/bnb-chain/greenfield-storage-provider/cmd/utils"
 	"github.com/bnb-chain/greenfield-storage-provider/config"
 	"github.com/bnb-chain/greenfield-storage-provider/pkg/lifecycle"
@@ -81,6 +82,9 @@ func init() {
 		conf.ConfigUploadCmd,
 		// p2p category commands
 		p2p.P2PCreateKeysCmd,
+		// piece store category commands
+		piecestore.PieceRebalanceCmd,
+		piecestore.PieceRepairCmd,
 		// miscellaneous category commands
 		VersionCmd,
 		utils.ListServiceCmd,
@@ -15,9 +15,12 @@ import (
 	"github.com/bnb-chain/greenfield-storage-provider/pkg/p2p"
 	"github.com/bnb-chain/greenfield-storage-provider/pkg/pprof"
 	"github.com/bnb-chain/greenfield-storage-provider/service/blocksyncer"
+	"github.com/bnb-chain/greenfield-storage-provider/service/downloader"
+	"github.com/bnb-chain/greenfield-storage-provider/service/manager"
 	"github.com/bnb-chain/greenfield-storage-provider/service/metadata"
 	"github.com/bnb-chain/greenfield-storage-provider/service/signer"
 	"github.com/bnb-chain/greenfield-storage-provider/service/stopserving"
+	"github.com/bnb-chain/greenfield-storage-provider/service/tasknode"
 	"github.com/bnb-chain/greenfield-storage-provider/store/config"
 	storeconfig "github.com/bnb-chain/greenfield-storage-provider/store/config"
 	"github.com/bnb-chain/greenfield-storage-provider/store/piecestore/storage"
@@ -45,6 +48,11 @@ type StorageProviderConfig struct {
 	DiscontinueCfg     *stopserving.DiscontinueConfig
 	MetadataCfg        *metadata.MetadataConfig
 	BandwidthLimiter   *localhttp.BandwidthLimiterConfig
+	PieceReconcilerCfg *manager.OrphanPieceReconcilerConfig
+	PieceScrubberCfg   *manager.PieceScrubberConfig
+	PieceTierCfg       *manager.PieceTierMigratorConfig
+	DegradedReadCfg    *downloader.DegradedReadConfig
+	SPReputationCfg    *tasknode.SPReputationConfig
 }
 
 // JSONMarshal marshal the StorageProviderConfig to json format
@@ -113,6 +121,11 @@ var DefaultStorageProviderConfig = &StorageProviderConfig{
 	DiscontinueCfg:     stopserving.DefaultDiscontinueConfig,
 	MetadataCfg:        DefaultMetadataConfig,
 	BandwidthLimiter:   DefaultBandwidthLimiterConfig,
+	PieceReconcilerCfg: manager.DefaultOrphanPieceReconcilerConfig,
+	PieceScrubberCfg:   manager.DefaultPieceScrubberConfig,
+	PieceTierCfg:       manager.DefaultPieceTierMigratorConfig,
+	DegradedReadCfg:    downloader.DefaultDegradedReadConfig,
+	SPReputationCfg:    tasknode.DefaultSPReputationConfig,
 }
 
 // DefaultSQLDBConfig defines the default configuration of SQL DB
@@ -142,14 +142,21 @@ func (cfg *StorageProviderConfig) MakeUploaderConfig() (*uploader.UploaderConfig
 // MakeDownloaderConfig make downloader service config from StorageProviderConfig
 func (cfg *StorageProviderConfig) MakeDownloaderConfig() (*downloader.DownloaderConfig, error) {
 	dCfg := &downloader.DownloaderConfig{
-		SpDBConfig:       cfg.SpDBConfig,
-		PieceStoreConfig: cfg.PieceStoreConfig,
+		SpOperatorAddress: cfg.SpOperatorAddress,
+		SpDBConfig:        cfg.SpDBConfig,
+		PieceStoreConfig:  cfg.PieceStoreConfig,
+		DegradedReadCfg:   cfg.DegradedReadCfg,
 	}
 	if _, ok := cfg.ListenAddress[model.DownloaderService]; ok {
 		dCfg.GRPCAddress = cfg.ListenAddress[model.DownloaderService]
 	} else {
 		return nil, fmt.Errorf("missing downloader gRPC address configuration for downloader service")
 	}
+	if _, ok := cfg.Endpoint[model.SignerService]; ok {
+		dCfg.SignerGrpcAddress = cfg.Endpoint[model.SignerService]
+	} else if cfg.DegradedReadCfg != nil && cfg.DegradedReadCfg.Enabled {
+		return nil, fmt.Errorf("missing signer gRPC address configuration for downloader service")
+	}
 	return dCfg, nil
 }
 
@@ -201,10 +208,11 @@ func (cfg *StorageProviderConfig) MakeSignerConfig() (*signer.SignerConfig, *gnf
 // MakeTaskNodeConfig make task node service config from StorageProviderConfig
 func (cfg *StorageProviderConfig) MakeTaskNodeConfig() (*tasknode.TaskNodeConfig, error) {
 	snCfg := &tasknode.TaskNodeConfig{
-		SpOperatorAddress: cfg.SpOperatorAddress,
-		SpDBConfig:        cfg.SpDBConfig,
-		PieceStoreConfig:  cfg.PieceStoreConfig,
-		ChainConfig:       cfg.ChainConfig,
+		SpOperatorAddress:  cfg.SpOperatorAddress,
+		SpDBConfig:         cfg.SpDBConfig,
+		PieceStoreConfig:   cfg.PieceStoreConfig,
+		ChainConfig:        cfg.ChainConfig,
+		SPReputationConfig: cfg.SPReputationCfg,
 	}
 	if _, ok := cfg.ListenAddress[model.TaskNodeService]; ok {
 		snCfg.GRPCAddress = cfg.ListenAddress[model.TaskNodeService]
@@ -221,6 +229,11 @@ func (cfg *StorageProviderConfig) MakeTaskNodeConfig() (*tasknode.TaskNodeConfig
 	} else {
 		return nil, fmt.Errorf("missing p2p server gRPC address configuration for task node service")
 	}
+	if _, ok := cfg.Endpoint[model.MetadataService]; ok {
+		snCfg.MetadataGrpcAddress = cfg.Endpoint[model.MetadataService]
+	} else {
+		return nil, fmt.Errorf("missing metadata server gRPC address configuration for task node service")
+	}
 	return snCfg, nil
 }
 
@@ -243,16 +256,29 @@ func (cfg *StorageProviderConfig) MakeMetadataServiceConfig() (*metadata.Metadat
 // MakeManagerServiceConfig make manager service config from StorageProviderConfig
 func (cfg *StorageProviderConfig) MakeManagerServiceConfig() (*manager.ManagerConfig, error) {
 	managerConfig := &manager.ManagerConfig{
-		SpOperatorAddress: cfg.SpOperatorAddress,
-		ChainConfig:       cfg.ChainConfig,
-		SpDBConfig:        cfg.SpDBConfig,
-		PieceStoreConfig:  cfg.PieceStoreConfig,
+		SpOperatorAddress:  cfg.SpOperatorAddress,
+		ChainConfig:        cfg.ChainConfig,
+		SpDBConfig:         cfg.SpDBConfig,
+		PieceStoreConfig:   cfg.PieceStoreConfig,
+		ReconcilerConfig:   cfg.PieceReconcilerCfg,
+		ScrubberConfig:     cfg.PieceScrubberCfg,
+		TierMigratorConfig: cfg.PieceTierCfg,
 	}
 	if _, ok := cfg.Endpoint[model.MetadataService]; ok {
 		managerConfig.MetadataGrpcAddress = cfg.Endpoint[model.MetadataService]
 	} else {
 		return nil, fmt.Errorf("missing metadata server gRPC address configuration for manager service")
 	}
+	if _, ok := cfg.Endpoint[model.TaskNodeService]; ok {
+		managerConfig.TaskNodeGrpcAddress = cfg.Endpoint[model.TaskNodeService]
+	} else {
+		return nil, fmt.Errorf("missing task node gRPC address configuration for manager service")
+	}
+	if _, ok := cfg.Endpoint[model.SignerService]; ok {
+		managerConfig.SignerGrpcAddress = cfg.Endpoint[model.SignerService]
+	} else {
+		return nil, fmt.Errorf("missing signer gRPC address configuration for manager service")
+	}
 	return managerConfig, nil
 }
 
@@ -166,6 +166,36 @@ const (
 	ContentLengthHeader = "Content-Length"
 	// ETagHeader is an MD5 digest of the object data
 	ETagHeader = "ETag"
+	// LastModifiedHeader contains the date and time at which the origin server believes the resource was last modified
+	LastModifiedHeader = "Last-Modified"
+	// IfMatchHeader makes the request conditional on the ETag of resource matches one of the listed ETags
+	IfMatchHeader = "If-Match"
+	// IfNoneMatchHeader makes the request conditional on the ETag of resource matches none of the listed ETags
+	IfNoneMatchHeader = "If-None-Match"
+	// IfModifiedSinceHeader makes the request conditional on the resource is modified after the given date
+	IfModifiedSinceHeader = "If-Modified-Since"
+	// IfUnmodifiedSinceHeader makes the request conditional on the resource is not modified after the given date
+	IfUnmodifiedSinceHeader = "If-Unmodified-Since"
+	// IfRangeHeader makes the range request conditional, the range is ignored if the ETag or date does not match
+	IfRangeHeader = "If-Range"
+	// OriginHeader indicates the origin that caused the cross-origin request
+	OriginHeader = "Origin"
+	// VaryHeader describes the request headers that influence the response
+	VaryHeader = "Vary"
+	// AccessControlRequestMethodHeader is used by preflight request to indicate the method of actual request
+	AccessControlRequestMethodHeader = "Access-Control-Request-Method"
+	// AccessControlRequestHeadersHeader is used by preflight request to indicate the headers of actual request
+	AccessControlRequestHeadersHeader = "Access-Control-Request-Headers"
+	// AccessControlAllowOriginHeader indicates whether the response can be shared with the origin
+	AccessControlAllowOriginHeader = "Access-Control-Allow-Origin"
+	// AccessControlAllowMethodsHeader indicates the methods allowed when accessing the resource
+	AccessControlAllowMethodsHeader = "Access-Control-Allow-Methods"
+	// AccessControlAllowHeadersHeader indicates the headers allowed in the actual request
+	AccessControlAllowHeadersHeader = "Access-Control-Allow-Headers"
+	// AccessControlExposeHeadersHeader indicates the response headers that can be exposed to the browser
+	AccessControlExposeHeadersHeader = "Access-Control-Expose-Headers"
+	// AccessControlMaxAgeHeader indicates how long the results of preflight request can be cached
+	AccessControlMaxAgeHeader = "Access-Control-Max-Age"
 	// RangeHeader asks the server to send only a portion of an HTTP message back to a client
 	RangeHeader = "Range"
 	// ContentRangeHeader response HTTP header indicates where in a full body message a partial message belongs
@@ -176,6 +206,8 @@ const (
 	ContentTypeJSONHeaderValue = "application/json"
 	// ContentTypeXMLHeaderValue is used to indicate xml
 	ContentTypeXMLHeaderValue = "application/xml"
+	// ContentTypeMultipartByteRangesHeaderValue is used to indicate the multi-range response
+	ContentTypeMultipartByteRangesHeaderValue = "multipart/byteranges"
 	// ContentDispositionHeader is used to indicate the media disposition of the resource
 	ContentDispositionHeader = "Content-Disposition"
 	// ContentDispositionAttachmentValue is used to indicate attachment
@@ -197,6 +229,8 @@ const (
 	SignTypeOffChain   = "OffChainAuth" // sign type - off-chain-auth
 	SignTypePersonal   = "PersonalSign" // sign type -  PersonalSign
 	SignAlgorithmEddsa = "EDDSA"
+	// SignAlgorithmS3V4 is the AWS signature version 4 algorithm, which is used by s3 compatible clients
+	SignAlgorithmS3V4 = "AWS4-HMAC-SHA256"
 
 	// GetApprovalPath defines get-approval path style suffix
 	GetApprovalPath = "/greenfield/admin/v1/get-approval"
@@ -204,6 +238,8 @@ const (
 	ActionQuery = "action"
 	// UploadProgressQuery defines upload progress query, which is used to route request
 	UploadProgressQuery = "upload-progress"
+	// PutObjectOffsetQuery defines the start position of the payload in the object, which is used to resume uploading
+	PutObjectOffsetQuery = "offset"
 	// GetBucketReadQuotaQuery defines bucket read quota query, which is used to route request
 	GetBucketReadQuotaQuery = "read-quota"
 	// GetBucketReadQuotaMonthQuery defines bucket read quota query month
@@ -222,6 +258,16 @@ const (
 	ListObjectsDelimiterQuery = "delimiter"
 	// ListObjectsPrefixQuery defines limits the response to keys that begin with the specified prefix
 	ListObjectsPrefixQuery = "prefix"
+	// ListObjectsV2Query defines the s3 list objects version query, which is used to route request
+	ListObjectsV2Query = "list-type"
+	// PresignedURLQuery defines the pre-signed url query, which is used to route the request of issuing the url
+	PresignedURLQuery = "presigned-url"
+	// PresignedSignerQuery defines the address of the user who the pre-signed url is issued to
+	PresignedSignerQuery = "X-Gnfd-Signer"
+	// PresignedExpiresQuery defines the expiry unix timestamp in seconds of the pre-signed url
+	PresignedExpiresQuery = "X-Gnfd-Expires"
+	// PresignedSignatureQuery defines the signature of the pre-signed url signed by the sp operator
+	PresignedSignatureQuery = "X-Gnfd-Signature"
 	// GetBucketMetaQuery defines get bucket metadata query, which is used to route request
 	GetBucketMetaQuery = "bucket-meta"
 	// GetObjectMetaQuery defines get object metadata query, which is used to route request
@@ -234,16 +280,34 @@ const (
 	ChallengePath = "/greenfield/admin/v1/challenge"
 	// ReplicateObjectPiecePath defines replicate-object path style
 	ReplicateObjectPiecePath = "/greenfield/receiver/v1/replicate-piece"
+	// RecoveryPiecePath defines recovery-piece path style, which is used to get the pieces from other sps
+	RecoveryPiecePath = "/greenfield/recovery/v1/get-piece"
 	// AuthRequestNoncePath defines path to request auth nonce
 	AuthRequestNoncePath = "/auth/request_nonce"
 	// AuthUpdateKeyPath defines path to update user public key
 	AuthUpdateKeyPath = "/auth/update_key"
+	// BucketCORSPath defines the admin path to get, put or delete the cors configuration of bucket
+	BucketCORSPath = "/greenfield/admin/v1/bucket-cors"
+	// AuthCreateS3CredentialPath defines path to create s3 credential bound to the user's off chain auth key
+	AuthCreateS3CredentialPath = "/auth/create_s3_credential"
+	// ScrubResultPath defines the admin path to query the scrub results of the locally stored pieces
+	ScrubResultPath = "/greenfield/admin/v1/scrub-result"
+	// ScrubStatusQuery defines the scrub status of the queried objects, supports healthy, corrupt and repaired
+	ScrubStatusQuery = "status"
+	// ScrubLimitQuery defines the max number of the queried scrub results
+	ScrubLimitQuery = "limit"
 	// GnfdRequestIDHeader defines trace-id, trace request in sp
 	GnfdRequestIDHeader = "X-Gnfd-Request-ID"
 	// GnfdAuthorizationHeader defines authorization, verify signature and check authorization
 	GnfdAuthorizationHeader = "Authorization"
 	// GnfdObjectIDHeader defines object id
 	GnfdObjectIDHeader = "X-Gnfd-Object-ID"
+	// GnfdObjectStatusHeader defines object status, e.g. OBJECT_STATUS_SEALED
+	GnfdObjectStatusHeader = "X-Gnfd-Object-Status"
+	// GnfdChecksumsHeader defines the comma separated hex encoded checksums of object, the first one is the integrity hash
+	GnfdChecksumsHeader = "X-Gnfd-Checksums"
+	// GnfdSecondarySPsHeader defines the comma separated operator addresses of object's secondary sps
+	GnfdSecondarySPsHeader = "X-Gnfd-Secondary-SPs"
 	// GnfdPieceIndexHeader defines piece idx, which is used by challenge
 	GnfdPieceIndexHeader = "X-Gnfd-Piece-Index"
 	// GnfdRedundancyIndexHeader defines redundancy idx, which is used by challenge and receiver
@@ -260,10 +324,15 @@ const (
 	GnfdPieceSizeHeader = "X-Gnfd-Piece-Size"
 	// GnfdReplicateApproval defines SP approval that allow to replicate piece data, which is used by receiver
 	GnfdReplicateApproval = "X-Gnfd-Replicate-Approval"
+	// GnfdRecoveryApprovalHeader defines SP approval that allow to get the piece data from other SPs, which is used by recovery
+	GnfdRecoveryApprovalHeader = "X-Gnfd-Recovery-Approval"
 	// GnfdIntegrityHashSignatureHeader defines integrity hash signature, which is used by receiver
 	GnfdIntegrityHashSignatureHeader = "X-Gnfd-Integrity-Hash-Signature"
 	// GnfdUserAddressHeader defines the user address
 	GnfdUserAddressHeader = "X-Gnfd-User-Address"
+	// GnfdMissingSegmentsHeader defines the comma separated indexes of the segments which have not been uploaded,
+	// which is returned by the partial put object request
+	GnfdMissingSegmentsHeader = "X-Gnfd-Missing-Segments"
 	// GnfdResponseXMLVersion defines the response xml version
 	GnfdResponseXMLVersion = "1.0"
 
@@ -277,6 +346,10 @@ const (
 	GnfdOffChainAuthAppRegPublicKeyHeader = "X-Gnfd-App-Reg-Public-Key"
 	// GnfdOffChainAuthAppRegExpiryDateHeader defines the Expiry-Date is the ISO 8601 datetime string (e.g. 2021-09-30T16:25:24Z), used to register the EDDSA public key
 	GnfdOffChainAuthAppRegExpiryDateHeader = "X-Gnfd-App-Reg-Expiry-Date"
+	// AmzDateHeader defines the request time of s3 signature version 4, formatted in ISO 8601 basic format
+	AmzDateHeader = "X-Amz-Date"
+	// AmzContentSha256Header defines the hex encoded sha256 of payload, or UNSIGNED-PAYLOAD, signed by s3 signature version 4
+	AmzContentSha256Header = "X-Amz-Content-Sha256"
 )
 
 // define all kinds of size
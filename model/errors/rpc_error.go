@@ -1,7 +1,9 @@
 package errors
 
 import (
+	"encoding/hex"
 	"errors"
+	"fmt"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -38,8 +40,29 @@ var (
 	ErrInvalidObjectKey = errors.New("invalid object key")
 	// ErrNoPermissionAccessBucket defines deny access bucket error
 	ErrNoPermissionAccessBucket = errors.New("deny access bucket")
+	// ErrPieceCorrupt defines the piece data mismatches the checksum in integrity meta error
+	ErrPieceCorrupt = errors.New("piece data is corrupt")
 )
 
+// PieceCorruptError defines the corrupt piece error returned by the verifying read of piece store,
+// which records the piece key and the checksums, and is matched by errors.Is(err, ErrPieceCorrupt)
+type PieceCorruptError struct {
+	Key              string
+	ExpectedChecksum []byte
+	ActualChecksum   []byte
+}
+
+// Error implements the error interface
+func (e *PieceCorruptError) Error() string {
+	return fmt.Sprintf("%s, key: %s, expected checksum: %s, actual checksum: %s", ErrPieceCorrupt.Error(),
+		e.Key, hex.EncodeToString(e.ExpectedChecksum), hex.EncodeToString(e.ActualChecksum))
+}
+
+// Unwrap returns ErrPieceCorrupt, so that the typed error can be matched by errors.Is
+func (e *PieceCorruptError) Unwrap() error {
+	return ErrPieceCorrupt
+}
+
 // gateway errors
 var (
 	// ErrInternalError defines storage provider internal error
@@ -58,6 +81,16 @@ var (
 	ErrSignatureConsistent = errors.New("signature is not consistent")
 	// ErrUnsupportedSignType defines the unsupported signature type error
 	ErrUnsupportedSignType = errors.New("unsupported signature type")
+	// ErrRequestTimeTooSkewed defines the request time of s3 signature is too skewed from the server time
+	ErrRequestTimeTooSkewed = errors.New("request time too skewed")
+	// ErrUnsupportedPayloadSigning defines the unsupported s3 payload signing mode, e.g. the streaming payload
+	ErrUnsupportedPayloadSigning = errors.New("unsupported payload signing")
+	// ErrPayloadHashMismatch defines the payload mismatches the hash in x-amz-content-sha256 header signed by s3 signature
+	ErrPayloadHashMismatch = errors.New("payload hash mismatch")
+	// ErrPresignedURLExpired defines the pre-signed url is expired
+	ErrPresignedURLExpired = errors.New("pre-signed url is expired")
+	// ErrPresignedURLExpiryTooLong defines the expiry of pre-signed url exceeds the max expiry
+	ErrPresignedURLExpiryTooLong = errors.New("pre-signed url expiry is too long")
 	// ErrEmptyReqHeader defines the empty header error
 	ErrEmptyReqHeader = errors.New("request header is empty")
 	// ErrInvalidHeader defines the invalid header error
@@ -92,6 +125,14 @@ var (
 	ErrSealObjectOnChain = errors.New("send sealObject msg failed")
 )
 
+// auth service error
+var (
+	// ErrInvalidAccessKey defines the s3 access key does not exist or its off chain auth key is expired or rotated
+	ErrInvalidAccessKey = errors.New("invalid s3 access key")
+	// ErrNoValidAuthKey defines the user has no valid off chain auth key to bind the s3 credential to
+	ErrNoValidAuthKey = errors.New("no valid off chain auth key")
+)
+
 // metadata service error
 var (
 	// ErrInvalidAccountID defines invalid account id
@@ -106,6 +147,8 @@ var (
 	ErrSPNumber = errors.New("failed to get sufficient SPs from DB")
 	// ErrExhaustedSP defines no backup SP to pick up error
 	ErrExhaustedSP = errors.New("backup storage providers exhausted")
+	// ErrInvalidSecondarySignature defines the secondary SP's signature of integrity hash is invalid
+	ErrInvalidSecondarySignature = errors.New("invalid secondary sp signature")
 )
 
 // uploader service error
@@ -114,6 +157,12 @@ var (
 	ErrMismatchIntegrityHash = errors.New("integrity hash mismatch")
 	// ErrMismatchChecksumNum defines checksum number mismatch error
 	ErrMismatchChecksumNum = errors.New("checksum number mismatch")
+	// ErrInvalidUploadOffset defines the upload offset is not aligned to segment size or out of payload range
+	ErrInvalidUploadOffset = errors.New("upload offset is invalid")
+	// ErrMismatchSegmentSize defines the uploaded segment size mismatch error
+	ErrMismatchSegmentSize = errors.New("segment size mismatch")
+	// ErrMismatchSegmentChecksum defines the re-uploaded segment differs from the segment uploaded before
+	ErrMismatchSegmentChecksum = errors.New("segment checksum mismatch")
 )
 
 // InnerErrorToGRPCError convents inner error to grpc/status error
@@ -125,6 +174,19 @@ func InnerErrorToGRPCError(err error) error {
 	if errors.Is(err, ErrCheckQuotaEnough) {
 		return status.Errorf(codes.PermissionDenied, "Quota is not enough")
 	}
+	if errors.Is(err, ErrInvalidUploadOffset) || errors.Is(err, ErrMismatchSegmentSize) ||
+		errors.Is(err, ErrMismatchSegmentChecksum) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if errors.Is(err, ErrPieceCorrupt) {
+		return status.Error(codes.DataLoss, ErrPieceCorrupt.Error())
+	}
+	if errors.Is(err, ErrInvalidAccessKey) {
+		return status.Error(codes.Unauthenticated, ErrInvalidAccessKey.Error())
+	}
+	if errors.Is(err, ErrNoValidAuthKey) {
+		return status.Error(codes.FailedPrecondition, ErrNoValidAuthKey.Error())
+	}
 	return err
 }
 
@@ -137,6 +199,25 @@ func GRPCErrorToInnerError(err error) error {
 	if codes.PermissionDenied == errStatus.Code() {
 		return ErrCheckQuotaEnough
 	}
+	if codes.InvalidArgument == errStatus.Code() {
+		switch errStatus.Message() {
+		case ErrInvalidUploadOffset.Error():
+			return ErrInvalidUploadOffset
+		case ErrMismatchSegmentSize.Error():
+			return ErrMismatchSegmentSize
+		case ErrMismatchSegmentChecksum.Error():
+			return ErrMismatchSegmentChecksum
+		}
+	}
+	if codes.DataLoss == errStatus.Code() && errStatus.Message() == ErrPieceCorrupt.Error() {
+		return ErrPieceCorrupt
+	}
+	if codes.Unauthenticated == errStatus.Code() && errStatus.Message() == ErrInvalidAccessKey.Error() {
+		return ErrInvalidAccessKey
+	}
+	if codes.FailedPrecondition == errStatus.Code() && errStatus.Message() == ErrNoValidAuthKey.Error() {
+		return ErrNoValidAuthKey
+	}
 	return err
 }
 
@@ -10,13 +10,21 @@ const (
 	DiskFileStore = "file"
 	// MemoryStore defines storage type for memory
 	MemoryStore = "memory"
+	// AzureBlobStore defines storage type for azure blob storage
+	AzureBlobStore = "azblob"
+	// GCSStore defines storage type for google cloud storage
+	GCSStore = "gcs"
+	// OSSStore defines storage type for alibaba cloud object storage service
+	OSSStore = "oss"
 )
 
 // piece store storage config and environment constants
 const (
-	// AKSKIAMType defines IAM type config which uses access key and secret key to access aws s3
+	// AKSKIAMType defines IAM type config which uses access key and secret key to access aws s3, or the
+	// equivalent static credentials of other object storages
 	AKSKIAMType = "AKSK"
-	// SAIAMType defines IAM type config which uses service account to access aws s3
+	// SAIAMType defines IAM type config which uses service account to access aws s3, or the equivalent
+	// workload identity of other object storages
 	SAIAMType = "SA"
 
 	// AWSRoleARN defines env variable for aws role arn
@@ -41,6 +49,24 @@ const (
 	MinioSecretKey = "MINIO_SECRET_KEY"
 	// MinioSessionToken defines env variable name for minio session token
 	MinioSessionToken = "MINIO_SESSION_TOKEN"
+	// AzureStorageAccount defines env variable name for azure storage account name
+	AzureStorageAccount = "AZURE_STORAGE_ACCOUNT"
+	// AzureStorageKey defines env variable name for azure storage account key
+	AzureStorageKey = "AZURE_STORAGE_KEY"
+	// GCSCredentialsFile defines env variable name for the json key file of google cloud service account
+	GCSCredentialsFile = "GCS_CREDENTIALS_FILE"
+	// GCSProjectID defines env variable name for google cloud project id, which is used to create bucket
+	GCSProjectID = "GCS_PROJECT_ID"
+	// GCSEmulatorHost defines env variable name for the host of gcs emulator, e.g. fake-gcs-server
+	GCSEmulatorHost = "STORAGE_EMULATOR_HOST"
+	// OSSAccessKey defines env variable name for oss access key
+	OSSAccessKey = "OSS_ACCESS_KEY"
+	// OSSSecretKey defines env variable name for oss secret key
+	OSSSecretKey = "OSS_SECRET_KEY"
+	// OSSSessionToken defines env variable name for oss security token
+	OSSSessionToken = "OSS_SESSION_TOKEN"
+	// OSSECSRoleName defines env variable name for the ram role of ecs instance to access oss
+	OSSECSRoleName = "ALIBABA_CLOUD_ECS_METADATA"
 )
 
 // define piece store constants.
@@ -96,6 +96,15 @@ func ComputeSegmentCount(size uint64, spiltSize uint64) uint32 {
 	return segmentCount
 }
 
+// ComputeSegmentSize return the size of the segment at segmentIdx, all the segments are the spilt size except
+// the last one.
+func ComputeSegmentSize(size uint64, segmentIdx uint32, spiltSize uint64) uint64 {
+	if remaining := size - uint64(segmentIdx)*spiltSize; remaining < spiltSize {
+		return remaining
+	}
+	return spiltSize
+}
+
 // GenerateObjectSegmentKeyList generate object's segment piece key list.
 func GenerateObjectSegmentKeyList(objectID, objectSize, segmentSize uint64) []string {
 	var (
@@ -252,3 +252,37 @@ func TestCheckECPieceKey(t *testing.T) {
 		})
 	}
 }
+
+func TestComputeSegmentSize(t *testing.T) {
+	cases := []struct {
+		name       string
+		size       uint64
+		segmentIdx uint32
+		wantedResp uint64
+	}{
+		{
+			name:       "full segment",
+			size:       40,
+			segmentIdx: 1,
+			wantedResp: 16,
+		},
+		{
+			name:       "last segment",
+			size:       40,
+			segmentIdx: 2,
+			wantedResp: 8,
+		},
+		{
+			name:       "aligned last segment",
+			size:       32,
+			segmentIdx: 1,
+			wantedResp: 16,
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := ComputeSegmentSize(tt.size, tt.segmentIdx, 16)
+			assert.Equal(t, tt.wantedResp, resp)
+		})
+	}
+}
@@ -51,9 +51,59 @@ var (
 		Name: "piece_store_total_requests",
 		Help: "Track piece store handles total request",
 	}, []string{"method_name"})
+	// PieceStoreCacheCounter records total hit or miss number of local piece cache
+	PieceStoreCacheCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "piece_store_cache_total",
+		Help: "Track the hit or miss number of local piece cache",
+	}, []string{"hit_or_miss"})
 	SPDBTimeHistogram = prometheus.NewHistogramVec(prometheus.HistogramOpts{
 		Name:    "sp_db_handling_seconds",
 		Help:    "Track the latency for spdb requests",
 		Buckets: prometheus.DefBuckets,
 	}, []string{"method_name"})
+	// GCExhaustedTaskGauge records the number of gc tasks which have run out of the retry budget
+	GCExhaustedTaskGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "manager_gc_exhausted_task_number",
+		Help: "Track manager service gc tasks which have run out of the retry budget and block gc progress",
+	}, []string{serviceLabelName})
+	// OrphanPieceScannedCounter records total piece number scanned by orphan piece reconciler
+	OrphanPieceScannedCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "manager_orphan_piece_scanned_total",
+		Help: "Track manager service orphan piece reconciler scans total piece number",
+	}, []string{serviceLabelName})
+	// OrphanPieceCounter records total orphan piece number found by orphan piece reconciler
+	OrphanPieceCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "manager_orphan_piece_total",
+		Help: "Track manager service orphan piece reconciler finds total orphan piece number",
+	}, []string{"reason", "action"})
+	// ScrubPieceCounter records total piece number scrubbed by piece scrubber
+	ScrubPieceCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "manager_scrub_piece_total",
+		Help: "Track manager service piece scrubber scrubs total piece number",
+	}, []string{"result"})
+	// ScrubRepairCounter records total repair number triggered by piece scrubber
+	ScrubRepairCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "manager_scrub_repair_total",
+		Help: "Track manager service piece scrubber triggers total repair number",
+	}, []string{"success_or_failure"})
+	// PieceCorruptCounter records total corrupt piece number found by verifying read
+	PieceCorruptCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "piece_store_corrupt_piece_total",
+		Help: "Track the corrupt piece number found by verifying read of piece store",
+	}, []string{serviceLabelName})
+	// P2PApprovalDecisionCounter records total replicate approval request number decided by approval policies
+	P2PApprovalDecisionCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "p2p_approval_decision_total",
+		Help: "Track the replicate approval request number accepted or refused by p2p approval policies",
+	}, []string{"decision", "policy"})
+	// PieceTierMigrationCounter records total piece number handled by piece tier migrator
+	PieceTierMigrationCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "manager_piece_tier_migration_total",
+		Help: "Track manager service piece tier migrator migrates or keeps total hot piece number",
+	}, []string{"result"})
+	// PieceTierMigratedBytesCounter records total piece bytes moved from hot tier to cold tier
+	PieceTierMigratedBytesCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "manager_piece_tier_migrated_bytes_total",
+		Help: "Track manager service piece tier migrator moves total piece bytes from hot tier to cold tier",
+	}, []string{serviceLabelName})
 )
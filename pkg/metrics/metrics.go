@@ -99,7 +99,9 @@ func (m *Metrics) registerMetricItems() {
 	m.registry.MustRegister(DefaultGRPCServerMetrics, DefaultGRPCClientMetrics, DefaultHTTPServerMetrics,
 		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}), BlockHeightLagGauge,
 		SealObjectTimeHistogram, SealObjectTotalCounter, ReplicateObjectTaskGauge, PieceStoreTimeHistogram,
-		PieceStoreRequestTotal, SPDBTimeHistogram)
+		PieceStoreRequestTotal, PieceStoreCacheCounter, SPDBTimeHistogram, GCExhaustedTaskGauge, OrphanPieceScannedCounter,
+		OrphanPieceCounter, ScrubPieceCounter, ScrubRepairCounter, PieceCorruptCounter,
+		P2PApprovalDecisionCounter, PieceTierMigrationCounter, PieceTierMigratedBytesCounter)
 }
 
 func (m *Metrics) serve() {
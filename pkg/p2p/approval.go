@@ -12,6 +12,7 @@ import (
 	"github.com/libp2p/go-libp2p/core/network"
 
 	"github.com/bnb-chain/greenfield-storage-provider/pkg/log"
+	"github.com/bnb-chain/greenfield-storage-provider/pkg/metrics"
 	"github.com/bnb-chain/greenfield-storage-provider/pkg/p2p/types"
 )
 
@@ -25,12 +26,20 @@ const ResponseChannelSize = 9
 // ValidApprovalDuration defines the default approval validity period
 const ValidApprovalDuration = "1h"
 
+// define the decisions of approval request
+const (
+	approvalAccepted = "accepted"
+	approvalRefused  = "refused"
+)
+
 // ApprovalProtocol define the approval protocol and callback
 // maintains requests for getting approvals in memory
 type ApprovalProtocol struct {
 	node     *Node
 	response map[uint64]chan *types.GetApprovalResponse
 	mux      sync.RWMutex
+	policies []ApprovalPolicy
+	policyMu sync.RWMutex
 }
 
 // NewApprovalProtocol return an instance of ApprovalProtocol
@@ -38,6 +47,7 @@ func NewApprovalProtocol(host *Node) *ApprovalProtocol {
 	approval := &ApprovalProtocol{
 		node:     host,
 		response: make(map[uint64]chan *types.GetApprovalResponse),
+		policies: NewApprovalPolicies(&host.config.ApprovalPolicy),
 	}
 	host.node.SetStreamHandler(GetApprovalRequest, approval.onGetApprovalRequest)
 	host.node.SetStreamHandler(GetApprovalResponse, approval.onGetApprovalResponse)
@@ -83,6 +93,47 @@ func (a *ApprovalProtocol) cancelApprovalRequest(id uint64) {
 	delete(a.response, id)
 }
 
+// registerPolicy appends the approval policy to the policy chain, the refundable policies are kept at the
+// end of the chain so that the refused requests do not consume their resource.
+func (a *ApprovalProtocol) registerPolicy(policy ApprovalPolicy) {
+	a.policyMu.Lock()
+	defer a.policyMu.Unlock()
+	i := len(a.policies)
+	if _, ok := policy.(refundablePolicy); !ok {
+		for i > 0 {
+			if _, ok = a.policies[i-1].(refundablePolicy); !ok {
+				break
+			}
+			i--
+		}
+	}
+	a.policies = append(a.policies[:i], append([]ApprovalPolicy{policy}, a.policies[i:]...)...)
+}
+
+// decide checks the approval request by the policy chain in order, returns the name of the first policy
+// refusing the request and the refused reason, the empty reason means the request is accepted.
+func (a *ApprovalProtocol) decide(req *types.GetApprovalRequest) (policy string, reason string) {
+	a.policyMu.RLock()
+	defer a.policyMu.RUnlock()
+	for _, p := range a.policies {
+		if err := p.Check(req); err != nil {
+			return p.Name(), err.Error()
+		}
+	}
+	return "", ""
+}
+
+// refund gives back the resource consumed by the accepted request which fails to be responded.
+func (a *ApprovalProtocol) refund(req *types.GetApprovalRequest) {
+	a.policyMu.RLock()
+	defer a.policyMu.RUnlock()
+	for _, p := range a.policies {
+		if rp, ok := p.(refundablePolicy); ok {
+			rp.Refund(req)
+		}
+	}
+}
+
 // onGetApprovalRequest defines the get approval request protocol callback
 func (a *ApprovalProtocol) onGetApprovalRequest(s network.Stream) {
 	req := &types.GetApprovalRequest{}
@@ -122,13 +173,29 @@ func (a *ApprovalProtocol) onGetApprovalRequest(s network.Stream) {
 		SpOperatorAddress: a.node.SpOperatorAddress,
 		ExpiredTime:       time.Now().Add(validTime).Unix(),
 	}
-	// TODO:: customized approval strategy, if refuse will fill back resp refuse field
+	policy, reason := a.decide(req)
+	if len(reason) != 0 {
+		resp.RefusedReason = reason
+		metrics.P2PApprovalDecisionCounter.WithLabelValues(approvalRefused, policy).Inc()
+		log.Infow("refuse approval request", "sp", req.GetSpOperatorAddress(),
+			"object_id", req.GetObjectInfo().Id.Uint64(), "policy", policy, "reason", reason)
+	} else {
+		metrics.P2PApprovalDecisionCounter.WithLabelValues(approvalAccepted, "").Inc()
+		log.Debugw("accept approval request", "sp", req.GetSpOperatorAddress(),
+			"object_id", req.GetObjectInfo().Id.Uint64())
+	}
 	resp, err = a.node.signer.SignReplicateApprovalRspMsg(context.Background(), resp)
 	if err != nil {
 		log.Errorw("failed to sign get approval response msg", "local", s.Conn().LocalPeer(), "remote", s.Conn().RemotePeer(), "error", err)
+		if len(reason) == 0 {
+			a.refund(req)
+		}
 		return
 	}
 	err = a.node.sendToPeer(s.Conn().RemotePeer(), GetApprovalResponse, resp)
+	if err != nil && len(reason) == 0 {
+		a.refund(req)
+	}
 	log.Infof("%s response to %s approval request, error: %s",
 		s.Conn().LocalPeer(), s.Conn().RemotePeer(), err)
 }
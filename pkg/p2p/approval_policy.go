@@ -0,0 +1,263 @@
+package p2p
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bnb-chain/greenfield-storage-provider/model"
+	"github.com/bnb-chain/greenfield-storage-provider/pkg/log"
+	"github.com/bnb-chain/greenfield-storage-provider/pkg/p2p/types"
+	"github.com/bnb-chain/greenfield-storage-provider/pkg/rcmgr"
+)
+
+// define the names of built-in approval policies
+const (
+	ObjectSizePolicyName   = "object_size"
+	ContentTypePolicyName  = "content_type"
+	SPAccessPolicyName     = "sp_access"
+	FreeCapacityPolicyName = "free_capacity"
+	TaskNodeLoadPolicyName = "task_node_load"
+	SPQuotaPolicyName      = "sp_quota"
+)
+
+// ApprovalPolicy defines the strategy of deciding whether to accept the replicate approval request
+// from other sp, the approval request is refused if Check returns non-nil error, and the error message
+// is filled back to the refused reason field of approval response.
+type ApprovalPolicy interface {
+	// Name returns the name of approval policy
+	Name() string
+	// Check returns non-nil error if the approval request should be refused
+	Check(req *types.GetApprovalRequest) error
+}
+
+// refundablePolicy defines the approval policy which consumes the resource of the accepted request, the
+// resource is refunded if the accepted approval fails to be signed or sent.
+type refundablePolicy interface {
+	ApprovalPolicy
+	// Refund gives back the resource consumed by the accepted request
+	Refund(req *types.GetApprovalRequest)
+}
+
+// ApprovalPolicyConfig defines the configuration of built-in approval policies, the zero value of
+// each field disables the corresponding policy.
+type ApprovalPolicyConfig struct {
+	// MaxObjectSize defines the max payload size of object that can be accepted
+	MaxObjectSize uint64
+	// AllowedContentTypes defines the content types that can be accepted, empty means all
+	AllowedContentTypes []string
+	// DeniedContentTypes defines the content types that are refused
+	DeniedContentTypes []string
+	// AllowedSPs defines the operator addresses of sp whose requests can be accepted, empty means all
+	AllowedSPs []string
+	// DeniedSPs defines the operator addresses of sp whose requests are refused
+	DeniedSPs []string
+	// DataPath defines the local path to check free capacity, usually the piece store directory
+	DataPath string
+	// MinFreeCapacity defines the min free capacity in bytes of DataPath to accept requests
+	MinFreeCapacity uint64
+	// TaskNodeLoadProbeSize defines the memory size in bytes probed in task node resource scope, the
+	// requests are refused if the probe fails, which means the task node is busy
+	TaskNodeLoadProbeSize int
+	// MaxApprovalsPerSP defines the max number of accepted requests of each sp in the quota window
+	MaxApprovalsPerSP int
+	// QuotaWindowSeconds defines the duration of the per-sp quota window
+	QuotaWindowSeconds int64
+}
+
+// NewApprovalPolicies returns the built-in approval policies enabled by config, the per-sp quota policy
+// is the last one so that only the requests passed the other policies consume the quota.
+func NewApprovalPolicies(cfg *ApprovalPolicyConfig) []ApprovalPolicy {
+	var policies []ApprovalPolicy
+	if cfg == nil {
+		return policies
+	}
+	if cfg.MaxObjectSize > 0 {
+		policies = append(policies, &objectSizePolicy{maxSize: cfg.MaxObjectSize})
+	}
+	if len(cfg.AllowedContentTypes) > 0 || len(cfg.DeniedContentTypes) > 0 {
+		policies = append(policies, &contentTypePolicy{
+			allowed: newStringSet(cfg.AllowedContentTypes, strings.ToLower),
+			denied:  newStringSet(cfg.DeniedContentTypes, strings.ToLower),
+		})
+	}
+	if len(cfg.AllowedSPs) > 0 || len(cfg.DeniedSPs) > 0 {
+		policies = append(policies, &spAccessPolicy{
+			allowed: newStringSet(cfg.AllowedSPs, nil),
+			denied:  newStringSet(cfg.DeniedSPs, nil),
+		})
+	}
+	if cfg.MinFreeCapacity > 0 {
+		path := cfg.DataPath
+		if path == "" {
+			path = DefaultDataPath
+		}
+		policies = append(policies, &freeCapacityPolicy{path: path, minFree: cfg.MinFreeCapacity})
+	}
+	if cfg.TaskNodeLoadProbeSize > 0 {
+		policies = append(policies, &taskNodeLoadPolicy{probeSize: cfg.TaskNodeLoadProbeSize})
+	}
+	if cfg.MaxApprovalsPerSP > 0 && cfg.QuotaWindowSeconds > 0 {
+		policies = append(policies, &spQuotaPolicy{
+			maxApprovals: cfg.MaxApprovalsPerSP,
+			window:       time.Duration(cfg.QuotaWindowSeconds) * time.Second,
+			quotas:       make(map[string]*spQuota),
+		})
+	}
+	return policies
+}
+
+func newStringSet(items []string, normalize func(string) string) map[string]struct{} {
+	set := make(map[string]struct{}, len(items))
+	for _, item := range items {
+		item = strings.TrimSpace(item)
+		if normalize != nil {
+			item = normalize(item)
+		}
+		set[item] = struct{}{}
+	}
+	return set
+}
+
+// objectSizePolicy refuses the objects whose payload size is greater than max size
+type objectSizePolicy struct {
+	maxSize uint64
+}
+
+func (p *objectSizePolicy) Name() string { return ObjectSizePolicyName }
+
+func (p *objectSizePolicy) Check(req *types.GetApprovalRequest) error {
+	if size := req.GetObjectInfo().GetPayloadSize(); size > p.maxSize {
+		return fmt.Errorf("object size %d exceeds the max size %d", size, p.maxSize)
+	}
+	return nil
+}
+
+// contentTypePolicy refuses the objects whose content type is denied or not allowed
+type contentTypePolicy struct {
+	allowed map[string]struct{}
+	denied  map[string]struct{}
+}
+
+func (p *contentTypePolicy) Name() string { return ContentTypePolicyName }
+
+func (p *contentTypePolicy) Check(req *types.GetApprovalRequest) error {
+	contentType := strings.ToLower(strings.TrimSpace(req.GetObjectInfo().GetContentType()))
+	if _, ok := p.denied[contentType]; ok {
+		return fmt.Errorf("content type %s is denied", contentType)
+	}
+	if _, ok := p.allowed[contentType]; len(p.allowed) > 0 && !ok {
+		return fmt.Errorf("content type %s is not allowed", contentType)
+	}
+	return nil
+}
+
+// spAccessPolicy refuses the requests from the sp which is denied or not allowed
+type spAccessPolicy struct {
+	allowed map[string]struct{}
+	denied  map[string]struct{}
+}
+
+func (p *spAccessPolicy) Name() string { return SPAccessPolicyName }
+
+func (p *spAccessPolicy) Check(req *types.GetApprovalRequest) error {
+	sp := req.GetSpOperatorAddress()
+	if _, ok := p.denied[sp]; ok {
+		return fmt.Errorf("sp %s is denied", sp)
+	}
+	if _, ok := p.allowed[sp]; len(p.allowed) > 0 && !ok {
+		return fmt.Errorf("sp %s is not allowed", sp)
+	}
+	return nil
+}
+
+// freeCapacityPolicy refuses the requests if the free capacity of local path is not enough to store the object
+type freeCapacityPolicy struct {
+	path    string
+	minFree uint64
+}
+
+func (p *freeCapacityPolicy) Name() string { return FreeCapacityPolicyName }
+
+func (p *freeCapacityPolicy) Check(req *types.GetApprovalRequest) error {
+	free, err := getFreeCapacity(p.path)
+	if err != nil {
+		// the request is refused, because the replicated data may not be stored if the capacity is unknown
+		log.Warnw("failed to get free capacity", "path", p.path, "error", err)
+		return fmt.Errorf("unknown free capacity: %v", err)
+	}
+	if free < p.minFree+req.GetObjectInfo().GetPayloadSize() {
+		return fmt.Errorf("insufficient free capacity %d, min free capacity %d", free, p.minFree)
+	}
+	return nil
+}
+
+// taskNodeLoadPolicy refuses the requests if the task node is busy, it probes the memory reservation in
+// task node resource scope at medium priority, the probe is skipped if task node is not in this process.
+type taskNodeLoadPolicy struct {
+	probeSize int
+}
+
+func (p *taskNodeLoadPolicy) Name() string { return TaskNodeLoadPolicyName }
+
+func (p *taskNodeLoadPolicy) Check(req *types.GetApprovalRequest) error {
+	return rcmgr.ResrcManager().ViewService(model.TaskNodeService, func(scope rcmgr.ResourceScope) error {
+		span, err := scope.BeginSpan()
+		if err != nil {
+			return fmt.Errorf("task node is busy: %v", err)
+		}
+		defer span.Done()
+		if err = span.ReserveMemory(p.probeSize, rcmgr.ReservationPriorityMedium); err != nil {
+			return fmt.Errorf("task node is busy: %v", err)
+		}
+		return nil
+	})
+}
+
+// spQuotaPolicy refuses the requests if the sp runs out of its quota in the current window, it is always the
+// last policy of the policy chain so that only the requests passed the other policies consume the quota
+type spQuotaPolicy struct {
+	maxApprovals int
+	window       time.Duration
+	mux          sync.Mutex
+	quotas       map[string]*spQuota
+}
+
+type spQuota struct {
+	start time.Time
+	used  int
+}
+
+func (p *spQuotaPolicy) Name() string { return SPQuotaPolicyName }
+
+func (p *spQuotaPolicy) Check(req *types.GetApprovalRequest) error {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	now := time.Now()
+	sp := req.GetSpOperatorAddress()
+	quota, ok := p.quotas[sp]
+	if !ok || now.Sub(quota.start) >= p.window {
+		// gc the expired quotas of other sps by the way
+		for addr, q := range p.quotas {
+			if now.Sub(q.start) >= p.window {
+				delete(p.quotas, addr)
+			}
+		}
+		quota = &spQuota{start: now}
+		p.quotas[sp] = quota
+	}
+	if quota.used >= p.maxApprovals {
+		return fmt.Errorf("sp %s exceeds the quota %d in %s", sp, p.maxApprovals, p.window)
+	}
+	quota.used++
+	return nil
+}
+
+func (p *spQuotaPolicy) Refund(req *types.GetApprovalRequest) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	if quota, ok := p.quotas[req.GetSpOperatorAddress()]; ok && quota.used > 0 {
+		quota.used--
+	}
+}
@@ -0,0 +1,112 @@
+package p2p
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	storagetypes "github.com/bnb-chain/greenfield/x/storage/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnb-chain/greenfield-storage-provider/pkg/p2p/types"
+)
+
+func mockApprovalRequest(sp string, size uint64, contentType string) *types.GetApprovalRequest {
+	return &types.GetApprovalRequest{
+		ObjectInfo: &storagetypes.ObjectInfo{
+			PayloadSize: size,
+			ContentType: contentType,
+		},
+		SpOperatorAddress: sp,
+	}
+}
+
+func TestApprovalPolicies(t *testing.T) {
+	policies := NewApprovalPolicies(&ApprovalPolicyConfig{
+		MaxObjectSize:      1024,
+		DeniedContentTypes: []string{"Application/X-Msdownload"},
+		AllowedSPs:         []string{"sp1", "sp2"},
+		DeniedSPs:          []string{"sp2"},
+		MaxApprovalsPerSP:  2,
+		QuotaWindowSeconds: 3600,
+	})
+	approval := &ApprovalProtocol{policies: policies}
+	testCases := []struct {
+		name   string
+		req    *types.GetApprovalRequest
+		policy string
+	}{
+		{"accept", mockApprovalRequest("sp1", 1024, "text/plain"), ""},
+		{"too large object", mockApprovalRequest("sp1", 1025, "text/plain"), ObjectSizePolicyName},
+		{"denied content type", mockApprovalRequest("sp1", 1, "application/x-msdownload"), ContentTypePolicyName},
+		{"denied sp", mockApprovalRequest("sp2", 1, "text/plain"), SPAccessPolicyName},
+		{"not allowed sp", mockApprovalRequest("sp3", 1, "text/plain"), SPAccessPolicyName},
+		{"accept within quota", mockApprovalRequest("sp1", 1, "text/plain"), ""},
+		{"exceed quota", mockApprovalRequest("sp1", 1, "text/plain"), SPQuotaPolicyName},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, reason := approval.decide(tt.req)
+			require.Equal(t, tt.policy, policy)
+			require.Equal(t, tt.policy == "", reason == "")
+		})
+	}
+}
+
+func TestApprovalPolicies_Disabled(t *testing.T) {
+	require.Empty(t, NewApprovalPolicies(nil))
+	require.Empty(t, NewApprovalPolicies(&ApprovalPolicyConfig{MaxApprovalsPerSP: 1}))
+}
+
+type mockApprovalPolicy struct {
+	name    string
+	checked int
+}
+
+func (p *mockApprovalPolicy) Name() string { return p.name }
+
+func (p *mockApprovalPolicy) Check(req *types.GetApprovalRequest) error {
+	p.checked++
+	return errors.New("refused by mock policy")
+}
+
+func TestApprovalPolicies_RegisterBeforeQuota(t *testing.T) {
+	approval := &ApprovalProtocol{policies: NewApprovalPolicies(&ApprovalPolicyConfig{
+		MaxApprovalsPerSP:  1,
+		QuotaWindowSeconds: 3600,
+	})}
+	mock := &mockApprovalPolicy{name: "mock"}
+	approval.registerPolicy(mock)
+	require.Equal(t, SPQuotaPolicyName, approval.policies[len(approval.policies)-1].Name())
+
+	// the request refused by the registered policy does not consume the quota
+	policy, _ := approval.decide(mockApprovalRequest("sp1", 1, "text/plain"))
+	require.Equal(t, "mock", policy)
+	require.Equal(t, 1, mock.checked)
+	quota := approval.policies[len(approval.policies)-1].(*spQuotaPolicy)
+	require.Empty(t, quota.quotas)
+}
+
+func TestApprovalPolicies_Refund(t *testing.T) {
+	approval := &ApprovalProtocol{policies: NewApprovalPolicies(&ApprovalPolicyConfig{
+		MaxApprovalsPerSP:  1,
+		QuotaWindowSeconds: 3600,
+	})}
+	req := mockApprovalRequest("sp1", 1, "text/plain")
+	policy, _ := approval.decide(req)
+	require.Equal(t, "", policy)
+	policy, _ = approval.decide(req)
+	require.Equal(t, SPQuotaPolicyName, policy)
+
+	// the quota of the approval which fails to be signed is refunded
+	approval.refund(req)
+	policy, _ = approval.decide(req)
+	require.Equal(t, "", policy)
+}
+
+func TestFreeCapacityPolicy_UnknownCapacity(t *testing.T) {
+	policy := &freeCapacityPolicy{path: filepath.Join(t.TempDir(), "not-exist"), minFree: 1}
+	require.Error(t, policy.Check(mockApprovalRequest("sp1", 1, "text/plain")))
+	policy.path = t.TempDir()
+	require.NoError(t, policy.Check(mockApprovalRequest("sp1", 1, "text/plain")))
+}
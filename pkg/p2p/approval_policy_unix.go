@@ -0,0 +1,17 @@
+//go:build !windows
+// +build !windows
+
+package p2p
+
+import (
+	"syscall"
+)
+
+// getFreeCapacity returns the free capacity in bytes of the file system which the path is located in
+func getFreeCapacity(path string) (uint64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, err
+	}
+	return stat.Bavail * uint64(stat.Bsize), nil
+}
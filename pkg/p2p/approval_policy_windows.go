@@ -0,0 +1,13 @@
+//go:build windows
+// +build windows
+
+package p2p
+
+import (
+	"errors"
+)
+
+// getFreeCapacity is not supported on windows
+func getFreeCapacity(path string) (uint64, error) {
+	return 0, errors.New("free capacity is not supported on windows")
+}
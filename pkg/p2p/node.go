@@ -82,7 +82,7 @@ func NewNode(config *NodeConfig, SPAddr string, signer *signerclient.SignerClien
 		SpOperatorAddress: SPAddr,
 		node:              host,
 		signer:            signer,
-		peers:             NewPeerProvider(store),
+		peers:             NewPeerProvider(store, ds),
 		persistentDB:      ds,
 		stopCh:            make(chan struct{}),
 	}
@@ -104,35 +104,76 @@ func (n *Node) Name() string {
 	return P2PNode
 }
 
-// Start runs background task that trigger broadcast ping request
+// Start reloads the peers known before restart and re-dials them, and runs background task that
+// trigger broadcast ping request
 func (n *Node) Start(ctx context.Context) error {
+	peers, err := n.peers.LoadPeers()
+	if err != nil {
+		log.Warnw("failed to load persisted peers", "error", err)
+	}
+	go n.redialPeers(peers)
 	go n.eventLoop()
 	return nil
 }
 
-// Stop recycle the resources and termination background goroutine
+// Stop recycle the resources and termination background goroutine, the changed peers are flushed
+// before closing the peer store
 func (n *Node) Stop(ctx context.Context) error {
 	close(n.stopCh)
+	n.peers.Close()
 	n.persistentDB.Close()
 	return nil
 }
 
+// RegisterApprovalPolicy appends the customized approval policy after the built-in ones except the per-sp
+// quota policy, which is always the last one, the replicate approval request is accepted only if all the
+// policies pass.
+func (n *Node) RegisterApprovalPolicy(policy ApprovalPolicy) {
+	n.approval.registerPolicy(policy)
+}
+
 // PeersProvider returns the p2p peers provider
 func (n *Node) PeersProvider() *PeerProvider {
 	return n.peers
 }
 
-// GetApproval broadcast get approval request and blocking goroutine until timeout or
-// collect expect accept approval response number
-func (n *Node) GetApproval(object *storagetypes.ObjectInfo, expectedAccept int, timeout int64) (
-	accept map[string]*types.GetApprovalResponse, refuse map[string]*types.GetApprovalResponse, err error) {
+// ApprovalResult defines the outcome of soliciting the replicate approvals from other storage providers
+type ApprovalResult struct {
+	// Accept defines the accepted approvals, key is the sp operator address
+	Accept map[string]*types.GetApprovalResponse
+	// Refuse defines the refused approvals with reason, key is the sp operator address
+	Refuse map[string]*types.GetApprovalResponse
+	// Timeout defines the operator addresses of sps which received the request but did not respond before timeout
+	Timeout []string
+	// Pending defines the operator addresses of sps which received the request but had not responded when
+	// the expected approvals were collected, they are not regarded as failed
+	Pending []string
+	// Unreachable defines the operator addresses of sps which have no connected peer to send the request to
+	Unreachable []string
+}
+
+// GetApproval broadcast get approval request to the storage providers except the excluded ones, and blocking
+// goroutine until timeout, collect expect accept approval response number or all the solicited storage
+// providers respond.
+func (n *Node) GetApproval(object *storagetypes.ObjectInfo, expectedAccept int, timeout int64, excludedSPs []string) (
+	result *ApprovalResult, err error) {
 	approvalCh, err := n.approval.hangApprovalRequest(object.Id.Uint64())
 	if err != nil {
 		return
 	}
 	defer n.approval.cancelApprovalRequest(object.Id.Uint64())
-	accept = make(map[string]*types.GetApprovalResponse)
-	refuse = make(map[string]*types.GetApprovalResponse)
+	excluded := make(map[string]struct{}, len(excludedSPs)+1)
+	for _, sp := range excludedSPs {
+		excluded[sp] = struct{}{}
+	}
+	excluded[n.SpOperatorAddress] = struct{}{}
+	var solicited []string
+	for _, sp := range n.peers.SPs() {
+		if _, ok := excluded[sp]; !ok {
+			solicited = append(solicited, sp)
+		}
+	}
+
 	getApprovalReq := &types.GetApprovalRequest{
 		ObjectInfo:        object,
 		SpOperatorAddress: n.SpOperatorAddress,
@@ -142,32 +183,72 @@ func (n *Node) GetApproval(object *storagetypes.ObjectInfo, expectedAccept int,
 		log.Errorw("failed to sign the get approval request", "object_id", object.Id.Uint64())
 		return
 	}
-	n.broadcast(GetApprovalRequest, getApprovalReq)
-	ticker := time.NewTicker(time.Duration(timeout) * time.Second)
+	reached := n.broadcastExcept(GetApprovalRequest, getApprovalReq, excluded)
+	return collectApprovals(approvalCh, solicited, reached, excluded, expectedAccept,
+		time.Duration(timeout)*time.Second), nil
+}
+
+// collectApprovals collects the approval responses until timeout, expectedAccept approvals are accepted or all
+// the solicited sps respond, and classifies the silent sps by whether the request reached them and whether
+// the collection is stopped by timeout.
+func collectApprovals(approvalCh <-chan *types.GetApprovalResponse, solicited []string, reached,
+	excluded map[string]struct{}, expectedAccept int, timeout time.Duration) *ApprovalResult {
+	result := &ApprovalResult{
+		Accept: make(map[string]*types.GetApprovalResponse),
+		Refuse: make(map[string]*types.GetApprovalResponse),
+	}
+	timedOut := false
+	defer func() {
+		for _, sp := range solicited {
+			_, accepted := result.Accept[sp]
+			_, refused := result.Refuse[sp]
+			if accepted || refused {
+				continue
+			}
+			if _, ok := reached[sp]; !ok {
+				result.Unreachable = append(result.Unreachable, sp)
+			} else if timedOut {
+				result.Timeout = append(result.Timeout, sp)
+			} else {
+				result.Pending = append(result.Pending, sp)
+			}
+		}
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
 	for {
 		select {
 		case approval := <-approvalCh:
+			if _, ok := excluded[approval.GetSpOperatorAddress()]; ok {
+				continue
+			}
 			if approval.GetExpiredTime() <= time.Now().Unix() {
 				log.Warnw("discard expired approval", "sp", approval.GetSpOperatorAddress(),
 					"object_id", approval.GetObjectInfo().Id.Uint64(), "expire_time", approval.GetExpiredTime())
 				continue
 			}
 			if len(approval.GetRefusedReason()) != 0 {
-				if _, ok := refuse[approval.GetSpOperatorAddress()]; !ok {
-					refuse[approval.GetSpOperatorAddress()] = approval
-					delete(accept, approval.GetSpOperatorAddress())
+				if _, ok := result.Refuse[approval.GetSpOperatorAddress()]; !ok {
+					result.Refuse[approval.GetSpOperatorAddress()] = approval
+					delete(result.Accept, approval.GetSpOperatorAddress())
 				}
 			} else {
-				delete(refuse, approval.GetSpOperatorAddress())
-				if _, ok := accept[approval.GetSpOperatorAddress()]; !ok {
-					accept[approval.GetSpOperatorAddress()] = approval
+				delete(result.Refuse, approval.GetSpOperatorAddress())
+				if _, ok := result.Accept[approval.GetSpOperatorAddress()]; !ok {
+					result.Accept[approval.GetSpOperatorAddress()] = approval
 				}
-				if len(accept) >= expectedAccept {
-					return
+				if len(result.Accept) >= expectedAccept {
+					return result
 				}
 			}
-		case <-ticker.C:
-			return
+			if len(solicited) != 0 && len(result.Accept)+len(result.Refuse) >= len(solicited) {
+				// all the solicited sps respond, no need to wait for timeout
+				return result
+			}
+		case <-timer.C:
+			timedOut = true
+			return result
 		}
 	}
 }
@@ -175,10 +256,14 @@ func (n *Node) GetApproval(object *storagetypes.ObjectInfo, expectedAccept int,
 // eventLoop run the background task
 func (n *Node) eventLoop() {
 	ticker := time.NewTicker(time.Duration(n.config.PingPeriod) * time.Second)
+	flushTicker := time.NewTicker(PeerFlushPeriod * time.Second)
+	defer flushTicker.Stop()
 	for {
 		select {
 		case <-n.stopCh:
 			return
+		case <-flushTicker.C:
+			n.peers.Flush()
 		case <-ticker.C:
 			// TODO:: send to signer and back fill the signature field
 			ping := &types.Ping{
@@ -194,6 +279,34 @@ func (n *Node) eventLoop() {
 	}
 }
 
+// redialPeers re-dials the peers known before restart, the failed ones are counted by DeletePeer
+func (n *Node) redialPeers(peers []*Peer) {
+	var connected int
+	for _, p := range peers {
+		select {
+		case <-n.stopCh:
+			return
+		default:
+		}
+		if strings.Compare(n.node.ID().String(), p.ID().String()) == 0 {
+			continue
+		}
+		if p.addr != nil {
+			n.node.Peerstore().AddAddr(p.ID(), p.addr, peerstore.PermanentAddrTTL)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(DailTimeout)*time.Second)
+		err := n.node.Connect(ctx, peer.AddrInfo{ID: p.ID(), Addrs: n.node.Peerstore().Addrs(p.ID())})
+		cancel()
+		if err != nil {
+			log.Debugw("failed to redial peer", "peer_id", p.ID(), "error", err)
+			n.peers.DeletePeer(p.ID())
+			continue
+		}
+		connected++
+	}
+	log.Infow("finish redialing persisted peers", "peer_number", len(peers), "connected_number", connected)
+}
+
 // broadcast sends request to all p2p nodes
 func (n *Node) broadcast(pc protocol.ID, data proto.Message) {
 	for _, peerID := range n.node.Peerstore().PeersWithAddrs() {
@@ -205,6 +318,25 @@ func (n *Node) broadcast(pc protocol.ID, data proto.Message) {
 	}
 }
 
+// broadcastExcept sends request to the p2p nodes whose storage provider is not excluded, the nodes whose
+// storage provider is unspecified are included, returns the storage providers which the request is sent to
+func (n *Node) broadcastExcept(pc protocol.ID, data proto.Message, excludedSPs map[string]struct{}) map[string]struct{} {
+	reached := make(map[string]struct{})
+	for _, peerID := range n.node.Peerstore().PeersWithAddrs() {
+		if strings.Compare(n.node.ID().String(), peerID.String()) == 0 {
+			continue
+		}
+		sp := n.peers.PeerSP(peerID)
+		if _, ok := excludedSPs[sp]; ok {
+			continue
+		}
+		if err := n.sendToPeer(peerID, pc, data); err == nil && sp != PeerSpUnspecified {
+			reached[sp] = struct{}{}
+		}
+	}
+	return reached
+}
+
 // sendToPeer sends request to all special p2p node
 func (n *Node) sendToPeer(peerID peer.ID, pc protocol.ID, data proto.Message) error {
 	host := n.node
@@ -21,6 +21,8 @@ const (
 	PingPeriodMin = 1
 	// DailTimeout defines default value that the timeout of dail other p2p node
 	DailTimeout = 1
+	// PeerFlushPeriod defines the period in seconds of persisting the changed peers to the peer store
+	PeerFlushPeriod = 10
 	// DefaultDataPath defines default value that the path of peer store
 	DefaultDataPath = "./data"
 	// P2PNode defines the p2p protocol node name
@@ -40,6 +42,8 @@ type NodeConfig struct {
 	Bootstrap []string
 	// PingPeriod defines the period of ping other p2p nodes
 	PingPeriod int
+	// ApprovalPolicy defines the policies of accepting the replicate approval requests from other sps
+	ApprovalPolicy ApprovalPolicyConfig
 }
 
 // overrideConfigFromEnv load private key from ENV var
@@ -0,0 +1,77 @@
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	sdkmath "cosmossdk.io/math"
+	storagetypes "github.com/bnb-chain/greenfield/x/storage/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnb-chain/greenfield-storage-provider/pkg/p2p/types"
+)
+
+func mockApprovalResponse(sp string, refusedReason string) *types.GetApprovalResponse {
+	return &types.GetApprovalResponse{
+		ObjectInfo:        &storagetypes.ObjectInfo{Id: sdkmath.NewUint(1)},
+		SpOperatorAddress: sp,
+		ExpiredTime:       time.Now().Add(time.Hour).Unix(),
+		RefusedReason:     refusedReason,
+	}
+}
+
+func newSPSet(sps ...string) map[string]struct{} {
+	set := make(map[string]struct{}, len(sps))
+	for _, sp := range sps {
+		set[sp] = struct{}{}
+	}
+	return set
+}
+
+func TestCollectApprovals_Timeout(t *testing.T) {
+	approvalCh := make(chan *types.GetApprovalResponse, 10)
+	approvalCh <- mockApprovalResponse("sp1", "")
+	approvalCh <- mockApprovalResponse("sp2", "busy")
+	// the responses of the excluded sps and the expired approvals are discarded
+	approvalCh <- mockApprovalResponse("sp0", "")
+	expired := mockApprovalResponse("sp3", "")
+	expired.ExpiredTime = time.Now().Unix() - 1
+	approvalCh <- expired
+
+	result := collectApprovals(approvalCh, []string{"sp1", "sp2", "sp3", "sp4"}, newSPSet("sp1", "sp2", "sp3"),
+		newSPSet("sp0"), 3, 100*time.Millisecond)
+	require.Len(t, result.Accept, 1)
+	require.Contains(t, result.Accept, "sp1")
+	require.Len(t, result.Refuse, 1)
+	require.Contains(t, result.Refuse, "sp2")
+	require.Equal(t, []string{"sp3"}, result.Timeout)
+	require.Empty(t, result.Pending)
+	require.Equal(t, []string{"sp4"}, result.Unreachable)
+}
+
+func TestCollectApprovals_EnoughAccepted(t *testing.T) {
+	approvalCh := make(chan *types.GetApprovalResponse, 10)
+	approvalCh <- mockApprovalResponse("sp1", "")
+	approvalCh <- mockApprovalResponse("sp2", "")
+
+	// the sps not responding before the expected approvals are collected are pending rather than timeout
+	result := collectApprovals(approvalCh, []string{"sp1", "sp2", "sp3", "sp4"}, newSPSet("sp1", "sp2", "sp3"),
+		nil, 2, time.Hour)
+	require.Len(t, result.Accept, 2)
+	require.Empty(t, result.Timeout)
+	require.Equal(t, []string{"sp3"}, result.Pending)
+	require.Equal(t, []string{"sp4"}, result.Unreachable)
+}
+
+func TestCollectApprovals_AllResponded(t *testing.T) {
+	approvalCh := make(chan *types.GetApprovalResponse, 10)
+	approvalCh <- mockApprovalResponse("sp1", "busy")
+	approvalCh <- mockApprovalResponse("sp2", "")
+
+	result := collectApprovals(approvalCh, []string{"sp1", "sp2"}, newSPSet("sp1", "sp2"), nil, 2, time.Hour)
+	require.Len(t, result.Accept, 1)
+	require.Len(t, result.Refuse, 1)
+	require.Empty(t, result.Timeout)
+	require.Empty(t, result.Pending)
+	require.Empty(t, result.Unreachable)
+}
@@ -0,0 +1,250 @@
+package p2p
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+
+	"github.com/bnb-chain/greenfield-storage-provider/pkg/log"
+)
+
+// define the key prefixes of PeerProvider states in the persistent db
+const (
+	peerKeyPrefix = "/peer_provider/peer/"
+	spKeyPrefix   = "/peer_provider/sp/"
+)
+
+// persistentPeer defines the peer info persisted in db
+type persistentPeer struct {
+	SP      string `json:"sp"`
+	Addr    string `json:"addr"`
+	Last    int64  `json:"last"`
+	FailCnt int    `json:"fail_cnt"`
+}
+
+// savePeer marks the peer info to be persisted by the next Flush, so that the peer can be reloaded after restart
+// notice: no lock for save peer, only be called by the PeerProvider methods holding lock
+func (pr *PeerProvider) savePeer(p *Peer) {
+	if pr.persistentDB == nil {
+		return
+	}
+	pr.dirtyPeers[p.peerID] = true
+}
+
+// removePeer marks the persisted peer info to be deleted by the next Flush
+// notice: no lock for remove peer, only be called by the PeerProvider methods holding lock
+func (pr *PeerProvider) removePeer(peerID peer.ID) {
+	if pr.persistentDB == nil {
+		return
+	}
+	pr.dirtyPeers[peerID] = false
+}
+
+// Flush persists the peers and the storage providers changed since the last flush in one batch, the db is
+// written outside the lock of PeerProvider, so that AddPeer and DeletePeer are never blocked by the db. Only
+// the added and the stale storage providers are written. The changes are kept for the next flush if the
+// batch fails to commit.
+func (pr *PeerProvider) Flush() {
+	if pr.persistentDB == nil {
+		return
+	}
+	pr.flushMux.Lock()
+	defer pr.flushMux.Unlock()
+	pr.flush()
+}
+
+// Close flushes the changed peers for the last time, the later flushes are ignored so that the persistent
+// db can be closed safely.
+func (pr *PeerProvider) Close() {
+	if pr.persistentDB == nil {
+		return
+	}
+	pr.flushMux.Lock()
+	defer pr.flushMux.Unlock()
+	if !pr.closed {
+		pr.flush()
+		pr.closed = true
+	}
+}
+
+// flush persists the changed peers and storage providers
+// notice: only be called by Flush and Close holding the flush lock
+func (pr *PeerProvider) flush() {
+	if pr.closed {
+		return
+	}
+
+	// the nil value means the persisted peer should be deleted
+	peers := make(map[peer.ID][]byte)
+	var addSPs, staleSPs []string
+	pr.mux.Lock()
+	for peerID, save := range pr.dirtyPeers {
+		node, ok := pr.peers[peerID]
+		if !save || !ok {
+			peers[peerID] = nil
+			continue
+		}
+		record := &persistentPeer{SP: node.sp, Last: node.last, FailCnt: node.failCnt}
+		if node.addr != nil {
+			record.Addr = node.addr.String()
+		}
+		value, err := json.Marshal(record)
+		if err != nil {
+			log.Errorw("failed to marshal peer", "node_id", peerID.String(), "error", err)
+			continue
+		}
+		peers[peerID] = value
+	}
+	pr.dirtyPeers = make(map[peer.ID]bool)
+	for sp := range pr.spPeers {
+		if _, ok := pr.persistedSPs[sp]; !ok && sp != PeerSpUnspecified {
+			addSPs = append(addSPs, sp)
+		}
+	}
+	for sp := range pr.persistedSPs {
+		if _, ok := pr.spPeers[sp]; !ok {
+			staleSPs = append(staleSPs, sp)
+		}
+	}
+	pr.mux.Unlock()
+	if len(peers) == 0 && len(addSPs) == 0 && len(staleSPs) == 0 {
+		return
+	}
+
+	if err := pr.commit(peers, addSPs, staleSPs); err != nil {
+		log.Errorw("failed to persist peers", "peer_number", len(peers), "error", err)
+		pr.mux.Lock()
+		for peerID, value := range peers {
+			if _, ok := pr.dirtyPeers[peerID]; !ok {
+				pr.dirtyPeers[peerID] = value != nil
+			}
+		}
+		pr.mux.Unlock()
+		return
+	}
+	pr.mux.Lock()
+	for _, sp := range addSPs {
+		pr.persistedSPs[sp] = struct{}{}
+	}
+	for _, sp := range staleSPs {
+		delete(pr.persistedSPs, sp)
+	}
+	pr.mux.Unlock()
+}
+
+// commit writes the peers and the storage providers to the persistent db in one batch
+func (pr *PeerProvider) commit(peers map[peer.ID][]byte, addSPs, staleSPs []string) error {
+	ctx := context.Background()
+	batch, err := pr.persistentDB.Batch(ctx)
+	if err != nil {
+		return err
+	}
+	for peerID, value := range peers {
+		key := ds.NewKey(peerKeyPrefix + peerID.String())
+		if value == nil {
+			err = batch.Delete(ctx, key)
+		} else {
+			err = batch.Put(ctx, key, value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	for _, sp := range staleSPs {
+		if err = batch.Delete(ctx, ds.NewKey(spKeyPrefix+sp)); err != nil {
+			return err
+		}
+	}
+	for _, sp := range addSPs {
+		if err = batch.Put(ctx, ds.NewKey(spKeyPrefix+sp), []byte{}); err != nil {
+			return err
+		}
+	}
+	return batch.Commit(ctx)
+}
+
+// listKeys returns the key suffixes after the prefix in the persistent db
+func (pr *PeerProvider) listKeys(prefix string) ([]string, error) {
+	results, err := pr.persistentDB.Query(context.Background(), query.Query{Prefix: prefix, KeysOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer results.Close()
+	var keys []string
+	for result := range results.Next() {
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		keys = append(keys, strings.TrimPrefix(result.Key, prefix))
+	}
+	return keys, nil
+}
+
+// LoadPeers reloads the storage providers, the peers and their fail counters persisted before restart,
+// and returns the reloaded peers which should be re-dialed.
+func (pr *PeerProvider) LoadPeers() ([]*Peer, error) {
+	if pr.persistentDB == nil {
+		return nil, nil
+	}
+	pr.mux.Lock()
+	defer pr.mux.Unlock()
+	sps, err := pr.listKeys(spKeyPrefix)
+	if err != nil {
+		return nil, err
+	}
+	for _, sp := range sps {
+		pr.persistedSPs[sp] = struct{}{}
+		if _, ok := pr.spPeers[sp]; !ok {
+			pr.spPeers[sp] = make([]*Peer, 0)
+		}
+	}
+
+	results, err := pr.persistentDB.Query(context.Background(), query.Query{Prefix: peerKeyPrefix})
+	if err != nil {
+		return nil, err
+	}
+	defer results.Close()
+	var peers []*Peer
+	for result := range results.Next() {
+		if result.Error != nil {
+			return peers, result.Error
+		}
+		peerID, err := peer.Decode(strings.TrimPrefix(result.Key, peerKeyPrefix))
+		if err != nil {
+			log.Warnw("skip invalid persisted peer", "key", result.Key, "error", err)
+			continue
+		}
+		record := &persistentPeer{}
+		if err = json.Unmarshal(result.Value, record); err != nil {
+			log.Warnw("skip invalid persisted peer", "key", result.Key, "error", err)
+			continue
+		}
+		if _, ok := pr.peers[peerID]; ok {
+			continue
+		}
+		node := &Peer{
+			peerID:  peerID,
+			sp:      record.SP,
+			last:    record.Last,
+			failCnt: record.FailCnt,
+		}
+		if len(record.Addr) != 0 {
+			if node.addr, err = ma.NewMultiaddr(record.Addr); err != nil {
+				log.Warnw("skip invalid persisted peer addr", "node_id", peerID.String(), "error", err)
+			}
+		}
+		if _, ok := pr.spPeers[node.sp]; !ok {
+			node.sp = PeerSpUnspecified
+		}
+		pr.peers[peerID] = node
+		pr.spPeers[node.sp] = append(pr.spPeers[node.sp], node)
+		peers = append(peers, node)
+	}
+	log.Infow("load persisted peers", "sp_number", len(sps), "peer_number", len(peers))
+	return peers, nil
+}
@@ -0,0 +1,94 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+
+	ds "github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeerProvider_LoadPeers(t *testing.T) {
+	db := dssync.MutexWrap(ds.NewMapDatastore())
+	peerID, err := peer.Decode("16Uiu2HAmBzdPttaxicSDEf5Kq1XBnoH97wRFA8aiWEnYc2hp2ZHW")
+	require.NoError(t, err)
+	addr, err := ma.NewMultiaddr("/ip4/127.0.0.1/tcp/9933")
+	require.NoError(t, err)
+
+	provider := NewPeerProvider(nil, db)
+	provider.UpdateSp([]string{"sp1", "sp2"})
+	provider.AddPeer(peerID, "sp1", addr)
+	provider.DeletePeer(peerID)
+	// the changes are not persisted until flush
+	peers, err := NewPeerProvider(nil, db).LoadPeers()
+	require.NoError(t, err)
+	require.Empty(t, peers)
+	provider.Flush()
+
+	reloaded := NewPeerProvider(nil, db)
+	peers, err = reloaded.LoadPeers()
+	require.NoError(t, err)
+	require.Len(t, peers, 1)
+	require.Equal(t, peerID, peers[0].ID())
+	require.Equal(t, "sp1", peers[0].SP())
+	require.Equal(t, addr.String(), peers[0].addr.String())
+	require.True(t, peers[0].Fail())
+	require.True(t, reloaded.checkSP("sp1"))
+	require.True(t, reloaded.checkSP("sp2"))
+	require.False(t, reloaded.checkSP("sp3"))
+
+	// the stale sps are deleted while updating sps
+	provider.UpdateSp([]string{"sp1"})
+	provider.Close()
+	reloaded = NewPeerProvider(nil, db)
+	_, err = reloaded.LoadPeers()
+	require.NoError(t, err)
+	require.False(t, reloaded.checkSP("sp2"))
+}
+
+// countingBatching counts the batches committed to the datastore
+type countingBatching struct {
+	ds.Batching
+	commits int
+}
+
+func (c *countingBatching) Batch(ctx context.Context) (ds.Batch, error) {
+	c.commits++
+	return c.Batching.Batch(ctx)
+}
+
+func TestPeerProvider_Flush(t *testing.T) {
+	db := &countingBatching{Batching: dssync.MutexWrap(ds.NewMapDatastore())}
+	provider := NewPeerProvider(nil, db)
+	provider.UpdateSp([]string{"sp1", "sp2"})
+	for _, id := range []string{
+		"16Uiu2HAmBzdPttaxicSDEf5Kq1XBnoH97wRFA8aiWEnYc2hp2ZHW",
+		"16Uiu2HAm8HNv1MoDsNbmnkibnyCFKjGaU87hRKkFXdcyntCjJYTr",
+	} {
+		peerID, err := peer.Decode(id)
+		require.NoError(t, err)
+		provider.AddPeer(peerID, "sp1", nil)
+		provider.DeletePeer(peerID)
+	}
+	// all the changes are written in one batch
+	provider.Flush()
+	require.Equal(t, 1, db.commits)
+	// nothing is written if nothing changes, including the same sps
+	provider.UpdateSp([]string{"sp2", "sp1"})
+	provider.Flush()
+	require.Equal(t, 1, db.commits)
+
+	provider.Close()
+	provider.UpdateSp([]string{"sp3"})
+	provider.Flush()
+	require.Equal(t, 1, db.commits)
+	reloaded := NewPeerProvider(nil, db)
+	peers, err := reloaded.LoadPeers()
+	require.NoError(t, err)
+	require.Len(t, peers, 2)
+	require.True(t, reloaded.checkSP("sp2"))
+	require.False(t, reloaded.checkSP("sp3"))
+}
@@ -5,6 +5,7 @@ import (
 	"sync"
 	"time"
 
+	ds "github.com/ipfs/go-datastore"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/peerstore"
 	ma "github.com/multiformats/go-multiaddr"
@@ -63,20 +64,31 @@ func (p *Peer) Reset() {
 // for zombie nodes, PeerProvider's pruning strategy takes into account the information of the storage
 // provider dimension, and uses a very conservative pruning strategy. Nodes are only pruned if there are
 // enough backups and multiple failed interactions, can try to keep each storage provider with enough nodes
-// to try to connect, so that each sp has an equal opportunity to receive requests
+// to try to connect, so that each sp has an equal opportunity to receive requests. The storage providers,
+// the peers and their fail counters are persisted in db by Flush if persistentDB is not nil, so that they
+// can be reloaded after restart.
 type PeerProvider struct {
-	peerStore peerstore.Peerstore
-	peers     map[peer.ID]*Peer
-	spPeers   map[string][]*Peer
-	mux       sync.RWMutex
+	peerStore    peerstore.Peerstore
+	persistentDB ds.Batching
+	peers        map[peer.ID]*Peer
+	spPeers      map[string][]*Peer
+	mux          sync.RWMutex
+	// dirtyPeers records the peers changed since the last flush, false means the peer is pruned
+	dirtyPeers   map[peer.ID]bool
+	persistedSPs map[string]struct{}
+	flushMux     sync.Mutex
+	closed       bool
 }
 
 // NewPeerProvider return an instance of PeerProvider
-func NewPeerProvider(store peerstore.Peerstore) *PeerProvider {
+func NewPeerProvider(store peerstore.Peerstore, persistentDB ds.Batching) *PeerProvider {
 	return &PeerProvider{
-		peerStore: store,
-		peers:     make(map[peer.ID]*Peer),
-		spPeers:   make(map[string][]*Peer),
+		peerStore:    store,
+		persistentDB: persistentDB,
+		peers:        make(map[peer.ID]*Peer),
+		spPeers:      make(map[string][]*Peer),
+		dirtyPeers:   make(map[peer.ID]bool),
+		persistedSPs: make(map[string]struct{}),
 	}
 }
 
@@ -105,6 +117,31 @@ func (pr *PeerProvider) checkSP(sp string) bool {
 	return ok
 }
 
+// SPs returns the operator addresses of the storage providers, excludes the unspecified one
+func (pr *PeerProvider) SPs() []string {
+	pr.mux.RLock()
+	defer pr.mux.RUnlock()
+	sps := make([]string, 0, len(pr.spPeers))
+	for sp := range pr.spPeers {
+		if sp == PeerSpUnspecified {
+			continue
+		}
+		sps = append(sps, sp)
+	}
+	sort.Strings(sps)
+	return sps
+}
+
+// PeerSP returns the storage provider operator address of the peer, PeerSpUnspecified if unknown
+func (pr *PeerProvider) PeerSP(peerID peer.ID) string {
+	pr.mux.RLock()
+	defer pr.mux.RUnlock()
+	if node, ok := pr.peers[peerID]; ok {
+		return node.sp
+	}
+	return PeerSpUnspecified
+}
+
 // DeletePeer increase the peer's fail counter and trigger prunePeers
 func (pr *PeerProvider) DeletePeer(peerID peer.ID) {
 	pr.mux.Lock()
@@ -114,6 +151,7 @@ func (pr *PeerProvider) DeletePeer(peerID peer.ID) {
 		return
 	}
 	peer.IncrFail()
+	pr.savePeer(peer)
 	pr.prunePeers()
 }
 
@@ -147,6 +185,7 @@ func (pr *PeerProvider) AddPeer(peerID peer.ID, sp string, addr ma.Multiaddr) {
 		pr.spPeers[sp] = append(pr.spPeers[sp], node)
 	}
 	node.Reset()
+	pr.savePeer(node)
 	pr.prunePeers()
 }
 
@@ -184,6 +223,7 @@ func (pr *PeerProvider) deletePeers(peers []peer.ID) {
 	for _, peerID := range peers {
 		pr.peerStore.ClearAddrs(peerID)
 		pr.peerStore.RemovePeer(peerID)
+		pr.removePeer(peerID)
 		log.Infow("delete node", "node_id", peerID.String())
 	}
 }
@@ -27,6 +27,11 @@ func (entry *PieceEntry) ObjectID() uint64 {
 	return entry.objectID
 }
 
+// SegmentIndex returns piece's segment index
+func (entry *PieceEntry) SegmentIndex() uint32 {
+	return entry.segmentPieceIndex
+}
+
 // PieceKey returns piece key, may be ECPieceKey/SegmentPieceKey
 func (entry *PieceEntry) PieceKey() string {
 	if entry.redundancyType == storagetypes.REDUNDANCY_EC_TYPE {
@@ -52,6 +57,7 @@ type PayloadStream struct {
 	redundancyType storagetypes.RedundancyType
 	pieceSize      uint64 // pieceSize is used to split
 	ecPieceIndex   uint32
+	startIndex     uint32 // startIndex is the segment index of the first piece
 	entryCh        chan *PieceEntry
 	init           atomic.Value
 	close          atomic.Value
@@ -74,6 +80,13 @@ func NewAsyncPayloadStream() *PayloadStream {
 // must be called before write or read stream
 func (stream *PayloadStream) InitAsyncPayloadStream(objectID uint64, redundancyType storagetypes.RedundancyType,
 	pieceSize uint64, ecPieceIndex uint32) error {
+	return stream.InitAsyncPayloadStreamFromSegment(objectID, redundancyType, pieceSize, ecPieceIndex, 0)
+}
+
+// InitAsyncPayloadStreamFromSegment is similar to InitAsyncPayloadStream, but the segment index of
+// the first piece is startIndex, it is used to resume writing the payload from the middle of the object
+func (stream *PayloadStream) InitAsyncPayloadStreamFromSegment(objectID uint64, redundancyType storagetypes.RedundancyType,
+	pieceSize uint64, ecPieceIndex uint32, startIndex uint32) error {
 	if stream.init.Load() == true {
 		return nil
 	}
@@ -82,6 +95,7 @@ func (stream *PayloadStream) InitAsyncPayloadStream(objectID uint64, redundancyT
 	stream.redundancyType = redundancyType
 	stream.pieceSize = pieceSize
 	stream.ecPieceIndex = ecPieceIndex
+	stream.startIndex = startIndex
 	go stream.readStream()
 	return nil
 }
@@ -130,7 +144,7 @@ func (stream *PayloadStream) Close() {
 
 func (stream *PayloadStream) readStream() {
 	var (
-		segmentPieceIdx uint32
+		segmentPieceIdx = stream.startIndex
 		totalReadSize   int
 	)
 	for {
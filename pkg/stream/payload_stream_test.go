@@ -139,3 +139,19 @@ func TestPayloadStream(t *testing.T) {
 		})
 	}
 }
+
+func TestPayloadStreamFromSegment(t *testing.T) {
+	s := NewAsyncPayloadStream()
+	_ = s.InitAsyncPayloadStreamFromSegment(1, storagetypes.REDUNDANCY_REPLICA_TYPE, 16*1024*1024, 1, 2)
+	go func() {
+		s.StreamWrite(make([]byte, 16*1024*1024+1))
+		s.StreamClose()
+	}()
+
+	var segmentIndexes []uint32
+	for entry := range s.AsyncStreamRead() {
+		require.NoError(t, entry.Error())
+		segmentIndexes = append(segmentIndexes, entry.SegmentIndex())
+	}
+	assert.Equal(t, []uint32{2, 3}, segmentIndexes)
+}
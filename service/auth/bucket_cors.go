@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/bnb-chain/greenfield-storage-provider/pkg/log"
+	authtypes "github.com/bnb-chain/greenfield-storage-provider/service/auth/types"
+	"github.com/bnb-chain/greenfield-storage-provider/store/sqldb"
+)
+
+// GetBucketCORS gets the cors rules of bucket, the empty rules are returned if the bucket has no cors configuration.
+func (auth *AuthServer) GetBucketCORS(ctx context.Context, req *authtypes.GetBucketCORSRequest) (*authtypes.GetBucketCORSResponse, error) {
+	ctx = log.Context(ctx, req)
+	record, err := auth.spDB.GetBucketCORS(req.GetBucketName())
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &authtypes.GetBucketCORSResponse{}, nil
+	}
+	if err != nil {
+		log.CtxErrorw(ctx, "failed to get bucket cors", "error", err)
+		return nil, err
+	}
+	resp := &authtypes.GetBucketCORSResponse{}
+	if err = json.Unmarshal([]byte(record.Rules), &resp.Rules); err != nil {
+		log.CtxErrorw(ctx, "failed to unmarshal bucket cors rules", "error", err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+// PutBucketCORS puts the cors rules of bucket, the cors configuration is deleted if the rules are empty.
+// The rules are checked and the bucket owner is authenticated by the gateway.
+func (auth *AuthServer) PutBucketCORS(ctx context.Context, req *authtypes.PutBucketCORSRequest) (*authtypes.PutBucketCORSResponse, error) {
+	ctx = log.Context(ctx, req)
+	if len(req.GetRules()) == 0 {
+		if err := auth.spDB.DeleteBucketCORS(req.GetBucketName()); err != nil {
+			log.CtxErrorw(ctx, "failed to delete bucket cors", "error", err)
+			return nil, err
+		}
+		log.CtxInfow(ctx, "succeed to delete bucket cors")
+		return &authtypes.PutBucketCORSResponse{}, nil
+	}
+	rules, err := json.Marshal(req.GetRules())
+	if err != nil {
+		return nil, err
+	}
+	if err = auth.spDB.UpdateBucketCORS(&sqldb.BucketCORSTable{
+		BucketName:   req.GetBucketName(),
+		Rules:        string(rules),
+		ModifiedTime: time.Now(),
+	}); err != nil {
+		log.CtxErrorw(ctx, "failed to update bucket cors", "error", err)
+		return nil, err
+	}
+	log.CtxInfow(ctx, "succeed to put bucket cors", "rule_count", len(req.GetRules()))
+	return &authtypes.PutBucketCORSResponse{}, nil
+}
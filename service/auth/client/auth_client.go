@@ -77,3 +77,44 @@ func (client *AuthClient) VerifyOffChainSignature(ctx context.Context, in *autht
 	}
 	return resp, nil
 }
+
+// CreateS3Credential creates a s3 credential bound to the user's current off chain auth key.
+func (client *AuthClient) CreateS3Credential(ctx context.Context, in *authtypes.CreateS3CredentialRequest, opts ...grpc.CallOption) (*authtypes.CreateS3CredentialResponse, error) {
+	resp, err := client.Auth.CreateS3Credential(ctx, in, opts...)
+	if err != nil {
+		log.CtxErrorw(ctx, "failed to create s3 credential rpc", "error", err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+// VerifyS3Signature verifies the AWS signature version 4 signed by the s3 secret access key.
+func (client *AuthClient) VerifyS3Signature(ctx context.Context, in *authtypes.VerifyS3SignatureRequest, opts ...grpc.CallOption) (*authtypes.VerifyS3SignatureResponse, error) {
+	resp, err := client.Auth.VerifyS3Signature(ctx, in, opts...)
+	ctx = log.Context(ctx, resp)
+	if err != nil {
+		log.CtxErrorw(ctx, "failed to verify s3 signature rpc", "error", err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetBucketCORS gets the cors rules of bucket.
+func (client *AuthClient) GetBucketCORS(ctx context.Context, in *authtypes.GetBucketCORSRequest, opts ...grpc.CallOption) (*authtypes.GetBucketCORSResponse, error) {
+	resp, err := client.Auth.GetBucketCORS(ctx, in, opts...)
+	if err != nil {
+		log.CtxErrorw(ctx, "failed to get bucket cors rpc", "error", err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+// PutBucketCORS puts the cors rules of bucket, the cors configuration is deleted if the rules are empty.
+func (client *AuthClient) PutBucketCORS(ctx context.Context, in *authtypes.PutBucketCORSRequest, opts ...grpc.CallOption) (*authtypes.PutBucketCORSResponse, error) {
+	resp, err := client.Auth.PutBucketCORS(ctx, in, opts...)
+	if err != nil {
+		log.CtxErrorw(ctx, "failed to put bucket cors rpc", "error", err)
+		return nil, err
+	}
+	return resp, nil
+}
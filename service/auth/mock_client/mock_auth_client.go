@@ -96,6 +96,86 @@ func (mr *MockAuthServiceClientMockRecorder) VerifyOffChainSignature(ctx, in int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyOffChainSignature", reflect.TypeOf((*MockAuthServiceClient)(nil).VerifyOffChainSignature), varargs...)
 }
 
+// CreateS3Credential mocks base method.
+func (m *MockAuthServiceClient) CreateS3Credential(ctx context.Context, in *types.CreateS3CredentialRequest, opts ...grpc.CallOption) (*types.CreateS3CredentialResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateS3Credential", varargs...)
+	ret0, _ := ret[0].(*types.CreateS3CredentialResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateS3Credential indicates an expected call of CreateS3Credential.
+func (mr *MockAuthServiceClientMockRecorder) CreateS3Credential(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateS3Credential", reflect.TypeOf((*MockAuthServiceClient)(nil).CreateS3Credential), varargs...)
+}
+
+// GetBucketCORS mocks base method.
+func (m *MockAuthServiceClient) GetBucketCORS(ctx context.Context, in *types.GetBucketCORSRequest, opts ...grpc.CallOption) (*types.GetBucketCORSResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetBucketCORS", varargs...)
+	ret0, _ := ret[0].(*types.GetBucketCORSResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBucketCORS indicates an expected call of GetBucketCORS.
+func (mr *MockAuthServiceClientMockRecorder) GetBucketCORS(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBucketCORS", reflect.TypeOf((*MockAuthServiceClient)(nil).GetBucketCORS), varargs...)
+}
+
+// PutBucketCORS mocks base method.
+func (m *MockAuthServiceClient) PutBucketCORS(ctx context.Context, in *types.PutBucketCORSRequest, opts ...grpc.CallOption) (*types.PutBucketCORSResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PutBucketCORS", varargs...)
+	ret0, _ := ret[0].(*types.PutBucketCORSResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutBucketCORS indicates an expected call of PutBucketCORS.
+func (mr *MockAuthServiceClientMockRecorder) PutBucketCORS(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutBucketCORS", reflect.TypeOf((*MockAuthServiceClient)(nil).PutBucketCORS), varargs...)
+}
+
+// VerifyS3Signature mocks base method.
+func (m *MockAuthServiceClient) VerifyS3Signature(ctx context.Context, in *types.VerifyS3SignatureRequest, opts ...grpc.CallOption) (*types.VerifyS3SignatureResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "VerifyS3Signature", varargs...)
+	ret0, _ := ret[0].(*types.VerifyS3SignatureResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyS3Signature indicates an expected call of VerifyS3Signature.
+func (mr *MockAuthServiceClientMockRecorder) VerifyS3Signature(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyS3Signature", reflect.TypeOf((*MockAuthServiceClient)(nil).VerifyS3Signature), varargs...)
+}
+
 // MockAuthServiceServer is a mock of AuthServiceServer interface.
 type MockAuthServiceServer struct {
 	ctrl     *gomock.Controller
@@ -163,3 +243,63 @@ func (mr *MockAuthServiceServerMockRecorder) VerifyOffChainSignature(arg0, arg1
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyOffChainSignature", reflect.TypeOf((*MockAuthServiceServer)(nil).VerifyOffChainSignature), arg0, arg1)
 }
+
+// CreateS3Credential mocks base method.
+func (m *MockAuthServiceServer) CreateS3Credential(arg0 context.Context, arg1 *types.CreateS3CredentialRequest) (*types.CreateS3CredentialResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateS3Credential", arg0, arg1)
+	ret0, _ := ret[0].(*types.CreateS3CredentialResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateS3Credential indicates an expected call of CreateS3Credential.
+func (mr *MockAuthServiceServerMockRecorder) CreateS3Credential(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateS3Credential", reflect.TypeOf((*MockAuthServiceServer)(nil).CreateS3Credential), arg0, arg1)
+}
+
+// GetBucketCORS mocks base method.
+func (m *MockAuthServiceServer) GetBucketCORS(arg0 context.Context, arg1 *types.GetBucketCORSRequest) (*types.GetBucketCORSResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBucketCORS", arg0, arg1)
+	ret0, _ := ret[0].(*types.GetBucketCORSResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBucketCORS indicates an expected call of GetBucketCORS.
+func (mr *MockAuthServiceServerMockRecorder) GetBucketCORS(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBucketCORS", reflect.TypeOf((*MockAuthServiceServer)(nil).GetBucketCORS), arg0, arg1)
+}
+
+// PutBucketCORS mocks base method.
+func (m *MockAuthServiceServer) PutBucketCORS(arg0 context.Context, arg1 *types.PutBucketCORSRequest) (*types.PutBucketCORSResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutBucketCORS", arg0, arg1)
+	ret0, _ := ret[0].(*types.PutBucketCORSResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutBucketCORS indicates an expected call of PutBucketCORS.
+func (mr *MockAuthServiceServerMockRecorder) PutBucketCORS(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutBucketCORS", reflect.TypeOf((*MockAuthServiceServer)(nil).PutBucketCORS), arg0, arg1)
+}
+
+// VerifyS3Signature mocks base method.
+func (m *MockAuthServiceServer) VerifyS3Signature(arg0 context.Context, arg1 *types.VerifyS3SignatureRequest) (*types.VerifyS3SignatureResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyS3Signature", arg0, arg1)
+	ret0, _ := ret[0].(*types.VerifyS3SignatureResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyS3Signature indicates an expected call of VerifyS3Signature.
+func (mr *MockAuthServiceServerMockRecorder) VerifyS3Signature(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyS3Signature", reflect.TypeOf((*MockAuthServiceServer)(nil).VerifyS3Signature), arg0, arg1)
+}
@@ -0,0 +1,142 @@
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	merrors "github.com/bnb-chain/greenfield-storage-provider/model/errors"
+	"github.com/bnb-chain/greenfield-storage-provider/pkg/log"
+	authtypes "github.com/bnb-chain/greenfield-storage-provider/service/auth/types"
+	"github.com/bnb-chain/greenfield-storage-provider/store/sqldb"
+)
+
+const (
+	// S3AccessKeyIDPrefix is the prefix of s3 access key id issued by SP
+	S3AccessKeyIDPrefix = "GNFD"
+	// s3AccessKeyIDRandLen is the random length of s3 access key id after the prefix
+	s3AccessKeyIDRandLen = 16
+	// s3SecretKeyRandLen is the random bytes length of s3 secret access key
+	s3SecretKeyRandLen = 30
+	// s3AccessKeyIDAlphabet is the alphabet of s3 access key id
+	s3AccessKeyIDAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZ234567"
+)
+
+// CreateS3Credential creates a s3 credential bound to the user's current off chain auth key, the credential
+// is invalidated once the off chain auth key is expired or rotated.
+func (auth *AuthServer) CreateS3Credential(ctx context.Context, req *authtypes.CreateS3CredentialRequest) (*authtypes.CreateS3CredentialResponse, error) {
+	ctx = log.Context(ctx, req)
+	authKey, err := auth.spDB.GetAuthKey(req.GetAccountId(), req.GetDomain())
+	if err != nil {
+		log.CtxErrorw(ctx, "failed to get auth key", "error", err)
+		return nil, err
+	}
+	if !isValidAuthKey(authKey) {
+		log.CtxErrorw(ctx, "failed to create s3 credential due to no valid auth key")
+		return nil, merrors.InnerErrorToGRPCError(merrors.ErrNoValidAuthKey)
+	}
+
+	accessKeyID, err := randomString(S3AccessKeyIDPrefix, s3AccessKeyIDRandLen)
+	if err != nil {
+		return nil, err
+	}
+	secret := make([]byte, s3SecretKeyRandLen)
+	if _, err = rand.Read(secret); err != nil {
+		return nil, err
+	}
+	credential := &sqldb.S3CredentialTable{
+		AccessKeyID:  accessKeyID,
+		SecretKey:    base64.StdEncoding.EncodeToString(secret),
+		UserAddress:  authKey.UserAddress,
+		Domain:       authKey.Domain,
+		AuthKeyNonce: authKey.CurrentNonce,
+		CreatedTime:  time.Now(),
+		ModifiedTime: time.Now(),
+	}
+	if err = auth.spDB.InsertS3Credential(credential); err != nil {
+		log.CtxErrorw(ctx, "failed to insert s3 credential", "error", err)
+		return nil, err
+	}
+	log.CtxInfow(ctx, "succeed to create s3 credential", "access_key_id", accessKeyID)
+	return &authtypes.CreateS3CredentialResponse{
+		AccessKeyId:     credential.AccessKeyID,
+		SecretAccessKey: credential.SecretKey,
+	}, nil
+}
+
+// VerifyS3Signature verifies the AWS signature version 4 signed by the s3 secret access key, the secret access
+// key never leaves the auth service. merrors.ErrInvalidAccessKey is returned if the access key does not exist
+// or the bound off chain auth key is no longer valid.
+func (auth *AuthServer) VerifyS3Signature(ctx context.Context, req *authtypes.VerifyS3SignatureRequest) (*authtypes.VerifyS3SignatureResponse, error) {
+	ctx = log.Context(ctx, req)
+	credential, err := auth.spDB.GetS3Credential(req.GetAccessKeyId())
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		log.CtxErrorw(ctx, "failed to verify s3 signature due to access key not found")
+		return nil, merrors.InnerErrorToGRPCError(merrors.ErrInvalidAccessKey)
+	}
+	if err != nil {
+		log.CtxErrorw(ctx, "failed to get s3 credential", "error", err)
+		return nil, err
+	}
+	authKey, err := auth.spDB.GetAuthKey(credential.UserAddress, credential.Domain)
+	if err != nil {
+		log.CtxErrorw(ctx, "failed to get auth key", "error", err)
+		return nil, err
+	}
+	if !isValidAuthKey(authKey) || authKey.CurrentNonce != credential.AuthKeyNonce {
+		log.CtxErrorw(ctx, "failed to verify s3 signature due to auth key is expired or rotated",
+			"auth_key_nonce", authKey.CurrentNonce, "credential_nonce", credential.AuthKeyNonce)
+		return nil, merrors.InnerErrorToGRPCError(merrors.ErrInvalidAccessKey)
+	}
+
+	signingKey := S3SigningKey(credential.SecretKey, req.GetDate(), req.GetRegion(), req.GetService())
+	expected := hex.EncodeToString(hmacSHA256(signingKey, []byte(req.GetStringToSign())))
+	if !hmac.Equal([]byte(expected), []byte(strings.ToLower(req.GetSignature()))) {
+		log.CtxErrorw(ctx, "failed to verify s3 signature due to signature mismatch")
+		return &authtypes.VerifyS3SignatureResponse{Result: false}, nil
+	}
+	return &authtypes.VerifyS3SignatureResponse{
+		Result:    true,
+		AccountId: credential.UserAddress,
+	}, nil
+}
+
+// S3SigningKey derives the AWS signature version 4 signing key from the secret access key and credential scope.
+func S3SigningKey(secretKey, date, region, service string) []byte {
+	key := hmacSHA256([]byte("AWS4"+secretKey), []byte(date))
+	key = hmacSHA256(key, []byte(region))
+	key = hmacSHA256(key, []byte(service))
+	return hmacSHA256(key, []byte("aws4_request"))
+}
+
+func hmacSHA256(key, data []byte) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+// isValidAuthKey returns whether the off chain auth key is registered and not expired
+func isValidAuthKey(authKey *sqldb.OffChainAuthKeyTable) bool {
+	return authKey != nil && authKey.CurrentPublicKey != "" && time.Now().Before(authKey.ExpiryDate)
+}
+
+func randomString(prefix string, n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	sb.WriteString(prefix)
+	for _, b := range buf {
+		sb.WriteByte(s3AccessKeyIDAlphabet[int(b)%len(s3AccessKeyIDAlphabet)])
+	}
+	return sb.String(), nil
+}
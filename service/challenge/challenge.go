@@ -41,14 +41,18 @@ func NewChallengeService(cfg *ChallengeConfig) (*Challenge, error) {
 	challenge = &Challenge{
 		config: cfg,
 	}
-	if challenge.pieceStore, err = psclient.NewStoreClient(cfg.PieceStoreConfig); err != nil {
-		log.Errorw("failed to create piece store client", "error", err)
-		return nil, err
-	}
 	if challenge.spDB, err = sqldb.NewSpDB(cfg.SpDBConfig); err != nil {
 		log.Errorw("failed to create sp db client", "error", err)
 		return nil, err
 	}
+	var storeOpts []psclient.StoreClientOption
+	if cfg.PieceStoreConfig.VerifyRead {
+		storeOpts = append(storeOpts, psclient.WithVerifyingRead(challenge.spDB))
+	}
+	if challenge.pieceStore, err = psclient.NewStoreClient(cfg.PieceStoreConfig, storeOpts...); err != nil {
+		log.Errorw("failed to create piece store client", "error", err)
+		return nil, err
+	}
 	if challenge.rcScope, err = rcmgr.ResrcManager().OpenService(model.ChallengeService); err != nil {
 		log.Errorw("failed to open challenge resource scope", "error", err)
 		return nil, err
@@ -2,13 +2,18 @@ package challenge
 
 import (
 	"context"
+	"errors"
+
+	"gorm.io/gorm"
 
 	"github.com/bnb-chain/greenfield-storage-provider/model"
 	merrors "github.com/bnb-chain/greenfield-storage-provider/model/errors"
 	"github.com/bnb-chain/greenfield-storage-provider/model/piecestore"
 	"github.com/bnb-chain/greenfield-storage-provider/pkg/log"
+	"github.com/bnb-chain/greenfield-storage-provider/pkg/metrics"
 	"github.com/bnb-chain/greenfield-storage-provider/pkg/rcmgr"
 	"github.com/bnb-chain/greenfield-storage-provider/service/challenge/types"
+	"github.com/bnb-chain/greenfield-storage-provider/store/sqldb"
 )
 
 var _ types.ChallengeServiceServer = &Challenge{}
@@ -82,6 +87,9 @@ func (challenge *Challenge) ChallengePiece(ctx context.Context, req *types.Chall
 	resp.PieceHash = integrity.Checksum
 	resp.PieceData, err = challenge.pieceStore.GetPiece(ctx, pieceKey, 0, -1)
 	if err != nil {
+		if errors.Is(err, merrors.ErrPieceCorrupt) {
+			metrics.PieceCorruptCounter.WithLabelValues(model.ChallengeService).Inc()
+		}
 		log.CtxErrorw(ctx, "failed to get piece data", "error", err)
 		err = merrors.InnerErrorToGRPCError(err)
 		return resp, err
@@ -90,3 +98,44 @@ func (challenge *Challenge) ChallengePiece(ctx context.Context, req *types.Chall
 		"redundancy_idx", req.GetRedundancyIdx(), "segment_count", len(integrity.Checksum))
 	return resp, err
 }
+
+// QueryScrubResults queries the scrub results recorded by the piece scrubber of manager, returns the result of
+// the object if object id is set, otherwise returns the latest results in the status.
+func (challenge *Challenge) QueryScrubResults(ctx context.Context, req *types.QueryScrubResultsRequest) (
+	*types.QueryScrubResultsResponse, error) {
+	var (
+		results []*sqldb.ScrubResult
+		err     error
+	)
+	if req.GetObjectId() != 0 {
+		var result *sqldb.ScrubResult
+		result, err = challenge.spDB.GetScrubResult(req.GetObjectId())
+		if err == nil {
+			results = append(results, result)
+		} else if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = nil
+		}
+	} else {
+		if req.GetStatus() == types.ScrubStatus_SCRUB_STATUS_UNSPECIFIED {
+			return nil, merrors.InnerErrorToGRPCError(merrors.ErrInvalidParams)
+		}
+		results, err = challenge.spDB.ListScrubResults(sqldb.ScrubStatus(req.GetStatus()), int(req.GetLimit()))
+	}
+	if err != nil {
+		log.CtxErrorw(ctx, "failed to query scrub results", "object_id", req.GetObjectId(),
+			"status", req.GetStatus(), "error", err)
+		return nil, merrors.InnerErrorToGRPCError(err)
+	}
+	resp := &types.QueryScrubResultsResponse{}
+	for _, result := range results {
+		resp.Results = append(resp.Results, &types.ScrubResult{
+			ObjectId:         result.ObjectID,
+			Status:           types.ScrubStatus(result.Status),
+			ScrubbedPieces:   result.ScrubbedPieces,
+			CorruptPieceKeys: result.CorruptPieceKeys,
+			ErrorDescription: result.ErrorDescription,
+			ModifyTime:       result.ModifyTime,
+		})
+	}
+	return resp, nil
+}
@@ -0,0 +1,44 @@
+package challenge
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"github.com/bnb-chain/greenfield-storage-provider/service/challenge/types"
+	"github.com/bnb-chain/greenfield-storage-provider/store/sqldb"
+)
+
+func TestChallenge_QueryScrubResults(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	spDB := sqldb.NewMockSPDB(ctrl)
+	challenge := &Challenge{spDB: spDB}
+
+	spDB.EXPECT().GetScrubResult(uint64(1)).Return(&sqldb.ScrubResult{ObjectID: 1, Status: sqldb.ScrubStatusRepaired,
+		CorruptPieceKeys: []string{"1_s0"}}, nil)
+	resp, err := challenge.QueryScrubResults(context.TODO(), &types.QueryScrubResultsRequest{ObjectId: 1})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(resp.GetResults()))
+	assert.Equal(t, types.ScrubStatus_SCRUB_STATUS_REPAIRED, resp.GetResults()[0].GetStatus())
+	assert.Equal(t, []string{"1_s0"}, resp.GetResults()[0].GetCorruptPieceKeys())
+
+	// the object which has not been scrubbed has no result
+	spDB.EXPECT().GetScrubResult(uint64(2)).Return(nil, gorm.ErrRecordNotFound)
+	resp, err = challenge.QueryScrubResults(context.TODO(), &types.QueryScrubResultsRequest{ObjectId: 2})
+	assert.Nil(t, err)
+	assert.Empty(t, resp.GetResults())
+
+	spDB.EXPECT().ListScrubResults(sqldb.ScrubStatusCorrupt, 10).Return([]*sqldb.ScrubResult{
+		{ObjectID: 3, Status: sqldb.ScrubStatusCorrupt}, {ObjectID: 4, Status: sqldb.ScrubStatusCorrupt}}, nil)
+	resp, err = challenge.QueryScrubResults(context.TODO(), &types.QueryScrubResultsRequest{
+		Status: types.ScrubStatus_SCRUB_STATUS_CORRUPT, Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(resp.GetResults()))
+
+	_, err = challenge.QueryScrubResults(context.TODO(), &types.QueryScrubResultsRequest{})
+	assert.NotNil(t, err)
+}
@@ -65,3 +65,18 @@ func (client *ChallengeClient) ChallengePiece(ctx context.Context, objectInfo *s
 	}
 	return resp.GetIntegrityHash(), resp.GetPieceHash(), resp.GetPieceData(), err
 }
+
+// QueryScrubResults queries the scrub results of the object if objectID is not 0, otherwise queries the latest
+// scrub results in the status
+func (client *ChallengeClient) QueryScrubResults(ctx context.Context, objectID uint64, status types.ScrubStatus,
+	limit int32, opts ...grpc.CallOption) ([]*types.ScrubResult, error) {
+	resp, err := client.challenge.QueryScrubResults(ctx, &types.QueryScrubResultsRequest{
+		ObjectId: objectID,
+		Status:   status,
+		Limit:    limit,
+	}, opts...)
+	if err != nil {
+		return nil, merrors.GRPCErrorToInnerError(err)
+	}
+	return resp.GetResults(), nil
+}
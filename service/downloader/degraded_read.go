@@ -0,0 +1,172 @@
+package downloader
+
+import (
+	"context"
+	"errors"
+
+	"github.com/bnb-chain/greenfield-common/go/hash"
+	"github.com/bnb-chain/greenfield-common/go/redundancy"
+	storagetypes "github.com/bnb-chain/greenfield/x/storage/types"
+
+	"github.com/bnb-chain/greenfield-storage-provider/model"
+	merrors "github.com/bnb-chain/greenfield-storage-provider/model/errors"
+	"github.com/bnb-chain/greenfield-storage-provider/model/piecestore"
+	"github.com/bnb-chain/greenfield-storage-provider/pkg/log"
+	"github.com/bnb-chain/greenfield-storage-provider/pkg/metrics"
+	"github.com/bnb-chain/greenfield-storage-provider/pkg/rcmgr"
+	gatewayclient "github.com/bnb-chain/greenfield-storage-provider/service/gateway/client"
+	"github.com/bnb-chain/greenfield-storage-provider/store/sqldb"
+)
+
+var errInvalidPieceRange = errors.New("piece range is out of segment piece")
+
+// degradedReader reads the segment pieces of an object for degraded read. The local segment pieces are verified
+// against the integrity meta, and the lost or corrupt ones are reconstructed from the pieces of the secondary sps.
+type degradedReader struct {
+	ctx        context.Context
+	downloader *Downloader
+	objectInfo *storagetypes.ObjectInfo
+	params     *storagetypes.Params
+	integrity  *sqldb.IntegrityMeta
+	getter     *gatewayclient.RecoveryPieceGetter
+	// verifyLocal defines whether the reader verifies the local segment pieces, which are verified by the
+	// piece store instead if its verifying read mode is enabled
+	verifyLocal bool
+}
+
+// newDegradedReader returns a degradedReader instance, the integrity meta is used to verify the local segment
+// pieces, the local segment pieces are not verified if the integrity meta is missing.
+func newDegradedReader(ctx context.Context, downloader *Downloader, objectInfo *storagetypes.ObjectInfo) (
+	*degradedReader, error) {
+	params, err := downloader.spDB.GetStorageParams()
+	if err != nil {
+		log.CtxErrorw(ctx, "failed to get storage params", "error", err)
+		return nil, err
+	}
+	reader := &degradedReader{
+		ctx:        ctx,
+		downloader: downloader,
+		objectInfo: objectInfo,
+		params:     params,
+		getter: gatewayclient.NewRecoveryPieceGetter(objectInfo, downloader.config.SpOperatorAddress,
+			downloader.spDB, downloader.signer),
+		verifyLocal: !downloader.config.PieceStoreConfig.VerifyRead,
+	}
+	if reader.integrity, err = downloader.spDB.GetObjectIntegrity(objectInfo.Id.Uint64()); err != nil {
+		log.CtxWarnw(ctx, "failed to get integrity meta, the local segment pieces will not be verified", "error", err)
+	}
+	return reader, nil
+}
+
+// getPiece returns the data of the segment piece in the range, the segment piece is reconstructed from the
+// pieces of the secondary sps if it is lost or corrupt in local piece store. The local segment piece is verified
+// only once, either by the reader or by the piece store in verifying read mode.
+func (r *degradedReader) getPiece(pInfo *segmentPieceInfo) ([]byte, error) {
+	data, err := r.downloader.pieceStore.GetPiece(r.ctx, pInfo.segmentPieceKey, 0, 0)
+	if err == nil && r.verifyLocal {
+		err = r.verifySegmentPiece(pInfo.segmentIndex, data)
+	}
+	if err == nil {
+		return sliceSegmentPiece(data, pInfo)
+	}
+	if errors.Is(err, merrors.ErrPieceCorrupt) {
+		metrics.PieceCorruptCounter.WithLabelValues(model.DownloaderService).Inc()
+	}
+	log.CtxWarnw(r.ctx, "segment piece is lost or corrupt, try to reconstruct it from secondary sps",
+		"piece_key", pInfo.segmentPieceKey, "error", err)
+
+	if data, err = r.reconstructSegmentPiece(pInfo.segmentIndex); err != nil {
+		log.CtxErrorw(r.ctx, "failed to reconstruct segment piece", "piece_key", pInfo.segmentPieceKey, "error", err)
+		return nil, err
+	}
+	log.CtxInfow(r.ctx, "succeed to reconstruct segment piece", "piece_key", pInfo.segmentPieceKey)
+	if r.downloader.config.DegradedReadCfg.WriteBack {
+		if err = r.downloader.pieceStore.PutPiece(pInfo.segmentPieceKey, data); err != nil {
+			log.CtxWarnw(r.ctx, "failed to write back reconstructed segment piece",
+				"piece_key", pInfo.segmentPieceKey, "error", err)
+		} else {
+			log.CtxInfow(r.ctx, "succeed to write back reconstructed segment piece", "piece_key", pInfo.segmentPieceKey)
+		}
+	}
+	return sliceSegmentPiece(data, pInfo)
+}
+
+// verifySegmentPiece verifies the segment piece data against the integrity meta.
+func (r *degradedReader) verifySegmentPiece(segmentIdx uint32, data []byte) error {
+	if r.integrity == nil {
+		return nil
+	}
+	return hash.ChallengePieceHash(r.objectInfo.GetChecksums()[0], r.integrity.Checksum, int(segmentIdx), data)
+}
+
+// reconstructSegmentPiece gets the segment piece from secondary sps if the object is replica type, otherwise
+// gets enough ec pieces from secondary sps and decodes the segment piece.
+func (r *degradedReader) reconstructSegmentPiece(segmentIdx uint32) (data []byte, err error) {
+	var (
+		dataChunkNum   = int(r.params.VersionedParams.GetRedundantDataChunkNum())
+		parityChunkNum = int(r.params.VersionedParams.GetRedundantParityChunkNum())
+		segmentSize    = piecestore.ComputeSegmentSize(r.objectInfo.GetPayloadSize(), segmentIdx,
+			r.params.VersionedParams.GetMaxSegmentSize())
+		// the ec pieces and the decoded segment piece are held in memory at the same time
+		approximateMemSize = int(segmentSize) * 2
+	)
+	scopeSpan, err := r.downloader.rcScope.BeginSpan()
+	if err != nil {
+		log.CtxErrorw(r.ctx, "failed to begin span", "error", err)
+		return nil, err
+	}
+	defer scopeSpan.Done()
+	if err = scopeSpan.ReserveMemory(approximateMemSize, rcmgr.ReservationPriorityLow); err != nil {
+		log.CtxErrorw(r.ctx, "failed to reserve memory from resource manager",
+			"reserve_size", approximateMemSize, "resource_state", rcmgr.GetServiceState(model.DownloaderService), "error", err)
+		return nil, err
+	}
+
+	if r.objectInfo.GetRedundancyType() == storagetypes.REDUNDANCY_REPLICA_TYPE {
+		for redundancyIdx, spAddress := range r.objectInfo.GetSecondarySpAddresses() {
+			if data, err = r.getter.GetRecoveryPiece(r.ctx, spAddress, segmentIdx, -1, redundancyIdx+1); err == nil {
+				return data, nil
+			}
+		}
+		return nil, gatewayclient.ErrInsufficientRecoveryPieces
+	}
+
+	var (
+		pieces        = make([][]byte, dataChunkNum+parityChunkNum)
+		succeedNumber int
+	)
+	for redundancyIdx, spAddress := range r.objectInfo.GetSecondarySpAddresses() {
+		if succeedNumber >= dataChunkNum || redundancyIdx >= len(pieces) {
+			break
+		}
+		if pieces[redundancyIdx], err = r.getter.GetRecoveryPiece(r.ctx, spAddress, segmentIdx, int32(redundancyIdx),
+			redundancyIdx+1); err != nil {
+			continue
+		}
+		succeedNumber++
+	}
+	if succeedNumber < dataChunkNum {
+		return nil, gatewayclient.ErrInsufficientRecoveryPieces
+	}
+	// the lost pieces are passed as empty bytes
+	for idx := range pieces {
+		if pieces[idx] == nil {
+			pieces[idx] = []byte{}
+		}
+	}
+	if data, err = redundancy.DecodeRawSegment(pieces, int64(segmentSize), dataChunkNum, parityChunkNum); err != nil {
+		return nil, err
+	}
+	if err = r.verifySegmentPiece(segmentIdx, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// sliceSegmentPiece returns the data in the range of the segment piece.
+func sliceSegmentPiece(data []byte, pInfo *segmentPieceInfo) ([]byte, error) {
+	if pInfo.offset+pInfo.length > uint64(len(data)) {
+		return nil, errInvalidPieceRange
+	}
+	return data[pInfo.offset : pInfo.offset+pInfo.length], nil
+}
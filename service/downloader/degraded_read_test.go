@@ -0,0 +1,114 @@
+package downloader
+
+import (
+	"context"
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	"github.com/bnb-chain/greenfield-common/go/hash"
+	storagetypes "github.com/bnb-chain/greenfield/x/storage/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/greenfield-storage-provider/model"
+	"github.com/bnb-chain/greenfield-storage-provider/pkg/rcmgr"
+	gatewayclient "github.com/bnb-chain/greenfield-storage-provider/service/gateway/client"
+	psclient "github.com/bnb-chain/greenfield-storage-provider/store/piecestore/client"
+	"github.com/bnb-chain/greenfield-storage-provider/store/piecestore/storage"
+	"github.com/bnb-chain/greenfield-storage-provider/store/sqldb"
+)
+
+func setupDegradedReader(t *testing.T, segments ...[]byte) *degradedReader {
+	pieceStore, err := psclient.NewStoreClient(&storage.PieceStoreConfig{
+		Store: storage.ObjectStorageConfig{Storage: "file", BucketURL: t.TempDir() + "/"},
+	})
+	assert.Nil(t, err)
+	rcScope, err := rcmgr.ResrcManager().OpenService(model.DownloaderService)
+	assert.Nil(t, err)
+
+	var (
+		checksums   [][]byte
+		payloadSize uint64
+	)
+	for _, segment := range segments {
+		checksums = append(checksums, hash.GenerateChecksum(segment))
+		payloadSize += uint64(len(segment))
+	}
+	return &degradedReader{
+		ctx: context.TODO(),
+		downloader: &Downloader{
+			config:     &DownloaderConfig{DegradedReadCfg: DefaultDegradedReadConfig},
+			pieceStore: pieceStore,
+			rcScope:    rcScope,
+		},
+		objectInfo: &storagetypes.ObjectInfo{
+			Id:             sdkmath.NewUint(1),
+			PayloadSize:    payloadSize,
+			RedundancyType: storagetypes.REDUNDANCY_REPLICA_TYPE,
+			Checksums:      [][]byte{hash.GenerateIntegrityHash(checksums)},
+		},
+		params: &storagetypes.Params{
+			VersionedParams: storagetypes.VersionedParams{MaxSegmentSize: uint64(len(segments[0]))},
+		},
+		integrity:   &sqldb.IntegrityMeta{ObjectID: 1, Checksum: checksums},
+		verifyLocal: true,
+	}
+}
+
+func TestSliceSegmentPiece(t *testing.T) {
+	data, err := sliceSegmentPiece([]byte("0123456789"), &segmentPieceInfo{offset: 2, length: 3})
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("234"), data)
+	_, err = sliceSegmentPiece([]byte("0123456789"), &segmentPieceInfo{offset: 8, length: 3})
+	assert.Equal(t, errInvalidPieceRange, err)
+}
+
+func TestDegradedReader_GetPiece(t *testing.T) {
+	reader := setupDegradedReader(t, []byte("0123"), []byte("4567"))
+	assert.Nil(t, reader.downloader.pieceStore.PutPiece("1_s0", []byte("0123")))
+	assert.Nil(t, reader.downloader.pieceStore.PutPiece("1_s1", []byte("xxxx")))
+
+	// the verified local segment piece is served directly
+	data, err := reader.getPiece(&segmentPieceInfo{segmentPieceKey: "1_s0", segmentIndex: 0, offset: 1, length: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("12"), data)
+
+	// the corrupt and the lost segment pieces are reconstructed, which fails without secondary sps
+	_, err = reader.getPiece(&segmentPieceInfo{segmentPieceKey: "1_s1", segmentIndex: 1, offset: 0, length: 4})
+	assert.Equal(t, gatewayclient.ErrInsufficientRecoveryPieces, err)
+	_, err = reader.getPiece(&segmentPieceInfo{segmentPieceKey: "1_s2", segmentIndex: 1, offset: 0, length: 4})
+	assert.Equal(t, gatewayclient.ErrInsufficientRecoveryPieces, err)
+}
+
+func TestDegradedReader_VerifySegmentPiece(t *testing.T) {
+	reader := setupDegradedReader(t, []byte("0123"), []byte("4567"))
+	assert.Nil(t, reader.verifySegmentPiece(1, []byte("4567")))
+	assert.NotNil(t, reader.verifySegmentPiece(1, []byte("0123")))
+
+	// the local segment pieces are not verified without the integrity meta
+	reader.integrity = nil
+	assert.Nil(t, reader.verifySegmentPiece(1, []byte("0123")))
+}
+
+func TestDegradedReader_VerifyByPieceStore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	integrityDB := sqldb.NewMockSPDB(ctrl)
+	reader := setupDegradedReader(t, []byte("0123"), []byte("4567"))
+	reader.verifyLocal = false
+	// every full piece read is verified once by the piece store
+	integrityDB.EXPECT().GetObjectIntegrity(uint64(1)).Return(reader.integrity, nil).Times(2)
+	pieceStore, err := psclient.NewStoreClient(&storage.PieceStoreConfig{
+		Store: storage.ObjectStorageConfig{Storage: "file", BucketURL: t.TempDir() + "/"},
+	}, psclient.WithVerifyingRead(integrityDB))
+	assert.Nil(t, err)
+	reader.downloader.pieceStore = pieceStore
+	assert.Nil(t, pieceStore.PutPiece("1_s0", []byte("0123")))
+	assert.Nil(t, pieceStore.PutPiece("1_s1", []byte("xxxx")))
+
+	data, err := reader.getPiece(&segmentPieceInfo{segmentPieceKey: "1_s0", segmentIndex: 0, offset: 0, length: 4})
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("0123"), data)
+	_, err = reader.getPiece(&segmentPieceInfo{segmentPieceKey: "1_s1", segmentIndex: 1, offset: 0, length: 4})
+	assert.Equal(t, gatewayclient.ErrInsufficientRecoveryPieces, err)
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/bnb-chain/greenfield-storage-provider/pkg/metrics"
 	"github.com/bnb-chain/greenfield-storage-provider/pkg/rcmgr"
 	"github.com/bnb-chain/greenfield-storage-provider/service/downloader/types"
+	signerclient "github.com/bnb-chain/greenfield-storage-provider/service/signer/client"
 	psclient "github.com/bnb-chain/greenfield-storage-provider/store/piecestore/client"
 	"github.com/bnb-chain/greenfield-storage-provider/store/sqldb"
 	utilgrpc "github.com/bnb-chain/greenfield-storage-provider/util/grpc"
@@ -26,6 +27,7 @@ type Downloader struct {
 	config     *DownloaderConfig
 	spDB       sqldb.SPDB
 	pieceStore *psclient.StoreClient
+	signer     *signerclient.SignerClient
 	grpcServer *grpc.Server
 	rcScope    rcmgr.ResourceScope
 }
@@ -40,14 +42,27 @@ func NewDownloaderService(cfg *DownloaderConfig) (*Downloader, error) {
 	downloader = &Downloader{
 		config: cfg,
 	}
+	if downloader.config.DegradedReadCfg == nil {
+		downloader.config.DegradedReadCfg = DefaultDegradedReadConfig
+	}
 	if downloader.spDB, err = sqldb.NewSpDB(cfg.SpDBConfig); err != nil {
 		log.Errorw("failed to create sp db client", "error", err)
 		return nil, err
 	}
-	if downloader.pieceStore, err = psclient.NewStoreClient(cfg.PieceStoreConfig); err != nil {
+	var storeOpts []psclient.StoreClientOption
+	if cfg.PieceStoreConfig.VerifyRead {
+		storeOpts = append(storeOpts, psclient.WithVerifyingRead(downloader.spDB))
+	}
+	if downloader.pieceStore, err = psclient.NewStoreClient(cfg.PieceStoreConfig, storeOpts...); err != nil {
 		log.Errorw("failed to create piece store client", "error", err)
 		return nil, err
 	}
+	if downloader.config.DegradedReadCfg.Enabled {
+		if downloader.signer, err = signerclient.NewSignerClient(cfg.SignerGrpcAddress); err != nil {
+			log.Errorw("failed to create signer client", "error", err)
+			return nil, err
+		}
+	}
 	if downloader.rcScope, err = rcmgr.ResrcManager().OpenService(model.DownloaderService); err != nil {
 		log.Errorw("failed to open downloader resource scope", "error", err)
 		return nil, err
@@ -71,6 +86,9 @@ func (downloader *Downloader) Start(ctx context.Context) error {
 // Stop the downloader gRPC service and recycle the resources
 func (downloader *Downloader) Stop(ctx context.Context) error {
 	downloader.grpcServer.GracefulStop()
+	if downloader.signer != nil {
+		downloader.signer.Close()
+	}
 	downloader.rcScope.Release()
 	return nil
 }
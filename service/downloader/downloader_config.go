@@ -7,7 +7,26 @@ import (
 
 // DownloaderConfig defines downloader service config
 type DownloaderConfig struct {
-	GRPCAddress      string
-	SpDBConfig       *config.SQLDBConfig
-	PieceStoreConfig *storage.PieceStoreConfig
+	GRPCAddress       string
+	SpOperatorAddress string
+	SignerGrpcAddress string
+	SpDBConfig        *config.SQLDBConfig
+	PieceStoreConfig  *storage.PieceStoreConfig
+	DegradedReadCfg   *DegradedReadConfig
+}
+
+// DegradedReadConfig defines the config of serving the object when the local segment pieces are lost or corrupt,
+// the segment pieces are reconstructed from the pieces of the secondary sps
+type DegradedReadConfig struct {
+	// Enabled defines whether to verify the local segment pieces and reconstruct the lost or corrupt ones
+	Enabled bool
+	// WriteBack defines whether to write the reconstructed segment pieces back to the local piece store
+	WriteBack bool
+}
+
+// DefaultDegradedReadConfig defines the default config of degraded read, it is disabled by default because
+// every segment piece is read in full and verified, which costs more than the ranged read of the default path
+var DefaultDegradedReadConfig = &DegradedReadConfig{
+	Enabled:   false,
+	WriteBack: false,
 }
@@ -11,6 +11,7 @@ import (
 	merrors "github.com/bnb-chain/greenfield-storage-provider/model/errors"
 	"github.com/bnb-chain/greenfield-storage-provider/model/piecestore"
 	"github.com/bnb-chain/greenfield-storage-provider/pkg/log"
+	"github.com/bnb-chain/greenfield-storage-provider/pkg/metrics"
 	"github.com/bnb-chain/greenfield-storage-provider/pkg/rcmgr"
 	"github.com/bnb-chain/greenfield-storage-provider/service/downloader/types"
 	"github.com/bnb-chain/greenfield-storage-provider/store/sqldb"
@@ -18,21 +19,21 @@ import (
 
 var _ types.DownloaderServiceServer = &Downloader{}
 
-// GetObject downloads the payload of the object.
+// GetObject downloads the payload of the object, the data of multiple ranges is sent in order of request ranges.
 func (downloader *Downloader) GetObject(req *types.GetObjectRequest,
 	stream types.DownloaderService_GetObjectServer) (err error) {
 	if req.GetObjectInfo() == nil {
 		return merrors.ErrDanglingPointer
 	}
 	var (
-		scope       rcmgr.ResourceScopeSpan
-		sendSize    int
-		objectInfo  = req.GetObjectInfo()
-		bucketInfo  = req.GetBucketInfo()
-		resp        = &types.GetObjectResponse{}
-		startOffset uint64
-		endOffset   uint64
-		ctx         = log.WithValue(context.Background(), "object_id", objectInfo.Id.String())
+		scope      rcmgr.ResourceScopeSpan
+		sendSize   int
+		objectInfo = req.GetObjectInfo()
+		bucketInfo = req.GetBucketInfo()
+		resp       = &types.GetObjectResponse{}
+		ranges     = req.GetRanges()
+		readSize   uint64
+		ctx        = log.WithValue(context.Background(), "object_id", objectInfo.Id.String())
 	)
 	defer func() {
 		if scope != nil {
@@ -42,18 +43,28 @@ func (downloader *Downloader) GetObject(req *types.GetObjectRequest,
 			"resource_state", rcmgr.GetServiceState(model.DownloaderService), "error", err)
 	}()
 
+	if len(ranges) == 0 {
+		objectRange := &types.ObjectRange{Start: 0, End: objectInfo.GetPayloadSize() - 1}
+		if req.GetIsRange() {
+			objectRange = &types.ObjectRange{Start: req.GetRangeStart(), End: req.GetRangeEnd()}
+		}
+		ranges = []*types.ObjectRange{objectRange}
+	}
+	rangePieceInfos := make([][]*segmentPieceInfo, len(ranges))
+	for i, objectRange := range ranges {
+		if rangePieceInfos[i], err = downloader.SplitToSegmentPieceInfos(objectInfo.Id.Uint64(),
+			objectInfo.GetPayloadSize(), objectRange.GetStart(), objectRange.GetEnd()); err != nil {
+			return
+		}
+		// the overlapped ranges are charged repeatedly since the data is served repeatedly
+		readSize += objectRange.GetEnd() - objectRange.GetStart() + 1
+	}
+
 	scope, err = downloader.rcScope.BeginSpan()
 	if err != nil {
 		log.CtxErrorw(ctx, "failed to begin reserve resource", "error", err)
 		return
 	}
-	startOffset = uint64(0)
-	endOffset = objectInfo.GetPayloadSize() - 1
-	if req.GetIsRange() {
-		startOffset = req.GetRangeStart()
-		endOffset = req.GetRangeEnd()
-	}
-	readSize := endOffset - startOffset + 1
 	err = scope.ReserveMemory(int(readSize), rcmgr.ReservationPriorityAlways)
 	if err != nil {
 		log.CtxErrorw(ctx, "failed to reserve memory from resource manager",
@@ -78,25 +89,122 @@ func (downloader *Downloader) GetObject(req *types.GetObjectRequest,
 		return merrors.InnerErrorToGRPCError(err)
 	}
 
-	pieceInfos, err := downloader.SplitToSegmentPieceInfos(objectInfo.Id.Uint64(), objectInfo.GetPayloadSize(), startOffset, endOffset)
-	if err != nil {
-		return
-	}
-	for _, pInfo := range pieceInfos {
-		resp.Data, err = downloader.pieceStore.GetPiece(ctx, pInfo.segmentPieceKey, int64(pInfo.offset), int64(pInfo.length))
-		if err != nil {
+	var reader *degradedReader
+	if downloader.config.DegradedReadCfg.Enabled {
+		if reader, err = newDegradedReader(ctx, downloader, objectInfo); err != nil {
 			return
 		}
-		if err = stream.Send(resp); err != nil {
-			return
+	}
+	cache := newSharedPieceCache(rangePieceInfos)
+	for rangeIndex, pieceInfos := range rangePieceInfos {
+		for _, pInfo := range pieceInfos {
+			if resp.Data, err = cache.getPiece(pInfo, func(pInfo *segmentPieceInfo) ([]byte, error) {
+				return downloader.getSegmentPiece(ctx, reader, pInfo)
+			}); err != nil {
+				if errors.Is(err, merrors.ErrPieceCorrupt) {
+					metrics.PieceCorruptCounter.WithLabelValues(model.DownloaderService).Inc()
+					err = merrors.InnerErrorToGRPCError(err)
+				}
+				return
+			}
+			resp.RangeIndex = uint32(rangeIndex)
+			if err = stream.Send(resp); err != nil {
+				return
+			}
+			sendSize += len(resp.Data)
 		}
-		sendSize += len(resp.Data)
 	}
 	return
 }
 
+// getSegmentPiece returns the data of the segment piece in the range of piece info.
+func (downloader *Downloader) getSegmentPiece(ctx context.Context, reader *degradedReader, pInfo *segmentPieceInfo) ([]byte, error) {
+	if reader != nil {
+		return reader.getPiece(pInfo)
+	}
+	if downloader.config.PieceStoreConfig.VerifyRead {
+		// only the full piece reads are verified, so read the whole segment piece and slice it
+		data, err := downloader.pieceStore.GetPiece(ctx, pInfo.segmentPieceKey, 0, 0)
+		if err != nil {
+			return nil, err
+		}
+		return sliceSegmentPiece(data, pInfo)
+	}
+	return downloader.pieceStore.GetPiece(ctx, pInfo.segmentPieceKey, int64(pInfo.offset), int64(pInfo.length))
+}
+
+// sharedPieceCache reads the segment piece shared by multiple ranges only once, the window covering all the
+// shared parts is read and kept until the last range referring to it is served.
+type sharedPieceCache struct {
+	windows map[string]*segmentPieceInfo
+	refs    map[string]int
+	data    map[string][]byte
+}
+
+func newSharedPieceCache(rangePieceInfos [][]*segmentPieceInfo) *sharedPieceCache {
+	cache := &sharedPieceCache{
+		windows: make(map[string]*segmentPieceInfo),
+		refs:    make(map[string]int),
+		data:    make(map[string][]byte),
+	}
+	for _, pieceInfos := range rangePieceInfos {
+		for _, pInfo := range pieceInfos {
+			cache.refs[pInfo.segmentPieceKey]++
+			window, ok := cache.windows[pInfo.segmentPieceKey]
+			if !ok {
+				cache.windows[pInfo.segmentPieceKey] = &segmentPieceInfo{
+					segmentPieceKey: pInfo.segmentPieceKey,
+					segmentIndex:    pInfo.segmentIndex,
+					offset:          pInfo.offset,
+					length:          pInfo.length,
+				}
+				continue
+			}
+			end := window.offset + window.length
+			if pInfo.offset+pInfo.length > end {
+				end = pInfo.offset + pInfo.length
+			}
+			if pInfo.offset < window.offset {
+				window.offset = pInfo.offset
+			}
+			window.length = end - window.offset
+		}
+	}
+	return cache
+}
+
+// getPiece returns the data of the piece info, the read function is called directly if the segment piece
+// is not shared by other ranges, otherwise the covering window is read once and sliced.
+func (cache *sharedPieceCache) getPiece(pInfo *segmentPieceInfo,
+	read func(pInfo *segmentPieceInfo) ([]byte, error)) ([]byte, error) {
+	key := pInfo.segmentPieceKey
+	data, ok := cache.data[key]
+	if !ok && cache.refs[key] <= 1 {
+		delete(cache.refs, key)
+		return read(pInfo)
+	}
+	window := cache.windows[key]
+	if !ok {
+		var err error
+		if data, err = read(window); err != nil {
+			return nil, err
+		}
+		cache.data[key] = data
+	}
+	if cache.refs[key]--; cache.refs[key] == 0 {
+		delete(cache.data, key)
+	}
+	return sliceSegmentPiece(data, &segmentPieceInfo{
+		segmentPieceKey: key,
+		segmentIndex:    pInfo.segmentIndex,
+		offset:          pInfo.offset - window.offset,
+		length:          pInfo.length,
+	})
+}
+
 type segmentPieceInfo struct {
 	segmentPieceKey string
+	segmentIndex    uint32
 	offset          uint64
 	length          uint64
 }
@@ -133,6 +241,7 @@ func (downloader *Downloader) SplitToSegmentPieceInfos(objectID, objectSize, sta
 			lengthInPiece := currentEnd - currentStart + 1
 			pieceInfos = append(pieceInfos, &segmentPieceInfo{
 				segmentPieceKey: piecestore.EncodeSegmentPieceKey(objectID, uint32(segmentPieceIndex)),
+				segmentIndex:    uint32(segmentPieceIndex),
 				offset:          offsetInPiece,
 				length:          lengthInPiece,
 			})
@@ -143,6 +252,7 @@ func (downloader *Downloader) SplitToSegmentPieceInfos(objectID, objectSize, sta
 			lengthInPiece := currentEnd - currentStart + 1
 			pieceInfos = append(pieceInfos, &segmentPieceInfo{
 				segmentPieceKey: piecestore.EncodeSegmentPieceKey(objectID, uint32(segmentPieceIndex)),
+				segmentIndex:    uint32(segmentPieceIndex),
 				offset:          offsetInPiece,
 				length:          lengthInPiece,
 			})
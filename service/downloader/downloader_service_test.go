@@ -135,3 +135,33 @@ func TestSplitToSegmentPieceInfos(t *testing.T) {
 		})
 	}
 }
+
+func TestSharedPieceCache(t *testing.T) {
+	data := []byte("0123456789")
+	var reads []*segmentPieceInfo
+	read := func(pInfo *segmentPieceInfo) ([]byte, error) {
+		reads = append(reads, pInfo)
+		return data[pInfo.offset : pInfo.offset+pInfo.length], nil
+	}
+	rangePieceInfos := [][]*segmentPieceInfo{
+		{{segmentPieceKey: "s0", offset: 2, length: 3}},
+		{{segmentPieceKey: "s1", offset: 0, length: 4}},
+		{{segmentPieceKey: "s0", offset: 6, length: 2}},
+	}
+	cache := newSharedPieceCache(rangePieceInfos)
+	var got []string
+	for _, pieceInfos := range rangePieceInfos {
+		for _, pInfo := range pieceInfos {
+			piece, err := cache.getPiece(pInfo, read)
+			require.NoError(t, err)
+			got = append(got, string(piece))
+		}
+	}
+	assert.Equal(t, []string{"234", "0123", "67"}, got)
+	// the shared segment piece is read once with the window covering both ranges
+	require.Equal(t, 2, len(reads))
+	assert.Equal(t, "s0", reads[0].segmentPieceKey)
+	assert.Equal(t, uint64(2), reads[0].offset)
+	assert.Equal(t, uint64(6), reads[0].length)
+	assert.Empty(t, cache.data)
+}
@@ -1,18 +1,25 @@
 package gateway
 
 import (
+	"bytes"
 	"context"
 	"encoding/hex"
 	"net/http"
+	"strings"
 
 	"github.com/bnb-chain/greenfield/x/storage/types"
 	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gogoproto/jsonpb"
 
 	"github.com/bnb-chain/greenfield-storage-provider/model"
 	"github.com/bnb-chain/greenfield-storage-provider/pkg/log"
+	challengetypes "github.com/bnb-chain/greenfield-storage-provider/service/challenge/types"
 	"github.com/bnb-chain/greenfield-storage-provider/util"
 )
 
+// defaultScrubResultLimit defines the default max number of the queried scrub results
+const defaultScrubResultLimit int32 = 100
+
 // getApprovalHandler handles the bucket create or object create approval
 func (gateway *Gateway) getApprovalHandler(w http.ResponseWriter, r *http.Request) {
 	var (
@@ -194,3 +201,93 @@ func (gateway *Gateway) challengeHandler(w http.ResponseWriter, r *http.Request)
 	w.Header().Set(model.GnfdPieceHashHeader, util.BytesSliceToString(pieceHash))
 	w.Write(pieceData)
 }
+
+// scrubResultHandler handles the sp operator's request to query the scrub results of the locally stored pieces,
+// the result of the object is queried if the object id header is set, otherwise the latest results in the status.
+func (gateway *Gateway) scrubResultHandler(w http.ResponseWriter, r *http.Request) {
+	var (
+		err            error
+		b              bytes.Buffer
+		errDescription *errorDescription
+		reqContext     *requestContext
+		addr           sdktypes.AccAddress
+		objectID       uint64
+		limit          = defaultScrubResultLimit
+		results        []*challengetypes.ScrubResult
+	)
+
+	reqContext = newRequestContext(r)
+	defer func() {
+		if errDescription != nil {
+			_ = errDescription.errorResponse(w, reqContext)
+		}
+		if errDescription != nil && errDescription.statusCode != http.StatusOK {
+			log.Errorf("action(%v) statusCode(%v) %v", scrubResultRouterName, errDescription.statusCode, reqContext.generateRequestDetail())
+		} else {
+			log.Infof("action(%v) statusCode(200) %v", scrubResultRouterName, reqContext.generateRequestDetail())
+		}
+	}()
+
+	if gateway.challenge == nil {
+		log.Errorw("failed to query scrub results due to not config challenge")
+		errDescription = NotExistComponentError
+		return
+	}
+	if addr, err = gateway.verifySignature(reqContext); err != nil {
+		log.Errorw("failed to verify signature", "error", err)
+		errDescription = makeErrorDescription(err)
+		return
+	}
+	if err = gateway.checkAuthorization(reqContext, addr); err != nil {
+		log.Errorw("failed to check authorization", "error", err)
+		errDescription = makeErrorDescription(err)
+		return
+	}
+
+	if header := reqContext.request.Header.Get(model.GnfdObjectIDHeader); header != "" {
+		if objectID, err = util.StringToUint64(header); err != nil {
+			log.Errorw("failed to parse object_id", "object_id", header)
+			errDescription = InvalidHeader
+			return
+		}
+	}
+	status, ok := parseScrubStatus(reqContext.request.URL.Query().Get(model.ScrubStatusQuery))
+	if !ok {
+		log.Errorw("failed to parse scrub status", "status", reqContext.request.URL.Query().Get(model.ScrubStatusQuery))
+		errDescription = InvalidQuery
+		return
+	}
+	if query := reqContext.request.URL.Query().Get(model.ScrubLimitQuery); query != "" {
+		if limit, err = util.StringToInt32(query); err != nil {
+			log.Errorw("failed to parse limit", "limit", query)
+			errDescription = InvalidQuery
+			return
+		}
+	}
+	if results, err = gateway.challenge.QueryScrubResults(context.Background(), objectID, status, limit); err != nil {
+		log.Errorw("failed to query scrub results", "error", err)
+		errDescription = makeErrorDescription(err)
+		return
+	}
+	m := jsonpb.Marshaler{EmitDefaults: true, OrigName: true}
+	if err = m.Marshal(&b, &challengetypes.QueryScrubResultsResponse{Results: results}); err != nil {
+		log.Errorw("failed to marshal scrub results", "error", err)
+		errDescription = makeErrorDescription(err)
+		return
+	}
+	w.Header().Set(model.GnfdRequestIDHeader, reqContext.requestID)
+	w.Header().Set(model.ContentTypeHeader, model.ContentTypeJSONHeaderValue)
+	w.Write(b.Bytes())
+}
+
+// parseScrubStatus parses the scrub status query, e.g. corrupt, the corrupt status is returned if it is empty.
+func parseScrubStatus(query string) (challengetypes.ScrubStatus, bool) {
+	if query == "" {
+		return challengetypes.ScrubStatus_SCRUB_STATUS_CORRUPT, true
+	}
+	status, ok := challengetypes.ScrubStatus_value["SCRUB_STATUS_"+strings.ToUpper(query)]
+	if !ok || status == int32(challengetypes.ScrubStatus_SCRUB_STATUS_UNSPECIFIED) {
+		return challengetypes.ScrubStatus_SCRUB_STATUS_UNSPECIFIED, false
+	}
+	return challengetypes.ScrubStatus(status), true
+}
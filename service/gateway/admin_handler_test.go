@@ -0,0 +1,51 @@
+package gateway
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/greenfield-storage-provider/model"
+	challengeclient "github.com/bnb-chain/greenfield-storage-provider/service/challenge/client"
+	challengetypes "github.com/bnb-chain/greenfield-storage-provider/service/challenge/types"
+)
+
+func TestParseScrubStatus(t *testing.T) {
+	cases := []struct {
+		query        string
+		wantedStatus challengetypes.ScrubStatus
+		wantedOK     bool
+	}{
+		{"", challengetypes.ScrubStatus_SCRUB_STATUS_CORRUPT, true},
+		{"healthy", challengetypes.ScrubStatus_SCRUB_STATUS_HEALTHY, true},
+		{"Repaired", challengetypes.ScrubStatus_SCRUB_STATUS_REPAIRED, true},
+		{"unspecified", challengetypes.ScrubStatus_SCRUB_STATUS_UNSPECIFIED, false},
+		{"unknown", challengetypes.ScrubStatus_SCRUB_STATUS_UNSPECIFIED, false},
+	}
+	for _, tt := range cases {
+		t.Run(tt.query, func(t *testing.T) {
+			status, ok := parseScrubStatus(tt.query)
+			assert.Equal(t, tt.wantedStatus, status)
+			assert.Equal(t, tt.wantedOK, ok)
+		})
+	}
+}
+
+func TestScrubResultHandler_DenyAuthV2(t *testing.T) {
+	gateway := &Gateway{config: config, challenge: &challengeclient.ChallengeClient{}}
+	router := mux.NewRouter().SkipClean(true)
+	router.Path(model.ScrubResultPath).
+		Name(scrubResultRouterName).
+		Methods(http.MethodGet).
+		HandlerFunc(gateway.scrubResultHandler)
+
+	r := httptest.NewRequest(http.MethodGet, scheme+testDomain+model.ScrubResultPath, nil)
+	r.Header.Set(model.GnfdAuthorizationHeader,
+		signaturePrefix(model.SignTypeV2, model.SignAlgorithm)+" "+model.Signature+"=00")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
@@ -6,12 +6,14 @@ import (
 	"net/http"
 	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/gogoproto/jsonpb"
 
 	"github.com/bnb-chain/greenfield-storage-provider/model"
+	merrors "github.com/bnb-chain/greenfield-storage-provider/model/errors"
 	"github.com/bnb-chain/greenfield-storage-provider/pkg/log"
 	authtypes "github.com/bnb-chain/greenfield-storage-provider/service/auth/types"
 )
@@ -257,3 +259,69 @@ func (g *Gateway) verifySignedContent(signedContent string, expectedDomain strin
 
 	return nil
 }
+
+// createS3CredentialHandler handles the create s3 credential request, which must be signed by the user's off chain
+// auth key, the created s3 credential is bound to the off chain auth key of the user and domain.
+func (g *Gateway) createS3CredentialHandler(w http.ResponseWriter, r *http.Request) {
+	var (
+		err            error
+		b              bytes.Buffer
+		errDescription *errorDescription
+		reqContext     *requestContext
+		userAddress    sdk.AccAddress
+		statusCode     = http.StatusOK
+	)
+
+	reqContext = newRequestContext(r)
+	defer func() {
+		if errDescription != nil {
+			statusCode = errDescription.statusCode
+			_ = errDescription.errorResponse(w, reqContext)
+		}
+		if statusCode == http.StatusOK {
+			log.Infof("action(%v) statusCode(%v) %v", createS3CredentialRouterName, statusCode, reqContext.generateRequestDetail())
+		} else {
+			log.Errorf("action(%v) statusCode(%v) %v", createS3CredentialRouterName, statusCode, reqContext.generateRequestDetail())
+		}
+	}()
+
+	if g.auth == nil {
+		log.Errorw("failed to create s3 credential due to not config auth client")
+		errDescription = NotExistComponentError
+		return
+	}
+
+	offChainSignaturePrefix := signaturePrefix(model.SignTypeOffChain, model.SignAlgorithmEddsa)
+	if !strings.HasPrefix(reqContext.request.Header.Get(model.GnfdAuthorizationHeader), offChainSignaturePrefix) {
+		log.Errorw("failed to create s3 credential due to not signed by off chain auth key")
+		errDescription = SignatureNotMatch
+		return
+	}
+	if userAddress, err = g.verifySignature(reqContext); err != nil {
+		log.Errorw("failed to verify signature", "error", err)
+		errDescription = SignatureNotMatch
+		return
+	}
+
+	req := &authtypes.CreateS3CredentialRequest{
+		AccountId: userAddress.String(),
+		Domain:    reqContext.request.Header.Get(model.GnfdOffChainAuthAppDomainHeader),
+	}
+	ctx := log.Context(context.Background(), req)
+	resp, err := g.auth.CreateS3Credential(ctx, req)
+	if err != nil {
+		log.Errorw("failed to create s3 credential", "error", err)
+		errDescription = makeErrorDescription(merrors.GRPCErrorToInnerError(err))
+		return
+	}
+
+	m := jsonpb.Marshaler{EmitDefaults: true, OrigName: true, EnumsAsInts: true}
+	if err = m.Marshal(&b, resp); err != nil {
+		log.Errorw("failed to create s3 credential", "error", err)
+		errDescription = makeErrorDescription(err)
+		return
+	}
+
+	w.Header().Set(model.ContentTypeHeader, model.ContentTypeJSONHeaderValue)
+	w.Write(b.Bytes())
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/bnb-chain/greenfield-storage-provider/model"
 	"github.com/bnb-chain/greenfield-storage-provider/pkg/log"
 	p2ptypes "github.com/bnb-chain/greenfield-storage-provider/pkg/p2p/types"
+	servicetypes "github.com/bnb-chain/greenfield-storage-provider/service/types"
 	"github.com/bnb-chain/greenfield-storage-provider/util"
 )
 
@@ -91,3 +92,53 @@ func (client *GatewayClient) ReplicateObjectPieceStream(objectID uint64, pieceSi
 	}
 	return integrityHash, signature, nil
 }
+
+// GetRecoveryPiece gets the piece data from the target storage-provider, and returns the integrity hash and the
+// piece hash list of the piece list, which are used to verify the piece data. redundancyIdx < 0 means getting
+// the segment piece, otherwise getting the ec piece.
+func (client *GatewayClient) GetRecoveryPiece(objectID uint64, segmentIdx uint32, redundancyIdx int32,
+	approval *servicetypes.RecoveryPieceApproval) (integrityHash []byte, pieceHash [][]byte, pieceData []byte, err error) {
+	req, err := http.NewRequest(http.MethodGet, client.address+model.RecoveryPiecePath, nil)
+	if err != nil {
+		log.Errorw("failed to get recovery piece due to new request error", "error", err)
+		return nil, nil, nil, err
+	}
+	marshalApproval, err := json.Marshal(approval)
+	if err != nil {
+		log.Errorw("failed to marshal approval", "error", err)
+		return nil, nil, nil, err
+	}
+	req.Header.Add(model.GnfdObjectIDHeader, util.Uint64ToString(objectID))
+	req.Header.Add(model.GnfdPieceIndexHeader, util.Uint32ToString(segmentIdx))
+	req.Header.Add(model.GnfdRedundancyIndexHeader, util.Int32ToString(redundancyIdx))
+	req.Header.Add(model.GnfdRecoveryApprovalHeader, string(marshalApproval))
+
+	resp, err := client.httpClient.Do(req)
+	if err != nil {
+		log.Errorw("failed to get recovery piece from other sp", "sp_endpoint", client.address, "error", err)
+		return nil, nil, nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		log.Errorw("failed to get recovery piece", "status_code", resp.StatusCode, "sp_endpoint", client.address)
+		return nil, nil, nil, fmt.Errorf("failed to get recovery piece")
+	}
+
+	if integrityHash, err = hex.DecodeString(resp.Header.Get(model.GnfdIntegrityHashHeader)); err != nil {
+		log.Errorw("failed to parse integrity hash header",
+			"integrity_hash", resp.Header.Get(model.GnfdIntegrityHashHeader),
+			"sp_endpoint", client.address, "error", err)
+		return nil, nil, nil, err
+	}
+	if pieceHash, err = util.StringToBytesSlice(resp.Header.Get(model.GnfdPieceHashHeader)); err != nil {
+		log.Errorw("failed to parse piece hash header",
+			"piece_hash", resp.Header.Get(model.GnfdPieceHashHeader),
+			"sp_endpoint", client.address, "error", err)
+		return nil, nil, nil, err
+	}
+	if pieceData, err = io.ReadAll(resp.Body); err != nil {
+		log.Errorw("failed to read recovery piece data", "sp_endpoint", client.address, "error", err)
+		return nil, nil, nil, err
+	}
+	return integrityHash, pieceHash, pieceData, nil
+}
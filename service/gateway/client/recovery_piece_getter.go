@@ -0,0 +1,102 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/bnb-chain/greenfield-common/go/hash"
+	storagetypes "github.com/bnb-chain/greenfield/x/storage/types"
+	"google.golang.org/grpc"
+
+	"github.com/bnb-chain/greenfield-storage-provider/pkg/log"
+	servicetypes "github.com/bnb-chain/greenfield-storage-provider/service/types"
+	"github.com/bnb-chain/greenfield-storage-provider/store/sqldb"
+)
+
+const (
+	// DefaultRecoveryApprovalExpiredTime defines the valid duration of the approval of getting the pieces from
+	// other sps
+	DefaultRecoveryApprovalExpiredTime = 10 * time.Minute
+)
+
+// ErrInsufficientRecoveryPieces defines not enough pieces are got from other sps to reconstruct the segment
+var ErrInsufficientRecoveryPieces = errors.New("insufficient pieces from other sps to reconstruct segment")
+
+// RecoveryApprovalSigner signs the approval of getting the pieces from other sps, it is implemented by the
+// signer client
+type RecoveryApprovalSigner interface {
+	SignRecoveryPieceApproval(ctx context.Context, approval *servicetypes.RecoveryPieceApproval,
+		opts ...grpc.CallOption) (*servicetypes.RecoveryPieceApproval, error)
+}
+
+// RecoveryPieceGetter gets the pieces of an object from other sps to recover the lost or corrupt pieces,
+// the approval of getting the pieces is signed once per getter.
+type RecoveryPieceGetter struct {
+	objectInfo        *storagetypes.ObjectInfo
+	spOperatorAddress string
+	spDB              sqldb.SPInfo
+	signer            RecoveryApprovalSigner
+	approval          *servicetypes.RecoveryPieceApproval
+}
+
+// NewRecoveryPieceGetter returns a RecoveryPieceGetter instance, spOperatorAddress is the operator address of
+// this sp which signs the approval.
+func NewRecoveryPieceGetter(objectInfo *storagetypes.ObjectInfo, spOperatorAddress string, spDB sqldb.SPInfo,
+	signer RecoveryApprovalSigner) *RecoveryPieceGetter {
+	return &RecoveryPieceGetter{
+		objectInfo:        objectInfo,
+		spOperatorAddress: spOperatorAddress,
+		spDB:              spDB,
+		signer:            signer,
+	}
+}
+
+// GetRecoveryPiece gets the piece from the sp and verifies it against the checksum of the object at checksumIdx,
+// the checksum of the primary sp is at 0 and the checksum of the secondary sp is at its redundancy index + 1.
+// pieceRedundancyIdx < 0 means getting the segment piece, otherwise getting the ec piece.
+func (g *RecoveryPieceGetter) GetRecoveryPiece(ctx context.Context, spAddress string, segmentIdx uint32,
+	pieceRedundancyIdx int32, checksumIdx int) ([]byte, error) {
+	approval, err := g.getApproval(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sp, err := g.spDB.GetSpByAddress(spAddress, sqldb.OperatorAddressType)
+	if err != nil {
+		log.CtxErrorw(ctx, "failed to get sp info", "sp_address", spAddress, "error", err)
+		return nil, err
+	}
+	client, err := NewGatewayClient(sp.GetEndpoint())
+	if err != nil {
+		log.CtxErrorw(ctx, "failed to create gateway client", "sp_endpoint", sp.GetEndpoint(), "error", err)
+		return nil, err
+	}
+	_, pieceHash, data, err := client.GetRecoveryPiece(g.objectInfo.Id.Uint64(), segmentIdx, pieceRedundancyIdx, approval)
+	if err != nil {
+		return nil, err
+	}
+	if err = hash.ChallengePieceHash(g.objectInfo.GetChecksums()[checksumIdx], pieceHash, int(segmentIdx), data); err != nil {
+		log.CtxErrorw(ctx, "failed to verify recovery piece", "sp_endpoint", sp.GetEndpoint(),
+			"segment_idx", segmentIdx, "redundancy_idx", pieceRedundancyIdx, "error", err)
+		return nil, err
+	}
+	return data, nil
+}
+
+// getApproval returns the approval of getting the pieces from other sps, which is signed once per getter.
+func (g *RecoveryPieceGetter) getApproval(ctx context.Context) (*servicetypes.RecoveryPieceApproval, error) {
+	if g.approval != nil {
+		return g.approval, nil
+	}
+	approval, err := g.signer.SignRecoveryPieceApproval(ctx, &servicetypes.RecoveryPieceApproval{
+		ObjectId:          g.objectInfo.Id.Uint64(),
+		SpOperatorAddress: g.spOperatorAddress,
+		ExpiredTime:       time.Now().Add(DefaultRecoveryApprovalExpiredTime).Unix(),
+	})
+	if err != nil {
+		log.CtxErrorw(ctx, "failed to sign recovery piece approval", "error", err)
+		return nil, err
+	}
+	g.approval = approval
+	return approval, nil
+}
@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	"github.com/bnb-chain/greenfield-common/go/hash"
+	sptypes "github.com/bnb-chain/greenfield/x/sp/types"
+	storagetypes "github.com/bnb-chain/greenfield/x/storage/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+
+	"github.com/bnb-chain/greenfield-storage-provider/model"
+	servicetypes "github.com/bnb-chain/greenfield-storage-provider/service/types"
+	"github.com/bnb-chain/greenfield-storage-provider/store/sqldb"
+	"github.com/bnb-chain/greenfield-storage-provider/util"
+)
+
+type mockApprovalSigner struct {
+	signedNumber int
+	err          error
+}
+
+func (s *mockApprovalSigner) SignRecoveryPieceApproval(ctx context.Context, approval *servicetypes.RecoveryPieceApproval,
+	opts ...grpc.CallOption) (*servicetypes.RecoveryPieceApproval, error) {
+	s.signedNumber++
+	if s.err != nil {
+		return nil, s.err
+	}
+	approval.Signature = []byte("signature")
+	return approval, nil
+}
+
+func TestRecoveryPieceGetter_GetRecoveryPiece(t *testing.T) {
+	pieces := [][]byte{[]byte("piece0"), []byte("piece1")}
+	checksums := [][]byte{hash.GenerateChecksum(pieces[0]), hash.GenerateChecksum(pieces[1])}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		approval := &servicetypes.RecoveryPieceApproval{}
+		assert.Nil(t, json.Unmarshal([]byte(r.Header.Get(model.GnfdRecoveryApprovalHeader)), approval))
+		assert.Equal(t, uint64(1), approval.GetObjectId())
+		segmentIdx, err := util.StringToUint32(r.Header.Get(model.GnfdPieceIndexHeader))
+		assert.Nil(t, err)
+		w.Header().Set(model.GnfdPieceHashHeader, util.BytesSliceToString(checksums))
+		w.Write(pieces[segmentIdx])
+	}))
+	defer server.Close()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	spDB := sqldb.NewMockSPDB(ctrl)
+	spDB.EXPECT().GetSpByAddress(gomock.Any(), sqldb.OperatorAddressType).
+		Return(&sptypes.StorageProvider{Endpoint: server.URL}, nil).AnyTimes()
+	signer := &mockApprovalSigner{}
+	objectInfo := &storagetypes.ObjectInfo{
+		Id: sdkmath.NewUint(1),
+		// the checksum of the secondary sp at redundancy index 0 mismatches
+		Checksums: [][]byte{hash.GenerateIntegrityHash(checksums), []byte("mismatch")},
+	}
+	getter := NewRecoveryPieceGetter(objectInfo, "sp", spDB, signer)
+
+	data, err := getter.GetRecoveryPiece(context.TODO(), "primary", 1, -1, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, pieces[1], data)
+	_, err = getter.GetRecoveryPiece(context.TODO(), "secondary", 1, -1, 1)
+	assert.NotNil(t, err)
+	// the approval is signed once per getter
+	assert.Equal(t, 1, signer.signedNumber)
+}
+
+func TestRecoveryPieceGetter_SignApprovalFailed(t *testing.T) {
+	signer := &mockApprovalSigner{err: errors.New("mock error")}
+	getter := NewRecoveryPieceGetter(&storagetypes.ObjectInfo{Id: sdkmath.NewUint(1)}, "sp", nil, signer)
+	_, err := getter.GetRecoveryPiece(context.TODO(), "primary", 0, -1, 0)
+	assert.Equal(t, signer.err, err)
+}
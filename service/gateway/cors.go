@@ -0,0 +1,310 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gogoproto/jsonpb"
+	"github.com/gorilla/mux"
+
+	"github.com/bnb-chain/greenfield-storage-provider/model"
+	merrors "github.com/bnb-chain/greenfield-storage-provider/model/errors"
+	"github.com/bnb-chain/greenfield-storage-provider/pkg/log"
+	authtypes "github.com/bnb-chain/greenfield-storage-provider/service/auth/types"
+)
+
+const (
+	// corsCacheTTL defines how long the cors rules of bucket are cached in gateway
+	corsCacheTTL = time.Minute
+	// maxCORSRuleCount defines the max number of cors rules of a bucket
+	maxCORSRuleCount = 100
+)
+
+// corsAllowedMethods are the methods which can be allowed by the cors rules
+var corsAllowedMethods = map[string]bool{
+	http.MethodGet:    true,
+	http.MethodPut:    true,
+	http.MethodPost:   true,
+	http.MethodDelete: true,
+	http.MethodHead:   true,
+}
+
+// corsCacheEntry is the cached cors rules of bucket
+type corsCacheEntry struct {
+	rules    []*authtypes.CORSRule
+	expireAt time.Time
+}
+
+// corsWildcardMatch returns whether the value matches the pattern which may contain at most one wildcard "*"
+func corsWildcardMatch(pattern, value string, ignoreCase bool) bool {
+	if ignoreCase {
+		pattern, value = strings.ToLower(pattern), strings.ToLower(value)
+	}
+	idx := strings.Index(pattern, "*")
+	if idx < 0 {
+		return pattern == value
+	}
+	prefix, suffix := pattern[:idx], pattern[idx+1:]
+	return len(value) >= len(prefix)+len(suffix) && strings.HasPrefix(value, prefix) && strings.HasSuffix(value, suffix)
+}
+
+// matchCORSRule returns the first cors rule which allows the origin, method and request headers, the request
+// headers are only checked in preflight request.
+func matchCORSRule(rules []*authtypes.CORSRule, origin, method string, requestHeaders []string) *authtypes.CORSRule {
+	for _, rule := range rules {
+		if !corsMatchAny(rule.GetAllowedOrigins(), origin, false) {
+			continue
+		}
+		methodAllowed := false
+		for _, m := range rule.GetAllowedMethods() {
+			if m == method {
+				methodAllowed = true
+				break
+			}
+		}
+		if !methodAllowed {
+			continue
+		}
+		headersAllowed := true
+		for _, header := range requestHeaders {
+			if !corsMatchAny(rule.GetAllowedHeaders(), header, true) {
+				headersAllowed = false
+				break
+			}
+		}
+		if headersAllowed {
+			return rule
+		}
+	}
+	return nil
+}
+
+func corsMatchAny(patterns []string, value string, ignoreCase bool) bool {
+	for _, pattern := range patterns {
+		if corsWildcardMatch(pattern, value, ignoreCase) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkCORSRules checks the cors rules put by bucket owner
+func checkCORSRules(rules []*authtypes.CORSRule) bool {
+	if len(rules) > maxCORSRuleCount {
+		return false
+	}
+	for _, rule := range rules {
+		if len(rule.GetAllowedOrigins()) == 0 || len(rule.GetAllowedMethods()) == 0 || rule.GetMaxAgeSeconds() < 0 {
+			return false
+		}
+		for _, method := range rule.GetAllowedMethods() {
+			if !corsAllowedMethods[method] {
+				return false
+			}
+		}
+		for _, pattern := range append(rule.GetAllowedOrigins(), rule.GetAllowedHeaders()...) {
+			if strings.Count(pattern, "*") > 1 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// getBucketCORSRules returns the cors rules of bucket, which are cached for corsCacheTTL
+func (g *Gateway) getBucketCORSRules(bucketName string) ([]*authtypes.CORSRule, error) {
+	if g.corsCache != nil {
+		if v, ok := g.corsCache.Get(bucketName); ok && time.Now().Before(v.(*corsCacheEntry).expireAt) {
+			return v.(*corsCacheEntry).rules, nil
+		}
+	}
+	req := &authtypes.GetBucketCORSRequest{BucketName: bucketName}
+	ctx := log.Context(context.Background(), req)
+	resp, err := g.auth.GetBucketCORS(ctx, req)
+	if err != nil {
+		return nil, merrors.GRPCErrorToInnerError(err)
+	}
+	if g.corsCache != nil {
+		g.corsCache.Add(bucketName, &corsCacheEntry{rules: resp.GetRules(), expireAt: time.Now().Add(corsCacheTTL)})
+	}
+	return resp.GetRules(), nil
+}
+
+// corsMiddleware adds the cors response headers to the actual cross-origin request of bucket routes if one of
+// the bucket cors rules allows it, otherwise no cors header is added and the browser blocks the response.
+func (g *Gateway) corsMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get(model.OriginHeader)
+		bucketName := mux.Vars(r)["bucket"]
+		if origin == "" || bucketName == "" || g.auth == nil || r.Method == http.MethodOptions {
+			next.ServeHTTP(w, r)
+			return
+		}
+		w.Header().Add(model.VaryHeader, model.OriginHeader)
+		rules, err := g.getBucketCORSRules(bucketName)
+		if err != nil {
+			log.Errorw("failed to get bucket cors rules", "bucket_name", bucketName, "error", err)
+			next.ServeHTTP(w, r)
+			return
+		}
+		if rule := matchCORSRule(rules, origin, r.Method, nil); rule != nil {
+			w.Header().Set(model.AccessControlAllowOriginHeader, origin)
+			if len(rule.GetExposeHeaders()) > 0 {
+				w.Header().Set(model.AccessControlExposeHeadersHeader, strings.Join(rule.GetExposeHeaders(), ", "))
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// corsPreflightHandler handles the cors preflight OPTIONS request of bucket routes
+func (g *Gateway) corsPreflightHandler(w http.ResponseWriter, r *http.Request) {
+	var (
+		err            error
+		errDescription *errorDescription
+		reqContext     *requestContext
+		rules          []*authtypes.CORSRule
+		requestHeaders []string
+	)
+
+	reqContext = newRequestContext(r)
+	defer func() {
+		if errDescription != nil {
+			_ = errDescription.errorResponse(w, reqContext)
+		}
+		if errDescription != nil && errDescription.statusCode != http.StatusOK {
+			log.Errorf("action(%v) statusCode(%v) %v", corsPreflightRouterName, errDescription.statusCode, reqContext.generateRequestDetail())
+		} else {
+			log.Infof("action(%v) statusCode(200) %v", corsPreflightRouterName, reqContext.generateRequestDetail())
+		}
+	}()
+
+	if g.auth == nil {
+		log.Error("failed to handle cors preflight due to not config auth client")
+		errDescription = NotExistComponentError
+		return
+	}
+	origin := r.Header.Get(model.OriginHeader)
+	method := r.Header.Get(model.AccessControlRequestMethodHeader)
+	if origin == "" || method == "" {
+		log.Errorw("failed to handle cors preflight due to missing origin or request method")
+		errDescription = CORSForbidden
+		return
+	}
+	for _, header := range strings.Split(r.Header.Get(model.AccessControlRequestHeadersHeader), ",") {
+		if header = strings.TrimSpace(header); header != "" {
+			requestHeaders = append(requestHeaders, header)
+		}
+	}
+	if rules, err = g.getBucketCORSRules(reqContext.bucketName); err != nil {
+		log.Errorw("failed to get bucket cors rules", "bucket_name", reqContext.bucketName, "error", err)
+		errDescription = makeErrorDescription(err)
+		return
+	}
+	rule := matchCORSRule(rules, origin, method, requestHeaders)
+	if rule == nil {
+		errDescription = CORSForbidden
+		return
+	}
+
+	w.Header().Set(model.GnfdRequestIDHeader, reqContext.requestID)
+	w.Header().Add(model.VaryHeader, model.OriginHeader)
+	w.Header().Set(model.AccessControlAllowOriginHeader, origin)
+	w.Header().Set(model.AccessControlAllowMethodsHeader, strings.Join(rule.GetAllowedMethods(), ", "))
+	if len(requestHeaders) > 0 {
+		w.Header().Set(model.AccessControlAllowHeadersHeader, strings.Join(requestHeaders, ", "))
+	}
+	if len(rule.GetExposeHeaders()) > 0 {
+		w.Header().Set(model.AccessControlExposeHeadersHeader, strings.Join(rule.GetExposeHeaders(), ", "))
+	}
+	if rule.GetMaxAgeSeconds() > 0 {
+		w.Header().Set(model.AccessControlMaxAgeHeader, strconv.FormatInt(rule.GetMaxAgeSeconds(), 10))
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// bucketCORSHandler handles the bucket owner's request to get, put or delete the cors configuration of bucket,
+// the put request body is the json encoded PutBucketCORSRequest whose empty rules delete the configuration.
+func (g *Gateway) bucketCORSHandler(w http.ResponseWriter, r *http.Request) {
+	var (
+		err            error
+		b              bytes.Buffer
+		errDescription *errorDescription
+		reqContext     *requestContext
+		addr           sdk.AccAddress
+	)
+
+	reqContext = newRequestContext(r)
+	defer func() {
+		if errDescription != nil {
+			_ = errDescription.errorResponse(w, reqContext)
+		}
+		if errDescription != nil && errDescription.statusCode != http.StatusOK {
+			log.Errorf("action(%v) statusCode(%v) %v", bucketCORSRouterName, errDescription.statusCode, reqContext.generateRequestDetail())
+		} else {
+			log.Infof("action(%v) statusCode(200) %v", bucketCORSRouterName, reqContext.generateRequestDetail())
+		}
+	}()
+
+	if g.auth == nil {
+		log.Error("failed to handle bucket cors due to not config auth client")
+		errDescription = NotExistComponentError
+		return
+	}
+	if addr, err = g.verifySignature(reqContext); err != nil {
+		log.Errorw("failed to verify signature", "error", err)
+		errDescription = makeErrorDescription(err)
+		return
+	}
+	if err = g.checkAuthorization(reqContext, addr); err != nil {
+		log.Errorw("failed to check authorization", "error", err)
+		errDescription = makeErrorDescription(err)
+		return
+	}
+
+	if r.Method == http.MethodGet {
+		req := &authtypes.GetBucketCORSRequest{BucketName: reqContext.bucketName}
+		ctx := log.Context(context.Background(), req)
+		resp, err := g.auth.GetBucketCORS(ctx, req)
+		if err != nil {
+			log.Errorw("failed to get bucket cors", "error", err)
+			errDescription = makeErrorDescription(merrors.GRPCErrorToInnerError(err))
+			return
+		}
+		m := jsonpb.Marshaler{EmitDefaults: true, OrigName: true}
+		if err = m.Marshal(&b, resp); err != nil {
+			log.Errorw("failed to marshal bucket cors", "error", err)
+			errDescription = makeErrorDescription(err)
+			return
+		}
+		w.Header().Set(model.GnfdRequestIDHeader, reqContext.requestID)
+		w.Header().Set(model.ContentTypeHeader, model.ContentTypeJSONHeaderValue)
+		w.Write(b.Bytes())
+		return
+	}
+
+	req := &authtypes.PutBucketCORSRequest{}
+	if r.Method == http.MethodPut {
+		if err = jsonpb.Unmarshal(r.Body, req); err != nil || !checkCORSRules(req.GetRules()) {
+			log.Errorw("failed to parse bucket cors configuration", "error", err)
+			errDescription = InvalidCORSConfiguration
+			return
+		}
+	}
+	req.BucketName = reqContext.bucketName
+	ctx := log.Context(context.Background(), req)
+	if _, err = g.auth.PutBucketCORS(ctx, req); err != nil {
+		log.Errorw("failed to put bucket cors", "error", err)
+		errDescription = makeErrorDescription(merrors.GRPCErrorToInnerError(err))
+		return
+	}
+	if g.corsCache != nil {
+		g.corsCache.Remove(reqContext.bucketName)
+	}
+	w.Header().Set(model.GnfdRequestIDHeader, reqContext.requestID)
+}
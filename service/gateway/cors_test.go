@@ -0,0 +1,89 @@
+package gateway
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/greenfield-storage-provider/model"
+	authclient "github.com/bnb-chain/greenfield-storage-provider/service/auth/client"
+	authtypes "github.com/bnb-chain/greenfield-storage-provider/service/auth/types"
+)
+
+func TestMatchCORSRule(t *testing.T) {
+	rules := []*authtypes.CORSRule{
+		{
+			AllowedOrigins: []string{"https://*.example.com"},
+			AllowedMethods: []string{http.MethodGet, http.MethodHead},
+			AllowedHeaders: []string{"range", "x-gnfd-*"},
+		},
+		{
+			AllowedOrigins: []string{"*"},
+			AllowedMethods: []string{http.MethodGet},
+		},
+	}
+	cases := []struct {
+		name      string
+		origin    string
+		method    string
+		headers   []string
+		wantIndex int
+	}{
+		{"wildcard origin subdomain", "https://app.example.com", http.MethodHead, nil, 0},
+		{"allowed headers case insensitive", "https://app.example.com", http.MethodGet, []string{"Range", "X-Gnfd-Request-ID"}, 0},
+		{"header not allowed falls to next rule", "https://app.example.com", http.MethodGet, []string{"Authorization"}, -1},
+		{"any origin without headers", "https://other.com", http.MethodGet, nil, 1},
+		{"method not allowed", "https://other.com", http.MethodPut, nil, -1},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			rule := matchCORSRule(rules, c.origin, c.method, c.headers)
+			if c.wantIndex < 0 {
+				assert.Nil(t, rule)
+				return
+			}
+			assert.Equal(t, rules[c.wantIndex], rule)
+		})
+	}
+}
+
+func TestCheckCORSRules(t *testing.T) {
+	assert.True(t, checkCORSRules(nil))
+	assert.True(t, checkCORSRules([]*authtypes.CORSRule{
+		{AllowedOrigins: []string{"*"}, AllowedMethods: []string{http.MethodGet}, MaxAgeSeconds: 3600},
+	}))
+	assert.False(t, checkCORSRules([]*authtypes.CORSRule{
+		{AllowedOrigins: []string{"*"}, AllowedMethods: []string{http.MethodPatch}},
+	}))
+	assert.False(t, checkCORSRules([]*authtypes.CORSRule{
+		{AllowedMethods: []string{http.MethodGet}},
+	}))
+	assert.False(t, checkCORSRules([]*authtypes.CORSRule{
+		{AllowedOrigins: []string{"https://*.*.com"}, AllowedMethods: []string{http.MethodGet}},
+	}))
+}
+
+func TestBucketCORSHandler_DenyAuthV2(t *testing.T) {
+	gateway := &Gateway{config: config, auth: &authclient.AuthClient{}}
+	router := mux.NewRouter().SkipClean(true)
+	router.Path(model.BucketCORSPath+"/{bucket}").
+		Name(bucketCORSRouterName).
+		Methods(http.MethodGet, http.MethodPut, http.MethodDelete).
+		HandlerFunc(gateway.bucketCORSHandler)
+
+	for _, method := range []string{http.MethodGet, http.MethodPut, http.MethodDelete} {
+		t.Run(method, func(t *testing.T) {
+			r := httptest.NewRequest(method, scheme+testDomain+model.BucketCORSPath+"/"+bucketName,
+				strings.NewReader(`{"rules":[{"allowed_origins":["*"],"allowed_methods":["GET"]}]}`))
+			r.Header.Set(model.GnfdAuthorizationHeader,
+				signaturePrefix(model.SignTypeV2, model.SignAlgorithm)+" "+model.Signature+"=00")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, r)
+			assert.Equal(t, http.StatusForbidden, w.Code)
+		})
+	}
+}
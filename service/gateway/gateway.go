@@ -8,6 +8,7 @@ import (
 	"sync/atomic"
 
 	"github.com/gorilla/mux"
+	lru "github.com/hashicorp/golang-lru"
 
 	"github.com/bnb-chain/greenfield-storage-provider/model"
 	chainclient "github.com/bnb-chain/greenfield-storage-provider/pkg/greenfield"
@@ -43,6 +44,9 @@ type Gateway struct {
 	signer     *signerclient.SignerClient
 	metadata   *metadataclient.MetadataClient
 	auth       *authclient.AuthClient
+
+	// corsCache caches the cors rules of buckets, which are evaluated on every cross-origin bucket request
+	corsCache *lru.Cache
 }
 
 // NewGatewayService return the gateway instance
@@ -55,6 +59,10 @@ func NewGatewayService(cfg *GatewayConfig) (*Gateway, error) {
 	gateway = &Gateway{
 		config: cfg,
 	}
+	if gateway.corsCache, err = lru.New(model.LruCacheLimit); err != nil {
+		log.Errorw("failed to create cors cache", "error", err)
+		return nil, err
+	}
 	if gateway.chain, err = chainclient.NewGreenfield(cfg.ChainConfig); err != nil {
 		log.Errorw("failed to create chain client", "error", err)
 		return nil, err
@@ -1,6 +1,7 @@
 package gateway
 
 import (
+	"sync"
 	"testing"
 
 	"github.com/gorilla/mux"
@@ -17,10 +18,16 @@ var (
 	scheme     = "https://"
 	bucketName = "test-bucket-name"
 	objectName = "test-object-name"
+
+	// registerHandler registers the root handler to http.DefaultServeMux, so the router is only set up once
+	gwRouter     *mux.Router
+	gwRouterOnce sync.Once
 )
 
 func setupRouter(t *testing.T) *mux.Router {
-	gwRouter := mux.NewRouter().SkipClean(true)
-	gw.registerHandler(gwRouter)
+	gwRouterOnce.Do(func() {
+		gwRouter = mux.NewRouter().SkipClean(true)
+		gw.registerHandler(gwRouter)
+	})
 	return gwRouter
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 
 	"github.com/bnb-chain/greenfield-storage-provider/model"
+	merrors "github.com/bnb-chain/greenfield-storage-provider/model/errors"
 	"github.com/bnb-chain/greenfield-storage-provider/pkg/log"
 	metatypes "github.com/bnb-chain/greenfield-storage-provider/service/metadata/types"
 	"github.com/bnb-chain/greenfield-storage-provider/util"
@@ -76,21 +77,11 @@ func (gateway *Gateway) getUserBucketsHandler(w http.ResponseWriter, r *http.Req
 // listObjectsByBucketNameHandler handle list objects by bucket name request
 func (gateway *Gateway) listObjectsByBucketNameHandler(w http.ResponseWriter, r *http.Request) {
 	var (
-		err                      error
-		b                        bytes.Buffer
-		maxKeys                  uint64
-		errDescription           *errorDescription
-		reqContext               *requestContext
-		ok                       bool
-		requestBucketName        string
-		requestMaxKeys           string
-		requestStartAfter        string
-		requestContinuationToken string
-		requestDelimiter         string
-		requestPrefix            string
-		continuationToken        string
-		decodedContinuationToken []byte
-		queryParams              url.Values
+		err            error
+		b              bytes.Buffer
+		errDescription *errorDescription
+		reqContext     *requestContext
+		resp           *metatypes.ListObjectsByBucketNameResponse
 	)
 
 	reqContext = newRequestContext(r)
@@ -111,6 +102,38 @@ func (gateway *Gateway) listObjectsByBucketNameHandler(w http.ResponseWriter, r
 		return
 	}
 
+	if resp, errDescription = gateway.listObjectsByBucketName(reqContext); errDescription != nil {
+		return
+	}
+
+	m := jsonpb.Marshaler{EmitDefaults: true, OrigName: true, EnumsAsInts: true}
+	if err = m.Marshal(&b, resp); err != nil {
+		log.Errorf("failed to list objects by bucket name", "error", err)
+		errDescription = makeErrorDescription(err)
+		return
+	}
+
+	w.Header().Set(model.ContentTypeHeader, model.ContentTypeJSONHeaderValue)
+	w.Write(b.Bytes())
+}
+
+// listObjectsByBucketName parses the list objects queries of request and lists objects by bucket name from metadata
+func (gateway *Gateway) listObjectsByBucketName(reqContext *requestContext) (*metatypes.ListObjectsByBucketNameResponse, *errorDescription) {
+	var (
+		err                      error
+		maxKeys                  uint64
+		ok                       bool
+		requestBucketName        string
+		requestMaxKeys           string
+		requestStartAfter        string
+		requestContinuationToken string
+		requestDelimiter         string
+		requestPrefix            string
+		continuationToken        string
+		decodedContinuationToken []byte
+		queryParams              url.Values
+	)
+
 	queryParams = reqContext.request.URL.Query()
 	requestBucketName = reqContext.bucketName
 	requestMaxKeys = queryParams.Get(model.ListObjectsMaxKeysQuery)
@@ -121,23 +144,20 @@ func (gateway *Gateway) listObjectsByBucketNameHandler(w http.ResponseWriter, r
 
 	if err = s3util.CheckValidBucketName(requestBucketName); err != nil {
 		log.Errorw("failed to check bucket name", "bucket_name", requestBucketName, "error", err)
-		errDescription = InvalidBucketName
-		return
+		return nil, InvalidBucketName
 	}
 
 	if requestMaxKeys != "" {
 		if maxKeys, err = util.StringToUint64(requestMaxKeys); err != nil || maxKeys == 0 {
 			log.Errorw("failed to parse or check maxKeys", "max_keys", requestMaxKeys, "error", err)
-			errDescription = InvalidMaxKeys
-			return
+			return nil, InvalidMaxKeys
 		}
 	}
 
 	if requestStartAfter != "" {
 		if err = s3util.CheckValidObjectName(requestStartAfter); err != nil {
 			log.Errorw("failed to check startAfter", "start_after", requestStartAfter, "error", err)
-			errDescription = InvalidStartAfter
-			return
+			return nil, InvalidStartAfter
 		}
 	}
 
@@ -145,28 +165,24 @@ func (gateway *Gateway) listObjectsByBucketNameHandler(w http.ResponseWriter, r
 		decodedContinuationToken, err = base64.StdEncoding.DecodeString(requestContinuationToken)
 		if err != nil {
 			log.Errorw("failed to check requestContinuationToken", "continuation_token", requestContinuationToken, "error", err)
-			errDescription = InvalidContinuationToken
-			return
+			return nil, InvalidContinuationToken
 		}
 		continuationToken = string(decodedContinuationToken)
 
 		if err = s3util.CheckValidObjectName(continuationToken); err != nil {
 			log.Errorw("failed to check requestContinuationToken", "continuation_token", continuationToken, "error", err)
-			errDescription = InvalidContinuationToken
-			return
+			return nil, InvalidContinuationToken
 		}
 
 		if !strings.HasPrefix(continuationToken, requestPrefix) {
 			log.Errorw("failed to check requestContinuationToken", "continuation_token", continuationToken, "prefix", requestPrefix, "error", err)
-			errDescription = InvalidContinuationToken
-			return
+			return nil, InvalidContinuationToken
 		}
 	}
 
 	if ok = checkValidObjectPrefix(requestPrefix); !ok {
 		log.Errorw("failed to check requestPrefix", "prefix", requestPrefix, "error", err)
-		errDescription = InvalidPrefix
-		return
+		return nil, InvalidPrefix
 	}
 
 	if requestContinuationToken == "" {
@@ -186,19 +202,9 @@ func (gateway *Gateway) listObjectsByBucketNameHandler(w http.ResponseWriter, r
 	resp, err := gateway.metadata.ListObjectsByBucketName(ctx, req)
 	if err != nil {
 		log.Errorf("failed to list objects by bucket name", "error", err)
-		errDescription = makeErrorDescription(err)
-		return
-	}
-
-	m := jsonpb.Marshaler{EmitDefaults: true, OrigName: true, EnumsAsInts: true}
-	if err = m.Marshal(&b, resp); err != nil {
-		log.Errorf("failed to list objects by bucket name", "error", err)
-		errDescription = makeErrorDescription(err)
-		return
+		return nil, makeErrorDescription(err)
 	}
-
-	w.Header().Set(model.ContentTypeHeader, model.ContentTypeJSONHeaderValue)
-	w.Write(b.Bytes())
+	return resp, nil
 }
 
 // getObjectMetaHandler handle get object metadata request
@@ -265,6 +271,77 @@ func (gateway *Gateway) getObjectMetaHandler(w http.ResponseWriter, r *http.Requ
 	w.Write(b.Bytes())
 }
 
+// headObjectHandler handle head object request, which returns the object metadata as headers
+func (gateway *Gateway) headObjectHandler(w http.ResponseWriter, r *http.Request) {
+	var (
+		err            error
+		errDescription *errorDescription
+		reqContext     *requestContext
+	)
+
+	reqContext = newRequestContext(r)
+	defer func() {
+		if errDescription != nil {
+			_ = errDescription.errorResponse(w, reqContext)
+		}
+		if errDescription != nil && errDescription.statusCode != http.StatusOK {
+			log.Errorf("action(%v) statusCode(%v) %v", headObjectRouterName, errDescription.statusCode, reqContext.generateRequestDetail())
+		} else {
+			log.Infof("action(%v) statusCode(200) %v", headObjectRouterName, reqContext.generateRequestDetail())
+		}
+	}()
+
+	if gateway.metadata == nil {
+		log.Error("failed to head object due to not config metadata")
+		errDescription = NotExistComponentError
+		return
+	}
+
+	if err = s3util.CheckValidBucketName(reqContext.bucketName); err != nil {
+		log.Errorw("failed to check bucket name", "bucket_name", reqContext.bucketName, "error", err)
+		errDescription = InvalidBucketName
+		return
+	}
+
+	if err = s3util.CheckValidObjectName(reqContext.objectName); err != nil {
+		log.Errorw("failed to check object name", "object_name", reqContext.objectName, "error", err)
+		errDescription = InvalidKey
+		return
+	}
+
+	req := &metatypes.GetObjectMetaRequest{
+		BucketName: reqContext.bucketName,
+		ObjectName: reqContext.objectName,
+		IsFullList: true,
+	}
+
+	ctx := log.Context(context.Background(), req)
+	resp, err := gateway.metadata.GetObjectMeta(ctx, req)
+	if err != nil {
+		log.Errorf("failed to get object meta", "error", err)
+		errDescription = makeErrorDescription(merrors.GRPCErrorToInnerError(err))
+		return
+	}
+	if resp.GetObject().GetObjectInfo() == nil || resp.GetObject().GetRemoved() {
+		log.Errorw("failed to head object due to object not found")
+		errDescription = NoSuchKey
+		return
+	}
+
+	switch checkPreconditions(reqContext.request, resp.GetObject().GetObjectInfo()) {
+	case http.StatusNotModified:
+		notModifiedResponse(w, reqContext, resp.GetObject().GetObjectInfo())
+		return
+	case http.StatusPreconditionFailed:
+		errDescription = PreconditionFailed
+		return
+	}
+
+	w.Header().Set(model.GnfdRequestIDHeader, reqContext.requestID)
+	makeObjectMetaHeaders(w, resp.GetObject().GetObjectInfo())
+	w.WriteHeader(http.StatusOK)
+}
+
 // getBucketMetaHandler handle get bucket metadata request
 func (gateway *Gateway) getBucketMetaHandler(w http.ResponseWriter, r *http.Request) {
 	var (
@@ -3,8 +3,11 @@ package gateway
 import (
 	"context"
 	"crypto/md5"
+	"fmt"
 	"io"
+	"mime/multipart"
 	"net/http"
+	"net/textproto"
 	"net/url"
 
 	"github.com/bnb-chain/greenfield/types/s3util"
@@ -31,6 +34,7 @@ func (gateway *Gateway) getObjectHandler(w http.ResponseWriter, r *http.Request)
 		isRange        bool
 		rangeStart     int64
 		rangeEnd       int64
+		ranges         [][2]int64
 		readN, writeN  int
 		size           int
 		statusCode     = http.StatusOK
@@ -44,7 +48,7 @@ func (gateway *Gateway) getObjectHandler(w http.ResponseWriter, r *http.Request)
 			statusCode = errDescription.statusCode
 			_ = errDescription.errorResponse(w, reqContext)
 		}
-		if statusCode == http.StatusOK || statusCode == http.StatusPartialContent {
+		if statusCode == http.StatusOK || statusCode == http.StatusPartialContent || statusCode == http.StatusNotModified {
 			log.Infof("action(%v) statusCode(%v) %v", getObjectRouterName, statusCode, reqContext.generateRequestDetail())
 		} else {
 			log.Errorf("action(%v) statusCode(%v) %v", getObjectRouterName, statusCode, reqContext.generateRequestDetail())
@@ -79,14 +83,33 @@ func (gateway *Gateway) getObjectHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	isRange, rangeStart, rangeEnd = parseRange(reqContext.request.Header.Get(model.RangeHeader))
-	if isRange && (rangeEnd < 0 || rangeEnd >= int64(reqContext.objectInfo.GetPayloadSize())) {
-		rangeEnd = int64(reqContext.objectInfo.GetPayloadSize()) - 1
+	// evaluate the conditional headers before downloading, so the not modified object consumes no read quota
+	switch checkPreconditions(reqContext.request, reqContext.objectInfo) {
+	case http.StatusNotModified:
+		statusCode = http.StatusNotModified
+		notModifiedResponse(w, reqContext, reqContext.objectInfo)
+		return
+	case http.StatusPreconditionFailed:
+		errDescription = PreconditionFailed
+		return
 	}
-	if isRange && (rangeStart < 0 || rangeEnd < 0 || rangeStart > rangeEnd) {
-		errDescription = InvalidRange
+
+	if checkIfRange(reqContext.request, reqContext.objectInfo) {
+		isRange, ranges = parseMultiRange(reqContext.request.Header.Get(model.RangeHeader))
+	}
+	if isRange {
+		if isRange, ranges = normalizeRanges(ranges, int64(reqContext.objectInfo.GetPayloadSize())); !isRange {
+			errDescription = InvalidRange
+			return
+		}
+	}
+	if isRange && len(ranges) > 1 {
+		statusCode, errDescription = gateway.getObjectByRanges(ctx, w, reqContext, addr, ranges)
 		return
 	}
+	if isRange {
+		rangeStart, rangeEnd = ranges[0][0], ranges[0][1]
+	}
 
 	req := &types.GetObjectRequest{
 		BucketInfo:  reqContext.bucketInfo,
@@ -104,6 +127,7 @@ func (gateway *Gateway) getObjectHandler(w http.ResponseWriter, r *http.Request)
 	}
 	w.Header().Set(model.GnfdRequestIDHeader, reqContext.requestID)
 	w.Header().Set(model.ContentTypeHeader, reqContext.objectInfo.GetContentType())
+	makeObjectHeaders(w, reqContext.objectInfo)
 	if !isRange {
 		w.Header().Set(model.ContentLengthHeader, util.Uint64ToString(reqContext.objectInfo.GetPayloadSize()))
 	}
@@ -147,6 +171,84 @@ func (gateway *Gateway) getObjectHandler(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+// getObjectByRanges serves the multi-range get object request with the multipart/byteranges response, the
+// ranges must be normalized by normalizeRanges. Returns the status code or the error description if it fails
+// before any part is written, the connection is aborted if it fails after that so that the client never takes
+// the truncated multipart body as a complete one.
+func (gateway *Gateway) getObjectByRanges(ctx context.Context, w http.ResponseWriter, reqContext *requestContext,
+	addr sdk.AccAddress, ranges [][2]int64) (int, *errorDescription) {
+	var (
+		payloadSize  = int64(reqContext.objectInfo.GetPayloadSize())
+		objectRanges = make([]*types.ObjectRange, 0, len(ranges))
+		part         io.Writer
+		partIndex    = -1
+	)
+	for _, r := range ranges {
+		objectRanges = append(objectRanges, &types.ObjectRange{Start: uint64(r[0]), End: uint64(r[1])})
+	}
+
+	req := &types.GetObjectRequest{
+		BucketInfo:  reqContext.bucketInfo,
+		ObjectInfo:  reqContext.objectInfo,
+		UserAddress: addr.String(),
+		Ranges:      objectRanges,
+	}
+	stream, err := gateway.downloader.GetObject(ctx, req)
+	if err != nil {
+		log.Errorf("failed to get object", "error", err)
+		return 0, makeErrorDescription(err)
+	}
+	mw := multipart.NewWriter(w)
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			log.Errorw("failed to read stream", "error", err)
+			if partIndex < 0 {
+				return 0, makeErrorDescription(merrors.GRPCErrorToInnerError(err))
+			}
+			panic(http.ErrAbortHandler)
+		}
+		if localHttp.BandwidthLimit != nil {
+			if err = localHttp.BandwidthLimit.Limiter.Wait(ctx); err != nil {
+				log.Errorw("failed to wait bandwidth limiter", "error", err)
+			}
+		}
+		if partIndex < 0 {
+			w.Header().Set(model.GnfdRequestIDHeader, reqContext.requestID)
+			w.Header().Set(model.ContentTypeHeader, model.ContentTypeMultipartByteRangesHeaderValue+"; boundary="+mw.Boundary())
+			makeObjectHeaders(w, reqContext.objectInfo)
+			w.WriteHeader(http.StatusPartialContent)
+		}
+		if idx := int(resp.GetRangeIndex()); idx != partIndex {
+			if idx >= len(objectRanges) {
+				log.Errorw("failed to get object due to invalid range index", "range_index", idx)
+				panic(http.ErrAbortHandler)
+			}
+			partIndex = idx
+			header := textproto.MIMEHeader{}
+			header.Set(model.ContentTypeHeader, reqContext.objectInfo.GetContentType())
+			header.Set(model.ContentRangeHeader, fmt.Sprintf("bytes %d-%d/%d",
+				objectRanges[idx].GetStart(), objectRanges[idx].GetEnd(), payloadSize))
+			if part, err = mw.CreatePart(header); err != nil {
+				log.Errorw("failed to write multipart", "error", err)
+				panic(http.ErrAbortHandler)
+			}
+		}
+		if _, err = part.Write(resp.GetData()); err != nil {
+			log.Errorw("failed to write multipart", "error", err)
+			panic(http.ErrAbortHandler)
+		}
+	}
+	if err = mw.Close(); err != nil {
+		log.Errorw("failed to close multipart", "error", err)
+		panic(http.ErrAbortHandler)
+	}
+	return http.StatusPartialContent, nil
+}
+
 // putObjectHandler handles the put object request
 func (gateway *Gateway) putObjectHandler(w http.ResponseWriter, r *http.Request) {
 	var (
@@ -156,6 +258,7 @@ func (gateway *Gateway) putObjectHandler(w http.ResponseWriter, r *http.Request)
 		addr           sdk.AccAddress
 		size           int
 		readN          int
+		offset         uint64
 		buf            = make([]byte, model.DefaultStreamBufSize)
 		hashBuf        = make([]byte, model.DefaultStreamBufSize)
 		md5Hash        = md5.New()
@@ -210,6 +313,15 @@ func (gateway *Gateway) putObjectHandler(w http.ResponseWriter, r *http.Request)
 		errDescription = makeErrorDescription(err)
 		return
 	}
+	// the offset is used to resume uploading from the missing segments, which must be aligned to the
+	// segment size and is checked by uploader
+	if offsetStr := reqContext.request.URL.Query().Get(model.PutObjectOffsetQuery); offsetStr != "" {
+		if offset, err = util.StringToUint64(offsetStr); err != nil {
+			log.Errorw("failed to parse upload offset", "offset", offsetStr, "error", err)
+			errDescription = InvalidUploadOffset
+			return
+		}
+	}
 
 	stream, err := gateway.uploader.PutObject(ctx)
 	if err != nil {
@@ -218,14 +330,15 @@ func (gateway *Gateway) putObjectHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 	w.Header().Set(model.GnfdRequestIDHeader, reqContext.requestID)
-	// Greenfield has an integrity hash, so there is no need for an etag
-	// w.Header().Set(model.ETagHeader, hex.EncodeToString(md5Hash.Sum(nil)))
+	// Greenfield has an integrity hash, so the etag is derived from it instead of the md5 of payload
+	w.Header().Set(model.ETagHeader, makeETag(reqContext.objectInfo))
 
 	for {
 		readN, err = r.Body.Read(buf)
 		if err != nil && err != io.EOF {
 			log.Errorw("failed to put object due to reader error", "error", err)
-			errDescription = InternalError
+			// the payload mismatching the signed hash is detected at the end of body
+			errDescription = makeErrorDescription(err)
 			return
 		}
 		if readN > 0 {
@@ -238,6 +351,7 @@ func (gateway *Gateway) putObjectHandler(w http.ResponseWriter, r *http.Request)
 			req := &uploadertypes.PutObjectRequest{
 				ObjectInfo: reqContext.objectInfo,
 				Payload:    buf[:readN],
+				Offset:     offset,
 			}
 			if err := stream.Send(req); err != nil {
 				log.Errorw("failed to put object due to stream send error", "error", err)
@@ -254,10 +368,17 @@ func (gateway *Gateway) putObjectHandler(w http.ResponseWriter, r *http.Request)
 				errDescription = InvalidPayload
 				return
 			}
-			_, err = stream.CloseAndRecv()
+			resp, err := stream.CloseAndRecv()
 			if err != nil {
 				log.Errorw("failed to put object due to stream close", "error", err)
-				errDescription = makeErrorDescription(err)
+				errDescription = makeErrorDescription(merrors.GRPCErrorToInnerError(err))
+				return
+			}
+			if missing := resp.GetMissingSegmentIndexes(); len(missing) != 0 {
+				// the payload is stored but the object is incomplete, the client resumes from the missing segments
+				log.Warnw("put partial object", "missing_segment_number", len(missing))
+				w.Header().Set(model.GnfdMissingSegmentsHeader, makeSegmentIndexesHeader(missing))
+				w.WriteHeader(http.StatusAccepted)
 				return
 			}
 			// succeed to put object
@@ -0,0 +1,192 @@
+package gateway
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bnb-chain/greenfield/types/s3util"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/bnb-chain/greenfield-storage-provider/model"
+	merrors "github.com/bnb-chain/greenfield-storage-provider/model/errors"
+	"github.com/bnb-chain/greenfield-storage-provider/pkg/log"
+	p2ptypes "github.com/bnb-chain/greenfield-storage-provider/pkg/p2p/types"
+	servicetypes "github.com/bnb-chain/greenfield-storage-provider/service/types"
+)
+
+// MaxPresignedURLExpiry defines the max duration from now to the expiry of pre-signed url
+const MaxPresignedURLExpiry = 7 * 24 * time.Hour
+
+// newPresignedURL returns the pre-signed url message of getting the object of request context issued to the user,
+// the domain of this sp and the chain id are bound to the url.
+func (g *Gateway) newPresignedURL(reqContext *requestContext, userAddress string, expires int64) *servicetypes.PresignedURL {
+	return &servicetypes.PresignedURL{
+		Method:      http.MethodGet,
+		Domain:      g.config.Domain,
+		ChainId:     g.config.ChainConfig.ChainID,
+		BucketName:  reqContext.bucketName,
+		ObjectName:  reqContext.objectName,
+		UserAddress: userAddress,
+		Expires:     expires,
+	}
+}
+
+// checkPresignedURLExpiry checks the expiry of pre-signed url is in the future and not longer than MaxPresignedURLExpiry
+func checkPresignedURLExpiry(expires int64) error {
+	if time.Now().Unix() > expires {
+		return merrors.ErrPresignedURLExpired
+	}
+	if time.Until(time.Unix(expires, 0)) > MaxPresignedURLExpiry {
+		return merrors.ErrPresignedURLExpiryTooLong
+	}
+	return nil
+}
+
+// isPresignedRequest returns whether the request is authorized by the pre-signed url
+func isPresignedRequest(reqContext *requestContext) bool {
+	return reqContext.request.URL.Query().Get(model.PresignedSignatureQuery) != ""
+}
+
+// verifyPresignedSignature used to verify the pre-signed url is signed by the operator of this sp, return (user
+// address, nil) if check succeed, the permission of the user is checked as the get object request later.
+func (g *Gateway) verifyPresignedSignature(reqContext *requestContext) (sdk.AccAddress, error) {
+	query := reqContext.request.URL.Query()
+	userAddress, err := sdk.AccAddressFromHexUnsafe(query.Get(model.PresignedSignerQuery))
+	if err != nil {
+		log.Errorw("failed to parse pre-signed url signer", "error", err)
+		return nil, merrors.ErrAuthorizationFormat
+	}
+	expires, err := strconv.ParseInt(query.Get(model.PresignedExpiresQuery), 10, 64)
+	if err != nil {
+		log.Errorw("failed to parse pre-signed url expires", "error", err)
+		return nil, merrors.ErrAuthorizationFormat
+	}
+	if err = checkPresignedURLExpiry(expires); err != nil {
+		return nil, err
+	}
+	signature, err := hexutil.Decode(query.Get(model.PresignedSignatureQuery))
+	if err != nil {
+		log.Errorw("failed to decode pre-signed url signature", "error", err)
+		return nil, merrors.ErrAuthorizationFormat
+	}
+
+	presignedURL := g.newPresignedURL(reqContext, userAddress.String(), expires)
+	presignedURL.Method = reqContext.request.Method
+	if err = p2ptypes.VerifySignature(g.config.SpOperatorAddress, presignedURL.GetSignBytes(), signature); err != nil {
+		log.Errorw("failed to verify pre-signed url signature", "user_address", userAddress.String(), "error", err)
+		return nil, merrors.ErrSignatureConsistent
+	}
+	return userAddress, nil
+}
+
+// presignedURLHandler handles the request of issuing the pre-signed url of getting an object, the url is issued
+// to the request user who has the permission of getting the object, and signed by the operator of this sp, so
+// that the user can share the object by the url without exposing the private key.
+func (g *Gateway) presignedURLHandler(w http.ResponseWriter, r *http.Request) {
+	var (
+		err            error
+		errDescription *errorDescription
+		reqContext     *requestContext
+		addr           sdk.AccAddress
+		expires        int64
+		presignedURL   *servicetypes.PresignedURL
+	)
+
+	reqContext = newRequestContext(r)
+	defer func() {
+		if errDescription != nil {
+			_ = errDescription.errorResponse(w, reqContext)
+		}
+		if errDescription != nil && errDescription.statusCode != http.StatusOK {
+			log.Errorf("action(%v) statusCode(%v) %v", presignedURLRouterName, errDescription.statusCode, reqContext.generateRequestDetail())
+		} else {
+			log.Infof("action(%v) statusCode(200) %v", presignedURLRouterName, reqContext.generateRequestDetail())
+		}
+	}()
+
+	if g.signer == nil {
+		log.Error("failed to issue pre-signed url due to not config signer")
+		errDescription = NotExistComponentError
+		return
+	}
+	if err = s3util.CheckValidBucketName(reqContext.bucketName); err != nil {
+		log.Errorw("failed to check bucket name", "bucket_name", reqContext.bucketName, "error", err)
+		errDescription = InvalidBucketName
+		return
+	}
+	if err = s3util.CheckValidObjectName(reqContext.objectName); err != nil {
+		log.Errorw("failed to check object name", "object_name", reqContext.objectName, "error", err)
+		errDescription = InvalidKey
+		return
+	}
+	if expires, err = strconv.ParseInt(reqContext.request.URL.Query().Get(model.PresignedExpiresQuery), 10, 64); err != nil {
+		log.Errorw("failed to parse pre-signed url expires", "error", err)
+		errDescription = InvalidPresignedExpiry
+		return
+	}
+	if err = checkPresignedURLExpiry(expires); err != nil {
+		errDescription = InvalidPresignedExpiry
+		return
+	}
+
+	if addr, err = g.verifySignature(reqContext); err != nil {
+		log.Errorw("failed to verify signature", "error", err)
+		errDescription = makeErrorDescription(err)
+		return
+	}
+	if err = g.checkAuthorization(reqContext, addr); err != nil {
+		log.Errorw("failed to check authorization", "error", err)
+		errDescription = makeErrorDescription(err)
+		return
+	}
+
+	if presignedURL, err = g.signer.SignPresignedURL(context.Background(),
+		g.newPresignedURL(reqContext, addr.String(), expires)); err != nil {
+		log.Errorw("failed to sign pre-signed url", "error", err)
+		errDescription = makeErrorDescription(err)
+		return
+	}
+
+	var xmlInfo = struct {
+		XMLName xml.Name `xml:"PresignedURLResult"`
+		Version string   `xml:"version,attr"`
+		URL     string   `xml:"URL"`
+		Expires int64    `xml:"Expires"`
+	}{
+		Version: model.GnfdResponseXMLVersion,
+		URL:     makePresignedURL(reqContext.request, presignedURL),
+		Expires: presignedURL.GetExpires(),
+	}
+	xmlBody, err := xml.Marshal(&xmlInfo)
+	if err != nil {
+		log.Errorw("failed to marshal xml", "error", err)
+		errDescription = makeErrorDescription(err)
+		return
+	}
+	w.Header().Set(model.ContentTypeHeader, model.ContentTypeXMLHeaderValue)
+	w.Header().Set(model.GnfdRequestIDHeader, reqContext.requestID)
+	if _, err = w.Write(xmlBody); err != nil {
+		log.Errorw("failed to write body", "error", err)
+	}
+}
+
+// makePresignedURL returns the path style get object url of the signed pre-signed url on the domain of this sp,
+// the scheme follows the request of issuing the url.
+func makePresignedURL(r *http.Request, presignedURL *servicetypes.PresignedURL) string {
+	scheme := "http"
+	if r.TLS != nil || strings.EqualFold(r.Header.Get("X-Forwarded-Proto"), "https") {
+		scheme = "https"
+	}
+	query := url.Values{}
+	query.Set(model.PresignedSignerQuery, presignedURL.GetUserAddress())
+	query.Set(model.PresignedExpiresQuery, strconv.FormatInt(presignedURL.GetExpires(), 10))
+	query.Set(model.PresignedSignatureQuery, hexutil.Encode(presignedURL.GetSignature()))
+	return scheme + "://" + presignedURL.GetDomain() + "/" + presignedURL.GetBucketName() + "/" +
+		s3URIEncode(presignedURL.GetObjectName(), false) + "?" + query.Encode()
+}
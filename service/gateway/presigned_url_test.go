@@ -0,0 +1,96 @@
+package gateway
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	merrors "github.com/bnb-chain/greenfield-storage-provider/model/errors"
+	gnfd "github.com/bnb-chain/greenfield-storage-provider/pkg/greenfield"
+	servicetypes "github.com/bnb-chain/greenfield-storage-provider/service/types"
+)
+
+func TestPresignedURL(t *testing.T) {
+	operatorKey, _ := crypto.GenerateKey()
+	userKey, _ := crypto.GenerateKey()
+	user := sdk.AccAddress(crypto.PubkeyToAddress(userKey.PublicKey).Bytes())
+	g := &Gateway{config: &GatewayConfig{
+		SpOperatorAddress: sdk.AccAddress(crypto.PubkeyToAddress(operatorKey.PublicKey).Bytes()).String(),
+		Domain:            "gnfd.example.com",
+		ChainConfig:       &gnfd.GreenfieldChainConfig{ChainID: "greenfield_9000-121"},
+	}}
+	newRequestContextFromURL := func(method, rawURL, bucketName, objectName string) *requestContext {
+		r, err := http.NewRequest(method, rawURL, nil)
+		require.NoError(t, err)
+		return &requestContext{
+			request:    r,
+			routerName: getObjectRouterName,
+			bucketName: bucketName,
+			objectName: objectName,
+		}
+	}
+	// sign the pre-signed url as the signer service does
+	presign := func(g *Gateway, bucketName, objectName string, expires time.Time) string {
+		issueReqContext := newRequestContextFromURL(http.MethodGet, "http://localhost:9033", bucketName, objectName)
+		issueReqContext.request.Header.Set("X-Forwarded-Proto", "https")
+		presignedURL := g.newPresignedURL(issueReqContext, user.String(), expires.Unix())
+		var err error
+		presignedURL.Signature, err = crypto.Sign(crypto.Keccak256(presignedURL.GetSignBytes()), operatorKey)
+		require.NoError(t, err)
+		return makePresignedURL(issueReqContext.request, presignedURL)
+	}
+
+	rawURL := presign(g, "bucket", "dir/object name", time.Now().Add(time.Hour))
+	u, err := url.Parse(rawURL)
+	require.NoError(t, err)
+	assert.Equal(t, "https", u.Scheme)
+	assert.Equal(t, "gnfd.example.com", u.Host)
+
+	// succeed to verify
+	addr, err := g.verifyPresignedSignature(newRequestContextFromURL(http.MethodGet, rawURL, "bucket", "dir/object name"))
+	require.NoError(t, err)
+	assert.Equal(t, user, addr)
+
+	// the signature is bound to the object
+	_, err = g.verifyPresignedSignature(newRequestContextFromURL(http.MethodGet, rawURL, "bucket", "other"))
+	assert.Equal(t, merrors.ErrSignatureConsistent, err)
+
+	// the signature is bound to the method
+	_, err = g.verifyPresignedSignature(newRequestContextFromURL(http.MethodDelete, rawURL, "bucket", "dir/object name"))
+	assert.Equal(t, merrors.ErrSignatureConsistent, err)
+
+	// the signature is bound to the domain and the chain id of the issuing sp
+	otherDomain := &Gateway{config: &GatewayConfig{SpOperatorAddress: g.config.SpOperatorAddress,
+		Domain: "gnfd.other.com", ChainConfig: g.config.ChainConfig}}
+	_, err = otherDomain.verifyPresignedSignature(newRequestContextFromURL(http.MethodGet, rawURL, "bucket", "dir/object name"))
+	assert.Equal(t, merrors.ErrSignatureConsistent, err)
+	otherChain := &Gateway{config: &GatewayConfig{SpOperatorAddress: g.config.SpOperatorAddress,
+		Domain: g.config.Domain, ChainConfig: &gnfd.GreenfieldChainConfig{ChainID: "greenfield_5600-1"}}}
+	_, err = otherChain.verifyPresignedSignature(newRequestContextFromURL(http.MethodGet, rawURL, "bucket", "dir/object name"))
+	assert.Equal(t, merrors.ErrSignatureConsistent, err)
+
+	// the url must be signed by the operator of the sp
+	userSigned := &servicetypes.PresignedURL{}
+	*userSigned = *g.newPresignedURL(newRequestContextFromURL(http.MethodGet, rawURL, "bucket", "object"),
+		user.String(), time.Now().Add(time.Hour).Unix())
+	userSigned.Signature, err = crypto.Sign(crypto.Keccak256(userSigned.GetSignBytes()), userKey)
+	require.NoError(t, err)
+	rawURL = makePresignedURL(newRequestContextFromURL(http.MethodGet, rawURL, "bucket", "object").request, userSigned)
+	_, err = g.verifyPresignedSignature(newRequestContextFromURL(http.MethodGet, rawURL, "bucket", "object"))
+	assert.Equal(t, merrors.ErrSignatureConsistent, err)
+
+	// expired url
+	rawURL = presign(g, "bucket", "object", time.Now().Add(-time.Minute))
+	_, err = g.verifyPresignedSignature(newRequestContextFromURL(http.MethodGet, rawURL, "bucket", "object"))
+	assert.Equal(t, merrors.ErrPresignedURLExpired, err)
+
+	// too long expiry
+	assert.Equal(t, merrors.ErrPresignedURLExpiryTooLong,
+		checkPresignedURLExpiry(time.Now().Add(MaxPresignedURLExpiry+time.Hour).Unix()))
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/bnb-chain/greenfield-storage-provider/model/job"
 	"github.com/bnb-chain/greenfield-storage-provider/pkg/log"
 	servicetypes "github.com/bnb-chain/greenfield-storage-provider/service/types"
+	uploadertypes "github.com/bnb-chain/greenfield-storage-provider/service/uploader/types"
 	"github.com/bnb-chain/greenfield-storage-provider/util"
 	"github.com/bnb-chain/greenfield/types/s3util"
 	storagetypes "github.com/bnb-chain/greenfield/x/storage/types"
@@ -210,7 +211,7 @@ func (g *Gateway) queryUploadProgressHandler(w http.ResponseWriter, r *http.Requ
 		errDescription      *errorDescription
 		reqContext          *requestContext
 		addr                sdktypes.AccAddress
-		jobState            servicetypes.JobState
+		progress            *uploadertypes.QueryUploadProgressResponse
 		jobStateDescription string
 	)
 
@@ -256,7 +257,7 @@ func (g *Gateway) queryUploadProgressHandler(w http.ResponseWriter, r *http.Requ
 	if reqContext.objectInfo.GetObjectStatus() == storagetypes.OBJECT_STATUS_SEALED {
 		jobStateDescription = job.StateToDescription(servicetypes.JobState_JOB_STATE_SEAL_OBJECT_DONE)
 	} else {
-		jobState, err = g.uploader.QueryUploadProgress(context.Background(), reqContext.objectInfo.Id.Uint64())
+		progress, err = g.uploader.QueryUploadProgress(context.Background(), reqContext.objectInfo.Id.Uint64())
 		if err != nil {
 			err = merrors.GRPCErrorToInnerError(err)
 			if errors.Is(err, merrors.ErrNoSuchObject) {
@@ -267,16 +268,20 @@ func (g *Gateway) queryUploadProgressHandler(w http.ResponseWriter, r *http.Requ
 				return
 			}
 		} else {
-			jobStateDescription = job.StateToDescription(jobState)
+			jobStateDescription = job.StateToDescription(progress.GetState())
 		}
 	}
 	var xmlInfo = struct {
 		XMLName             xml.Name `xml:"QueryUploadProgress"`
 		Version             string   `xml:"version,attr"`
 		ProgressDescription string   `xml:"ProgressDescription"`
+		SegmentSize         uint64   `xml:"SegmentSize,omitempty"`
+		UploadedSegments    []uint32 `xml:"UploadedSegments>SegmentIndex"`
 	}{
 		Version:             model.GnfdResponseXMLVersion,
 		ProgressDescription: jobStateDescription,
+		SegmentSize:         progress.GetSegmentSize(),
+		UploadedSegments:    progress.GetUploadedSegmentIndexes(),
 	}
 	xmlBody, err := xml.Marshal(&xmlInfo)
 	if err != nil {
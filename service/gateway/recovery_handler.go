@@ -0,0 +1,130 @@
+package gateway
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/bnb-chain/greenfield/x/storage/types"
+
+	"github.com/bnb-chain/greenfield-storage-provider/model"
+	merrors "github.com/bnb-chain/greenfield-storage-provider/model/errors"
+	"github.com/bnb-chain/greenfield-storage-provider/pkg/log"
+	p2ptypes "github.com/bnb-chain/greenfield-storage-provider/pkg/p2p/types"
+	servicetypes "github.com/bnb-chain/greenfield-storage-provider/service/types"
+	"github.com/bnb-chain/greenfield-storage-provider/util"
+)
+
+// recoveryPieceHandler handles the get piece request from other sps, which is used to recover the lost pieces,
+// the requester must be the primary sp or one of the secondary sps of the object
+func (gateway *Gateway) recoveryPieceHandler(w http.ResponseWriter, r *http.Request) {
+	var (
+		err              error
+		errDescription   *errorDescription
+		reqContext       *requestContext
+		objectInfo       *types.ObjectInfo
+		redundancyIdx    int32
+		segmentIdx       uint32
+		recoveryApproval = &servicetypes.RecoveryPieceApproval{}
+	)
+
+	reqContext = newRequestContext(r)
+	defer func() {
+		if errDescription != nil {
+			_ = errDescription.errorResponse(w, reqContext)
+		}
+		if errDescription != nil && errDescription.statusCode != http.StatusOK {
+			log.Errorf("action(%v) statusCode(%v) %v", recoveryPieceRouterName, errDescription.statusCode, reqContext.generateRequestDetail())
+		} else {
+			log.Infof("action(%v) statusCode(200) %v", recoveryPieceRouterName, reqContext.generateRequestDetail())
+		}
+	}()
+
+	if gateway.challenge == nil {
+		log.Error("failed to recovery piece due to not config challenge")
+		errDescription = NotExistComponentError
+		return
+	}
+
+	objectID := reqContext.request.Header.Get(model.GnfdObjectIDHeader)
+	if redundancyIdx, err = util.StringToInt32(r.Header.Get(model.GnfdRedundancyIndexHeader)); err != nil {
+		log.Errorw("failed to parse redundancy_idx header", "redundancy_idx", r.Header.Get(model.GnfdRedundancyIndexHeader))
+		errDescription = InvalidHeader
+		return
+	}
+	if segmentIdx, err = util.StringToUint32(r.Header.Get(model.GnfdPieceIndexHeader)); err != nil {
+		log.Errorw("failed to parse segment_idx header", "segment_idx", r.Header.Get(model.GnfdPieceIndexHeader))
+		errDescription = InvalidHeader
+		return
+	}
+	if err = json.Unmarshal([]byte(r.Header.Get(model.GnfdRecoveryApprovalHeader)), recoveryApproval); err != nil {
+		log.Errorw("failed to parse recovery_approval header", "recovery_approval", r.Header.Get(model.GnfdRecoveryApprovalHeader))
+		errDescription = InvalidHeader
+		return
+	}
+	if err = gateway.verifyRecoveryApproval(recoveryApproval, objectID); err != nil {
+		log.Errorw("failed to verify recovery_approval header", "recovery_approval", recoveryApproval, "error", err)
+		errDescription = InvalidHeader
+		return
+	}
+
+	// check object info by querying chain
+	if objectInfo, err = gateway.chain.QueryObjectInfoByID(context.Background(), objectID); err != nil {
+		log.Errorw("failed to query object info on chain", "object_id", objectID, "error", err)
+		errDescription = makeErrorDescription(err)
+		return
+	}
+	if err = gateway.checkRecoveryPermission(objectInfo, recoveryApproval.GetSpOperatorAddress()); err != nil {
+		log.Errorw("failed to check recovery permission", "object_id", objectID,
+			"sp_operator_address", recoveryApproval.GetSpOperatorAddress(), "error", err)
+		errDescription = makeErrorDescription(err)
+		return
+	}
+
+	integrityHash, pieceHash, pieceData, err := gateway.challenge.ChallengePiece(context.Background(), objectInfo, redundancyIdx, segmentIdx)
+	if err != nil {
+		log.Errorw("failed to get recovery piece", "error", err)
+		errDescription = makeErrorDescription(merrors.GRPCErrorToInnerError(err))
+		return
+	}
+	w.Header().Set(model.GnfdRequestIDHeader, reqContext.requestID)
+	w.Header().Set(model.GnfdObjectIDHeader, objectID)
+	w.Header().Set(model.GnfdIntegrityHashHeader, hex.EncodeToString(integrityHash))
+	w.Header().Set(model.GnfdPieceHashHeader, util.BytesSliceToString(pieceHash))
+	w.Write(pieceData)
+}
+
+// verifyRecoveryApproval verify the recovery piece approval is signed by the requester and not expired
+func (gateway *Gateway) verifyRecoveryApproval(approval *servicetypes.RecoveryPieceApproval, objectID string) error {
+	if util.Uint64ToString(approval.GetObjectId()) != objectID {
+		return merrors.ErrInvalidParams
+	}
+	if err := p2ptypes.VerifySignature(approval.GetSpOperatorAddress(), approval.GetSignBytes(), approval.GetSignature()); err != nil {
+		log.Errorw("failed to verify approval signature", "error", err)
+		return merrors.ErrSignatureInvalid
+	}
+	if time.Now().Unix() > approval.GetExpiredTime() {
+		return merrors.ErrApprovalExpire
+	}
+	return nil
+}
+
+// checkRecoveryPermission checks the requester is the primary sp or one of the secondary sps of the object
+func (gateway *Gateway) checkRecoveryPermission(objectInfo *types.ObjectInfo, spOperatorAddress string) error {
+	for _, address := range objectInfo.GetSecondarySpAddresses() {
+		if strings.Compare(address, spOperatorAddress) == 0 {
+			return nil
+		}
+	}
+	bucketInfo, err := gateway.chain.QueryBucketInfo(context.Background(), objectInfo.GetBucketName())
+	if err != nil {
+		return err
+	}
+	if strings.Compare(bucketInfo.GetPrimarySpAddress(), spOperatorAddress) == 0 {
+		return nil
+	}
+	return merrors.ErrNoPermission
+}
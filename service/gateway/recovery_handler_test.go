@@ -0,0 +1,95 @@
+package gateway
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bnb-chain/greenfield/sdk/keys"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/greenfield-storage-provider/model"
+	merrors "github.com/bnb-chain/greenfield-storage-provider/model/errors"
+	challengeclient "github.com/bnb-chain/greenfield-storage-provider/service/challenge/client"
+	servicetypes "github.com/bnb-chain/greenfield-storage-provider/service/types"
+)
+
+func makeRecoveryApproval(t *testing.T, objectID uint64, expiredTime int64) *servicetypes.RecoveryPieceApproval {
+	// the private key comes from greenfield/sdk/keys/ unit test
+	km, err := keys.NewPrivateKeyManager("ab463aca3d2965233da3d1d6108aa521274c5ddc2369ff72970a52a451863fbf")
+	assert.Nil(t, err)
+	approval := &servicetypes.RecoveryPieceApproval{
+		ObjectId:          objectID,
+		SpOperatorAddress: km.GetAddr().String(),
+		ExpiredTime:       expiredTime,
+	}
+	approval.Signature, err = km.Sign(approval.GetSignBytes())
+	assert.Nil(t, err)
+	return approval
+}
+
+func TestVerifyRecoveryApproval(t *testing.T) {
+	gateway := &Gateway{}
+	approval := makeRecoveryApproval(t, 1, time.Now().Add(time.Minute).Unix())
+	assert.Nil(t, gateway.verifyRecoveryApproval(approval, "1"))
+	assert.Equal(t, merrors.ErrInvalidParams, gateway.verifyRecoveryApproval(approval, "2"))
+
+	tampered := makeRecoveryApproval(t, 1, time.Now().Add(time.Minute).Unix())
+	tampered.ExpiredTime++
+	assert.Equal(t, merrors.ErrSignatureInvalid, gateway.verifyRecoveryApproval(tampered, "1"))
+
+	expired := makeRecoveryApproval(t, 1, time.Now().Add(-time.Minute).Unix())
+	assert.Equal(t, merrors.ErrApprovalExpire, gateway.verifyRecoveryApproval(expired, "1"))
+}
+
+func TestRecoveryPieceHandler_InvalidRequest(t *testing.T) {
+	newRequest := func(headers map[string]string) *http.Request {
+		r := httptest.NewRequest(http.MethodGet, scheme+testDomain+model.RecoveryPiecePath, nil)
+		for key, value := range headers {
+			r.Header.Set(key, value)
+		}
+		return r
+	}
+	expired, _ := json.Marshal(makeRecoveryApproval(t, 1, time.Now().Add(-time.Minute).Unix()))
+	testCases := []struct {
+		name           string
+		gateway        *Gateway
+		headers        map[string]string
+		wantedErrorDes *errorDescription
+	}{
+		{
+			name:           "challenge is not configured",
+			gateway:        &Gateway{},
+			wantedErrorDes: NotExistComponentError,
+		},
+		{
+			name:           "invalid redundancy index",
+			gateway:        &Gateway{challenge: &challengeclient.ChallengeClient{}},
+			headers:        map[string]string{model.GnfdRedundancyIndexHeader: "x"},
+			wantedErrorDes: InvalidHeader,
+		},
+		{
+			name:    "invalid approval",
+			gateway: &Gateway{challenge: &challengeclient.ChallengeClient{}},
+			headers: map[string]string{model.GnfdRedundancyIndexHeader: "-1", model.GnfdPieceIndexHeader: "0",
+				model.GnfdRecoveryApprovalHeader: "{"},
+			wantedErrorDes: InvalidHeader,
+		},
+		{
+			name:    "expired approval",
+			gateway: &Gateway{challenge: &challengeclient.ChallengeClient{}},
+			headers: map[string]string{model.GnfdObjectIDHeader: "1", model.GnfdRedundancyIndexHeader: "-1",
+				model.GnfdPieceIndexHeader: "0", model.GnfdRecoveryApprovalHeader: string(expired)},
+			wantedErrorDes: InvalidHeader,
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			tt.gateway.recoveryPieceHandler(w, newRequest(tt.headers))
+			assert.Equal(t, tt.wantedErrorDes.statusCode, w.Code)
+		})
+	}
+}
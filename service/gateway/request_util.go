@@ -5,6 +5,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"net/http"
+	"sort"
 	"strings"
 	"time"
 
@@ -139,6 +140,14 @@ func (g *Gateway) verifySignature(reqContext *requestContext) (sdk.AccAddress, e
 	if strings.HasPrefix(requestSignature, OffChainSignaturePrefix) {
 		return g.verifyOffChainSignature(reqContext, requestSignature[len(OffChainSignaturePrefix):])
 	}
+	s3SignaturePrefix := model.SignAlgorithmS3V4 + " "
+	if strings.HasPrefix(requestSignature, s3SignaturePrefix) {
+		return g.verifyS3Signature(reqContext, requestSignature[len(s3SignaturePrefix):])
+	}
+	// The pre-signed url carries the signature in query, which is only used to get object.
+	if requestSignature == "" && reqContext.routerName == getObjectRouterName && isPresignedRequest(reqContext) {
+		return g.verifyPresignedSignature(reqContext)
+	}
 	// Anonymous users can get public object.
 	if requestSignature == "" && reqContext.routerName == getObjectRouterName {
 		reqContext.isAnonymous = true
@@ -355,6 +364,122 @@ func parseRange(rangeStr string) (bool, int64, int64) {
 	return false, -1, -1
 }
 
+// parseMultiRange parses the Range header which may contain multiple ranges, e.g. bytes=0-99,200-, every range
+// is in the form which parseRange supports, returns false if any range is invalid.
+func parseMultiRange(rangeStr string) (bool, [][2]int64) {
+	rangeStr = strings.ReplaceAll(strings.ToLower(rangeStr), " ", "")
+	if !strings.HasPrefix(rangeStr, "bytes=") {
+		return false, nil
+	}
+	var ranges [][2]int64
+	for _, item := range strings.Split(rangeStr[len("bytes="):], ",") {
+		isRange, rangeStart, rangeEnd := parseRange("bytes=" + item)
+		if !isRange {
+			return false, nil
+		}
+		ranges = append(ranges, [2]int64{rangeStart, rangeEnd})
+	}
+	return true, ranges
+}
+
+// maxRangeNumber defines the max number of ranges in the Range header of a get object request
+const maxRangeNumber = 100
+
+// normalizeRanges clamps the parsed ranges to the payload size, sorts them and coalesces the overlapping or
+// adjacent ones, so that every byte is served at most once. Returns false if any range is unsatisfiable or
+// the number of ranges exceeds maxRangeNumber.
+func normalizeRanges(ranges [][2]int64, payloadSize int64) (bool, [][2]int64) {
+	if len(ranges) == 0 || len(ranges) > maxRangeNumber {
+		return false, nil
+	}
+	normalized := make([][2]int64, 0, len(ranges))
+	for _, r := range ranges {
+		rangeStart, rangeEnd := r[0], r[1]
+		if rangeEnd < 0 || rangeEnd >= payloadSize {
+			rangeEnd = payloadSize - 1
+		}
+		if rangeStart < 0 || rangeEnd < 0 || rangeStart > rangeEnd {
+			return false, nil
+		}
+		normalized = append(normalized, [2]int64{rangeStart, rangeEnd})
+	}
+	sort.Slice(normalized, func(i, j int) bool { return normalized[i][0] < normalized[j][0] })
+	coalesced := normalized[:1]
+	for _, r := range normalized[1:] {
+		last := &coalesced[len(coalesced)-1]
+		if r[0] > last[1]+1 {
+			coalesced = append(coalesced, r)
+			continue
+		}
+		if r[1] > last[1] {
+			last[1] = r[1]
+		}
+	}
+	return true, coalesced
+}
+
+// etagMatched returns whether the ETag matches one of the comma separated ETags in the conditional header,
+// the weak comparison is used if weak is true, refer: https://www.rfc-editor.org/rfc/rfc7232#section-2.3.2
+func etagMatched(header string, etag string, weak bool) bool {
+	for _, item := range strings.Split(header, ",") {
+		item = strings.TrimSpace(item)
+		if item == "*" {
+			return true
+		}
+		if strings.HasPrefix(item, "W/") {
+			if !weak {
+				continue
+			}
+			item = item[len("W/"):]
+		}
+		if etag != "" && item == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// checkPreconditions evaluates the conditional headers of the get or head object request against the object,
+// returns http.StatusNotModified or http.StatusPreconditionFailed if the request should not be served, or 0 if
+// the request should be served, refer: https://www.rfc-editor.org/rfc/rfc7232#section-6
+func checkPreconditions(r *http.Request, objectInfo *storagetypes.ObjectInfo) int {
+	etag := makeETag(objectInfo)
+	lastModified := time.Unix(objectInfo.GetCreateAt(), 0)
+	if ifMatch := r.Header.Get(model.IfMatchHeader); ifMatch != "" {
+		if !etagMatched(ifMatch, etag, false) {
+			return http.StatusPreconditionFailed
+		}
+	} else if ifUnmodifiedSince := r.Header.Get(model.IfUnmodifiedSinceHeader); ifUnmodifiedSince != "" {
+		if t, err := http.ParseTime(ifUnmodifiedSince); err == nil && lastModified.After(t) {
+			return http.StatusPreconditionFailed
+		}
+	}
+	if ifNoneMatch := r.Header.Get(model.IfNoneMatchHeader); ifNoneMatch != "" {
+		if etagMatched(ifNoneMatch, etag, true) {
+			return http.StatusNotModified
+		}
+	} else if ifModifiedSince := r.Header.Get(model.IfModifiedSinceHeader); ifModifiedSince != "" {
+		if t, err := http.ParseTime(ifModifiedSince); err == nil && !lastModified.After(t) {
+			return http.StatusNotModified
+		}
+	}
+	return 0
+}
+
+// checkIfRange returns whether the Range header should be applied, the Range is ignored if the If-Range
+// header neither strongly matches the ETag nor equals to the Last-Modified of object
+func checkIfRange(r *http.Request, objectInfo *storagetypes.ObjectInfo) bool {
+	ifRange := r.Header.Get(model.IfRangeHeader)
+	if ifRange == "" {
+		return true
+	}
+	if strings.HasPrefix(ifRange, "\"") || strings.HasPrefix(ifRange, "W/") {
+		return etagMatched(ifRange, makeETag(objectInfo), false)
+	}
+	t, err := http.ParseTime(ifRange)
+	return err == nil && t.Equal(time.Unix(objectInfo.GetCreateAt(), 0))
+}
+
 // TODO: can be optimized by retriever
 // checkAuthorization check addr authorization
 func (g *Gateway) checkAuthorization(reqContext *requestContext, addr sdk.AccAddress) error {
@@ -365,6 +490,12 @@ func (g *Gateway) checkAuthorization(reqContext *requestContext, addr sdk.AccAdd
 
 	// TODO: just for auth v2 js-sdk, will be deleted in the future
 	if reqContext.skipAuth {
+		switch reqContext.routerName {
+		case bucketCORSRouterName, scrubResultRouterName:
+			// the auth v2 signature is not verified, so the routers checking the requester are denied
+			log.Errorw("failed to auth due to auth v2 is not allowed", "router", reqContext.routerName)
+			return errors.ErrNoPermission
+		}
 		if mux.CurrentRoute(reqContext.request).GetName() == putObjectRouterName ||
 			mux.CurrentRoute(reqContext.request).GetName() == getObjectRouterName {
 			if reqContext.bucketInfo, reqContext.objectInfo, err = g.chain.QueryBucketInfoAndObjectInfo(
@@ -419,7 +550,7 @@ func (g *Gateway) checkAuthorization(reqContext *requestContext, addr sdk.AccAdd
 			return errors.ErrNoPermission
 		}
 
-	case getObjectRouterName:
+	case getObjectRouterName, s3HeadObjectRouterName, presignedURLRouterName:
 		if reqContext.bucketInfo, reqContext.objectInfo, err = g.chain.QueryBucketInfoAndObjectInfo(
 			context.Background(), reqContext.bucketName, reqContext.objectName); err != nil {
 			log.Errorw("failed to query bucket info and object info on chain",
@@ -452,7 +583,7 @@ func (g *Gateway) checkAuthorization(reqContext *requestContext, addr sdk.AccAdd
 			log.Errorw("failed to check payment due to account status is not active", "status", streamRecord.Status)
 			return errors.ErrCheckPaymentAccountActive
 		}
-	case getBucketReadQuotaRouterName, listBucketReadRecordRouterName:
+	case getBucketReadQuotaRouterName, listBucketReadRecordRouterName, s3ListObjectsV2RouterName, bucketCORSRouterName:
 		if reqContext.bucketInfo, err = g.chain.QueryBucketInfo(
 			context.Background(), reqContext.bucketName); err != nil {
 			log.Errorw("failed to query bucket info and object info on chain",
@@ -465,6 +596,12 @@ func (g *Gateway) checkAuthorization(reqContext *requestContext, addr sdk.AccAdd
 				"request_address", addr.String())
 			return errors.ErrNoPermission
 		}
+	case scrubResultRouterName:
+		if addr.String() != g.config.SpOperatorAddress {
+			log.Errorw("failed to auth due to account is not equal to current sp",
+				"current_sp", g.config.SpOperatorAddress, "request_address", addr.String())
+			return errors.ErrNoPermission
+		}
 	case challengeRouterName:
 		objectID := reqContext.request.Header.Get(model.GnfdObjectIDHeader)
 		if reqContext.objectInfo, err = g.chain.QueryObjectInfoByID(context.Background(), objectID); err != nil {
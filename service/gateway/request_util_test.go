@@ -247,3 +247,105 @@ func TestParseRangeHeader(t *testing.T) {
 	assert.Equal(t, 1, int(start))
 	assert.Equal(t, 100, int(end))
 }
+
+func TestCheckPreconditions(t *testing.T) {
+	objectInfo := &storagetypes.ObjectInfo{
+		CreateAt:  1681747200,
+		Checksums: [][]byte{{0x01, 0xab}},
+	}
+	cases := []struct {
+		name    string
+		method  string
+		headers map[string]string
+		want    int
+	}{
+		{"no conditions", http.MethodGet, nil, 0},
+		{"if-match matched", http.MethodGet, map[string]string{model.IfMatchHeader: "\"ff\", \"01ab\""}, 0},
+		{"if-match wildcard", http.MethodGet, map[string]string{model.IfMatchHeader: "*"}, 0},
+		{"if-match mismatched", http.MethodGet, map[string]string{model.IfMatchHeader: "\"ff\""}, http.StatusPreconditionFailed},
+		{"if-match weak etag", http.MethodGet, map[string]string{model.IfMatchHeader: "W/\"01ab\""}, http.StatusPreconditionFailed},
+		{"if-unmodified-since before", http.MethodGet, map[string]string{model.IfUnmodifiedSinceHeader: "Mon, 17 Apr 2023 15:00:00 GMT"}, http.StatusPreconditionFailed},
+		{"if-unmodified-since ignored by if-match", http.MethodGet, map[string]string{model.IfMatchHeader: "\"01ab\"", model.IfUnmodifiedSinceHeader: "Mon, 17 Apr 2023 15:00:00 GMT"}, 0},
+		{"if-none-match matched", http.MethodGet, map[string]string{model.IfNoneMatchHeader: "\"01ab\""}, http.StatusNotModified},
+		{"if-none-match weak matched", http.MethodGet, map[string]string{model.IfNoneMatchHeader: "W/\"01ab\""}, http.StatusNotModified},
+		{"if-none-match mismatched", http.MethodGet, map[string]string{model.IfNoneMatchHeader: "\"ff\""}, 0},
+		{"if-modified-since equal", http.MethodGet, map[string]string{model.IfModifiedSinceHeader: "Mon, 17 Apr 2023 16:00:00 GMT"}, http.StatusNotModified},
+		{"if-modified-since before", http.MethodGet, map[string]string{model.IfModifiedSinceHeader: "Mon, 17 Apr 2023 15:00:00 GMT"}, 0},
+		{"if-modified-since ignored by if-none-match", http.MethodGet, map[string]string{model.IfNoneMatchHeader: "\"ff\"", model.IfModifiedSinceHeader: "Mon, 17 Apr 2023 16:00:00 GMT"}, 0},
+		{"if-modified-since invalid date", http.MethodGet, map[string]string{model.IfModifiedSinceHeader: "invalid"}, 0},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			r, _ := http.NewRequest(c.method, "/object", nil)
+			for k, v := range c.headers {
+				r.Header.Set(k, v)
+			}
+			assert.Equal(t, c.want, checkPreconditions(r, objectInfo))
+		})
+	}
+}
+
+func TestCheckIfRange(t *testing.T) {
+	objectInfo := &storagetypes.ObjectInfo{
+		CreateAt:  1681747200,
+		Checksums: [][]byte{{0x01, 0xab}},
+	}
+	cases := []struct {
+		ifRange string
+		want    bool
+	}{
+		{"", true},
+		{"\"01ab\"", true},
+		{"\"ff\"", false},
+		{"W/\"01ab\"", false},
+		{"Mon, 17 Apr 2023 16:00:00 GMT", true},
+		{"Mon, 17 Apr 2023 15:00:00 GMT", false},
+		{"invalid", false},
+	}
+	for _, c := range cases {
+		r, _ := http.NewRequest(http.MethodGet, "/object", nil)
+		if c.ifRange != "" {
+			r.Header.Set(model.IfRangeHeader, c.ifRange)
+		}
+		assert.Equal(t, c.want, checkIfRange(r, objectInfo), c.ifRange)
+	}
+}
+
+func TestParseMultiRange(t *testing.T) {
+	isRange, ranges := parseMultiRange("bytes=0-99")
+	assert.Equal(t, true, isRange)
+	assert.Equal(t, [][2]int64{{0, 99}}, ranges)
+
+	isRange, ranges = parseMultiRange("bytes=0-99, 200-299,500-")
+	assert.Equal(t, true, isRange)
+	assert.Equal(t, [][2]int64{{0, 99}, {200, 299}, {500, -1}}, ranges)
+
+	isRange, _ = parseMultiRange("bytes=0-99,abc")
+	assert.Equal(t, false, isRange)
+
+	isRange, _ = parseMultiRange("items=0-99")
+	assert.Equal(t, false, isRange)
+
+	isRange, _ = parseMultiRange("")
+	assert.Equal(t, false, isRange)
+}
+
+func TestNormalizeRanges(t *testing.T) {
+	ok, ranges := normalizeRanges([][2]int64{{200, 299}, {0, 99}, {50, 149}, {150, 160}, {900, -1}}, 1000)
+	assert.Equal(t, true, ok)
+	assert.Equal(t, [][2]int64{{0, 160}, {200, 299}, {900, 999}}, ranges)
+
+	ok, ranges = normalizeRanges([][2]int64{{0, 99}, {0, 99}}, 50)
+	assert.Equal(t, true, ok)
+	assert.Equal(t, [][2]int64{{0, 49}}, ranges)
+
+	ok, _ = normalizeRanges([][2]int64{{0, 99}, {100, 10}}, 1000)
+	assert.Equal(t, false, ok)
+
+	tooMany := make([][2]int64, maxRangeNumber+1)
+	for i := range tooMany {
+		tooMany[i] = [2]int64{int64(i * 2), int64(i * 2)}
+	}
+	ok, _ = normalizeRanges(tooMany, 1000)
+	assert.Equal(t, false, ok)
+}
@@ -5,6 +5,9 @@ import (
 	"encoding/xml"
 	"net/http"
 	"strconv"
+	"strings"
+
+	storagetypes "github.com/bnb-chain/greenfield/x/storage/types"
 
 	"github.com/bnb-chain/greenfield-storage-provider/model"
 	merrors "github.com/bnb-chain/greenfield-storage-provider/model/errors"
@@ -28,6 +31,9 @@ var (
 	InvalidPayload           = &errorDescription{errorCode: "InvalidPayload", errorMessage: "Payload is empty", statusCode: http.StatusBadRequest}
 	InvalidObjectState       = &errorDescription{errorCode: "InvalidObjectState", errorMessage: "Object state is invalid", statusCode: http.StatusForbidden}
 	InvalidRange             = &errorDescription{errorCode: "InvalidRange", errorMessage: "Range is invalid", statusCode: http.StatusBadRequest}
+	InvalidUploadOffset      = &errorDescription{errorCode: "InvalidUploadOffset", errorMessage: "Upload offset is invalid", statusCode: http.StatusBadRequest}
+	InvalidSegmentSize       = &errorDescription{errorCode: "InvalidSegmentSize", errorMessage: "Segment size is invalid", statusCode: http.StatusBadRequest}
+	InvalidSegmentChecksum   = &errorDescription{errorCode: "InvalidSegmentChecksum", errorMessage: "Segment differs from the uploaded one", statusCode: http.StatusBadRequest}
 	InvalidAddress           = &errorDescription{errorCode: "InvalidAddress", errorMessage: "Address is illegal", statusCode: http.StatusBadRequest}
 	InvalidMaxKeys           = &errorDescription{errorCode: "InvalidMaxKeys", errorMessage: "MaxKeys is illegal", statusCode: http.StatusBadRequest}
 	InvalidStartAfter        = &errorDescription{errorCode: "InvalidStartAfter", errorMessage: "StartAfter is illegal", statusCode: http.StatusBadRequest}
@@ -39,10 +45,20 @@ var (
 	NoSuchKey                = &errorDescription{errorCode: "NoSuchKey", errorMessage: "The specified key does not exist.", statusCode: http.StatusNotFound}
 	NoSuchBucket             = &errorDescription{errorCode: "NoSuchBucket", errorMessage: "The specified bucket does not exist.", statusCode: http.StatusNotFound}
 	NoRouter                 = &errorDescription{errorCode: "NoRouter", errorMessage: "The request can not route any handlers", statusCode: http.StatusNotFound}
+	InvalidAccessKeyID       = &errorDescription{errorCode: "InvalidAccessKeyId", errorMessage: "The access key Id you provided does not exist or its off-chain auth key is expired.", statusCode: http.StatusForbidden}
+	RequestTimeTooSkewed     = &errorDescription{errorCode: "RequestTimeTooSkewed", errorMessage: "The difference between the request time and the server's time is too large.", statusCode: http.StatusForbidden}
+	PresignedURLExpired      = &errorDescription{errorCode: "AccessDenied", errorMessage: "Request has expired", statusCode: http.StatusForbidden}
+	InvalidPresignedExpiry   = &errorDescription{errorCode: "AuthorizationQueryParametersError", errorMessage: "The expires must be less than a week (604800 seconds) from now.", statusCode: http.StatusBadRequest}
+	CORSForbidden            = &errorDescription{errorCode: "AccessForbidden", errorMessage: "CORSResponse: This CORS request is not allowed.", statusCode: http.StatusForbidden}
+	InvalidCORSConfiguration = &errorDescription{errorCode: "MalformedCORSConfiguration", errorMessage: "The CORS configuration you provided is not well formed or did not validate.", statusCode: http.StatusBadRequest}
+	PreconditionFailed       = &errorDescription{errorCode: "PreconditionFailed", errorMessage: "At least one of the preconditions you specified did not hold.", statusCode: http.StatusPreconditionFailed}
 	// 5xx
 	InternalError          = &errorDescription{errorCode: "InternalError", errorMessage: "Internal Server Error", statusCode: http.StatusInternalServerError}
 	NotImplementedError    = &errorDescription{errorCode: "NotImplementedError", errorMessage: "Not Implemented Error", statusCode: http.StatusNotImplemented}
 	NotExistComponentError = &errorDescription{errorCode: "NotExistComponentError", errorMessage: "Not Existed Component Error", statusCode: http.StatusNotImplemented}
+	PieceCorrupt           = &errorDescription{errorCode: "PieceCorrupt", errorMessage: "Piece data is corrupt", statusCode: http.StatusInternalServerError}
+	PayloadNotImplemented  = &errorDescription{errorCode: "NotImplemented", errorMessage: "The payload signing mode is not implemented.", statusCode: http.StatusNotImplemented}
+	PayloadHashMismatch    = &errorDescription{errorCode: "XAmzContentSHA256Mismatch", errorMessage: "The provided 'x-amz-content-sha256' header does not match what was computed.", statusCode: http.StatusBadRequest}
 )
 
 // off-chain-auth errors
@@ -124,6 +140,22 @@ func makeContentRangeHeader(w http.ResponseWriter, start int64, end int64) {
 	}
 }
 
+// makeSegmentIndexesHeader returns the comma separated segment indexes
+func makeSegmentIndexesHeader(segmentIndexes []uint32) string {
+	indexes := make([]string, len(segmentIndexes))
+	for i, segmentIndex := range segmentIndexes {
+		indexes[i] = strconv.FormatUint(uint64(segmentIndex), 10)
+	}
+	return strings.Join(indexes, ",")
+}
+
+// notModifiedResponse writes the 304 Not Modified response carrying the validators of object without body
+func notModifiedResponse(w http.ResponseWriter, reqContext *requestContext, objectInfo *storagetypes.ObjectInfo) {
+	w.Header().Set(model.GnfdRequestIDHeader, reqContext.requestID)
+	makeObjectHeaders(w, objectInfo)
+	w.WriteHeader(http.StatusNotModified)
+}
+
 // makeErrorDescription is used to convent err to errorDescription
 func makeErrorDescription(err error) *errorDescription {
 	switch err {
@@ -133,12 +165,32 @@ func makeErrorDescription(err error) *errorDescription {
 		return NoSuchBucket
 	case merrors.ErrAuthorizationFormat, merrors.ErrRequestConsistent, merrors.ErrSignatureConsistent, merrors.ErrUnsupportedSignType:
 		return SignatureNotMatch
-	case merrors.ErrNoPermission, merrors.ErrCheckPaymentAccountActive:
+	case merrors.ErrNoPermission, merrors.ErrCheckPaymentAccountActive, merrors.ErrNoValidAuthKey:
 		return AccessDenied
 	case merrors.ErrCheckQuotaEnough:
 		return OutOfQuota
 	case merrors.ErrCheckObjectCreated, merrors.ErrCheckObjectSealed:
 		return InvalidObjectState
+	case merrors.ErrInvalidUploadOffset:
+		return InvalidUploadOffset
+	case merrors.ErrMismatchSegmentSize:
+		return InvalidSegmentSize
+	case merrors.ErrMismatchSegmentChecksum:
+		return InvalidSegmentChecksum
+	case merrors.ErrPieceCorrupt:
+		return PieceCorrupt
+	case merrors.ErrInvalidAccessKey:
+		return InvalidAccessKeyID
+	case merrors.ErrRequestTimeTooSkewed:
+		return RequestTimeTooSkewed
+	case merrors.ErrUnsupportedPayloadSigning:
+		return PayloadNotImplemented
+	case merrors.ErrPayloadHashMismatch:
+		return PayloadHashMismatch
+	case merrors.ErrPresignedURLExpired:
+		return PresignedURLExpired
+	case merrors.ErrPresignedURLExpiryTooLong:
+		return InvalidPresignedExpiry
 	default:
 		return InternalError
 	}
@@ -0,0 +1,12 @@
+package gateway
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMakeSegmentIndexesHeader(t *testing.T) {
+	assert.Equal(t, "", makeSegmentIndexesHeader(nil))
+	assert.Equal(t, "0,3,4", makeSegmentIndexesHeader([]uint32{0, 3, 4}))
+}
@@ -17,6 +17,7 @@ const (
 	getObjectRouterName                   = "GetObject"
 	challengeRouterName                   = "Challenge"
 	replicateObjectPieceRouterName        = "ReplicateObjectPiece"
+	recoveryPieceRouterName               = "RecoveryPiece"
 	getUserBucketsRouterName              = "GetUserBuckets"
 	listObjectsByBucketRouterName         = "ListObjectsByBucketName"
 	getBucketReadQuotaRouterName          = "GetBucketReadQuota"
@@ -28,6 +29,15 @@ const (
 	viewObjectByUniversalEndpointName     = "ViewObjectByUniversalEndpoint"
 	getObjectMetaRouterName               = "getObjectMeta"
 	getBucketMetaRouterName               = "getBucketMeta"
+	headObjectRouterName                  = "HeadObject"
+	createS3CredentialRouterName          = "CreateS3Credential"
+	s3ListBucketsRouterName               = "S3ListBuckets"
+	s3ListObjectsV2RouterName             = "S3ListObjectsV2"
+	s3HeadObjectRouterName                = "S3HeadObject"
+	corsPreflightRouterName               = "CORSPreflight"
+	bucketCORSRouterName                  = "BucketCORS"
+	scrubResultRouterName                 = "ScrubResult"
+	presignedURLRouterName                = "PresignedURL"
 )
 
 const (
@@ -48,8 +58,46 @@ func (g *Gateway) notFoundHandler(w http.ResponseWriter, r *http.Request) {
 
 // registerHandler is used to register mux handlers.
 func (g *Gateway) registerHandler(r *mux.Router) {
+	// s3 compatible router, the requests signed by s3 signature version 4 are routed here firstly, the s3
+	// GetObject and PutObject requests fall through to the object routers below which accept the s3 signature
+	s3Router := r.MatcherFunc(isS3Request).Subrouter()
+	s3Router.NewRoute().
+		Name(s3ListObjectsV2RouterName).
+		Methods(http.MethodGet).
+		Host("{bucket:.+}."+g.config.Domain).
+		Path("/").
+		Queries(model.ListObjectsV2Query, s3ListObjectsV2).
+		HandlerFunc(g.s3ListObjectsV2Handler)
+	s3Router.NewRoute().
+		Name(s3HeadObjectRouterName).
+		Methods(http.MethodHead).
+		Host("{bucket:.+}." + g.config.Domain).
+		Path("/{object:.+}").
+		HandlerFunc(g.s3HeadObjectHandler)
+	s3Router.NewRoute().
+		Name(s3ListBucketsRouterName).
+		Methods(http.MethodGet).
+		Path("/").
+		MatcherFunc(func(r *http.Request, rm *mux.RouteMatch) bool { return !g.isS3BucketHost(r, rm) }).
+		HandlerFunc(g.s3ListBucketsHandler)
+	s3Router.NewRoute().
+		Name(s3ListObjectsV2RouterName).
+		Methods(http.MethodGet).
+		Path("/{bucket:[^/]+}{slash:/?}").
+		Queries(model.ListObjectsV2Query, s3ListObjectsV2).
+		HandlerFunc(g.s3ListObjectsV2Handler)
+	s3Router.NewRoute().
+		Name(s3HeadObjectRouterName).
+		Methods(http.MethodHead).
+		Path("/{bucket}/{object:.+}").
+		HandlerFunc(g.s3HeadObjectHandler)
+
 	// bucket router, virtual-hosted style
 	hostBucketRouter := r.Host("{bucket:.+}." + g.config.Domain).Subrouter()
+	hostBucketRouter.NewRoute().
+		Name(corsPreflightRouterName).
+		Methods(http.MethodOptions).
+		HandlerFunc(g.corsPreflightHandler)
 	hostBucketRouter.NewRoute().
 		Name(putObjectRouterName).
 		Methods(http.MethodPut).
@@ -72,6 +120,17 @@ func (g *Gateway) registerHandler(r *mux.Router) {
 		Path("/{object:.+}").
 		Queries(model.GetObjectMetaQuery, "").
 		HandlerFunc(g.getObjectMetaHandler)
+	hostBucketRouter.NewRoute().
+		Name(presignedURLRouterName).
+		Methods(http.MethodGet).
+		Path("/{object:.+}").
+		Queries(model.PresignedURLQuery, "").
+		HandlerFunc(g.presignedURLHandler)
+	hostBucketRouter.NewRoute().
+		Name(headObjectRouterName).
+		Methods(http.MethodHead).
+		Path("/{object:.+}").
+		HandlerFunc(g.headObjectHandler)
 	hostBucketRouter.NewRoute().
 		Name(getObjectRouterName).
 		Methods(http.MethodGet).
@@ -119,6 +178,24 @@ func (g *Gateway) registerHandler(r *mux.Router) {
 		Name(replicateObjectPieceRouterName).
 		Methods(http.MethodPut).
 		HandlerFunc(g.replicatePieceHandler)
+	// get piece from other sp to recover the lost pieces
+	r.Path(model.RecoveryPiecePath).
+		Name(recoveryPieceRouterName).
+		Methods(http.MethodGet).
+		HandlerFunc(g.recoveryPieceHandler)
+	r.Path(model.ScrubResultPath).
+		Name(scrubResultRouterName).
+		Methods(http.MethodGet).
+		HandlerFunc(g.scrubResultHandler)
+	r.Path(model.BucketCORSPath+"/{bucket}").
+		Name(bucketCORSRouterName).
+		Methods(http.MethodGet, http.MethodPut, http.MethodDelete).
+		HandlerFunc(g.bucketCORSHandler)
+	// cors preflight of universal endpoint
+	r.Path("/{endpoint:download|view}/{bucket:[^/]*}/{object:.+}").
+		Name(corsPreflightRouterName).
+		Methods(http.MethodOptions).
+		HandlerFunc(g.corsPreflightHandler)
 	// universal endpoint download
 	r.Path("/download/{bucket:[^/]*}/{object:.+}").
 		Name(downloadObjectByUniversalEndpointName).
@@ -141,9 +218,17 @@ func (g *Gateway) registerHandler(r *mux.Router) {
 		Name(updateUserPublicKey).
 		Methods(http.MethodPost).
 		HandlerFunc(g.updateUserPublicKeyHandler)
+	r.Path(model.AuthCreateS3CredentialPath).
+		Name(createS3CredentialRouterName).
+		Methods(http.MethodPost).
+		HandlerFunc(g.createS3CredentialHandler)
 
 	// path style
 	pathBucketRouter := r.PathPrefix("/{bucket}").Subrouter()
+	pathBucketRouter.NewRoute().
+		Name(corsPreflightRouterName).
+		Methods(http.MethodOptions).
+		HandlerFunc(g.corsPreflightHandler)
 	pathBucketRouter.NewRoute().
 		Name(putObjectRouterName).
 		Methods(http.MethodPut).
@@ -166,6 +251,17 @@ func (g *Gateway) registerHandler(r *mux.Router) {
 		Path("/{object:.+}").
 		Queries(model.GetObjectMetaQuery, "").
 		HandlerFunc(g.getObjectMetaHandler)
+	pathBucketRouter.NewRoute().
+		Name(presignedURLRouterName).
+		Methods(http.MethodGet).
+		Path("/{object:.+}").
+		Queries(model.PresignedURLQuery, "").
+		HandlerFunc(g.presignedURLHandler)
+	pathBucketRouter.NewRoute().
+		Name(headObjectRouterName).
+		Methods(http.MethodHead).
+		Path("/{object:.+}").
+		HandlerFunc(g.headObjectHandler)
 	pathBucketRouter.NewRoute().
 		Name(getObjectRouterName).
 		Methods(http.MethodGet).
@@ -194,4 +290,5 @@ func (g *Gateway) registerHandler(r *mux.Router) {
 
 	r.NotFoundHandler = http.HandlerFunc(g.notFoundHandler)
 	r.Use(localhttp.Limit)
+	r.Use(g.corsMiddleware)
 }
@@ -69,6 +69,54 @@ func TestRouters(t *testing.T) {
 			shouldMatch:      true,
 			wantedRouterName: queryUploadProgressRouterName,
 		},
+		{
+			name:             "Head object router, virtual host style",
+			router:           gwRouter,
+			method:           http.MethodHead,
+			url:              scheme + bucketName + "." + testDomain + "/" + objectName,
+			shouldMatch:      true,
+			wantedRouterName: headObjectRouterName,
+		},
+		{
+			name:             "Head object router, path style",
+			router:           gwRouter,
+			method:           http.MethodHead,
+			url:              scheme + testDomain + "/" + bucketName + "/" + objectName,
+			shouldMatch:      true,
+			wantedRouterName: headObjectRouterName,
+		},
+		{
+			name:             "CORS preflight router, virtual host style",
+			router:           gwRouter,
+			method:           http.MethodOptions,
+			url:              scheme + bucketName + "." + testDomain + "/" + objectName,
+			shouldMatch:      true,
+			wantedRouterName: corsPreflightRouterName,
+		},
+		{
+			name:             "CORS preflight router, path style",
+			router:           gwRouter,
+			method:           http.MethodOptions,
+			url:              scheme + testDomain + "/" + bucketName + "/" + objectName,
+			shouldMatch:      true,
+			wantedRouterName: corsPreflightRouterName,
+		},
+		{
+			name:             "CORS preflight router, universal endpoint view",
+			router:           gwRouter,
+			method:           http.MethodOptions,
+			url:              scheme + testDomain + "/view/" + bucketName + "/" + objectName,
+			shouldMatch:      true,
+			wantedRouterName: corsPreflightRouterName,
+		},
+		{
+			name:             "Put bucket cors router",
+			router:           gwRouter,
+			method:           http.MethodPut,
+			url:              scheme + testDomain + model.BucketCORSPath + "/" + bucketName,
+			shouldMatch:      true,
+			wantedRouterName: bucketCORSRouterName,
+		},
 		{
 			name:             "Get object router, virtual host style",
 			router:           gwRouter,
@@ -162,6 +210,22 @@ func TestRouters(t *testing.T) {
 			shouldMatch:      true,
 			wantedRouterName: getObjectMetaRouterName,
 		},
+		{
+			name:             "Issue pre-signed url router, virtual host style",
+			router:           gwRouter,
+			method:           http.MethodGet,
+			url:              scheme + bucketName + "." + testDomain + "/" + objectName + "?" + model.PresignedURLQuery,
+			shouldMatch:      true,
+			wantedRouterName: presignedURLRouterName,
+		},
+		{
+			name:             "Issue pre-signed url router, path style",
+			router:           gwRouter,
+			method:           http.MethodGet,
+			url:              scheme + testDomain + "/" + bucketName + "/" + objectName + "?" + model.PresignedURLQuery,
+			shouldMatch:      true,
+			wantedRouterName: presignedURLRouterName,
+		},
 		{
 			name:             "Get bucket metadata router, virtual host style",
 			router:           gwRouter,
@@ -194,6 +258,22 @@ func TestRouters(t *testing.T) {
 			shouldMatch:      true,
 			wantedRouterName: replicateObjectPieceRouterName,
 		},
+		{
+			name:             "Recovery piece router",
+			router:           gwRouter,
+			method:           http.MethodGet,
+			url:              scheme + testDomain + model.RecoveryPiecePath,
+			shouldMatch:      true,
+			wantedRouterName: recoveryPieceRouterName,
+		},
+		{
+			name:             "Scrub result router",
+			router:           gwRouter,
+			method:           http.MethodGet,
+			url:              scheme + testDomain + model.ScrubResultPath + "?" + model.ScrubStatusQuery + "=corrupt",
+			shouldMatch:      true,
+			wantedRouterName: scrubResultRouterName,
+		},
 	}
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
@@ -209,3 +289,72 @@ func TestRouters(t *testing.T) {
 		})
 	}
 }
+
+func TestS3Routers(t *testing.T) {
+	gwRouter := setupRouter(t)
+	testCases := []struct {
+		name             string
+		method           string // the request method
+		url              string // the request url, include path + query
+		wantedRouterName string // the matched router name
+	}{
+		{
+			name:             "S3 list buckets router",
+			method:           http.MethodGet,
+			url:              scheme + testDomain + "/",
+			wantedRouterName: s3ListBucketsRouterName,
+		},
+		{
+			name:             "S3 list objects v2 router, virtual host style",
+			method:           http.MethodGet,
+			url:              scheme + bucketName + "." + testDomain + "/?" + model.ListObjectsV2Query + "=2",
+			wantedRouterName: s3ListObjectsV2RouterName,
+		},
+		{
+			name:             "S3 list objects v2 router, path style",
+			method:           http.MethodGet,
+			url:              scheme + testDomain + "/" + bucketName + "?" + model.ListObjectsV2Query + "=2&prefix=a",
+			wantedRouterName: s3ListObjectsV2RouterName,
+		},
+		{
+			name:             "S3 head object router, virtual host style",
+			method:           http.MethodHead,
+			url:              scheme + bucketName + "." + testDomain + "/" + objectName,
+			wantedRouterName: s3HeadObjectRouterName,
+		},
+		{
+			name:             "S3 head object router, path style",
+			method:           http.MethodHead,
+			url:              scheme + testDomain + "/" + bucketName + "/" + objectName,
+			wantedRouterName: s3HeadObjectRouterName,
+		},
+		{
+			name:             "S3 get object router, path style",
+			method:           http.MethodGet,
+			url:              scheme + testDomain + "/" + bucketName + "/" + objectName,
+			wantedRouterName: getObjectRouterName,
+		},
+		{
+			name:             "S3 put object router, virtual host style",
+			method:           http.MethodPut,
+			url:              scheme + bucketName + "." + testDomain + "/" + objectName,
+			wantedRouterName: putObjectRouterName,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			request := httptest.NewRequest(testCase.method, testCase.url, strings.NewReader(""))
+			request.Header.Set(model.GnfdAuthorizationHeader, model.SignAlgorithmS3V4+" Credential=test")
+
+			var match mux.RouteMatch
+			assert.True(t, gwRouter.Match(request, &match))
+			assert.Equal(t, testCase.wantedRouterName, match.Route.GetName())
+		})
+	}
+
+	// the greenfield requests are not routed to s3 routers
+	request := httptest.NewRequest(http.MethodGet, scheme+testDomain+"/", strings.NewReader(""))
+	var match mux.RouteMatch
+	assert.True(t, gwRouter.Match(request, &match))
+	assert.Equal(t, getUserBucketsRouterName, match.Route.GetName())
+}
@@ -0,0 +1,345 @@
+package gateway
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/xml"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/bnb-chain/greenfield/types/s3util"
+	storagetypes "github.com/bnb-chain/greenfield/x/storage/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/gorilla/mux"
+
+	"github.com/bnb-chain/greenfield-storage-provider/model"
+	"github.com/bnb-chain/greenfield-storage-provider/pkg/log"
+	metatypes "github.com/bnb-chain/greenfield-storage-provider/service/metadata/types"
+	"github.com/bnb-chain/greenfield-storage-provider/util"
+)
+
+const (
+	// s3XMLNamespace defines the xml namespace of s3 responses
+	s3XMLNamespace = "http://s3.amazonaws.com/doc/2006-03-01/"
+	// s3ListObjectsV2 defines the value of list-type query of ListObjectsV2
+	s3ListObjectsV2 = "2"
+	// s3StorageClass defines the storage class of all objects
+	s3StorageClass = "STANDARD"
+	// s3ListTimeFormat defines the time format in s3 list responses
+	s3ListTimeFormat = "2006-01-02T15:04:05.000Z"
+)
+
+// s3Owner is the owner of buckets in s3 responses
+type s3Owner struct {
+	ID          string `xml:"ID"`
+	DisplayName string `xml:"DisplayName"`
+}
+
+// s3Bucket is the bucket in s3 ListBuckets response
+type s3Bucket struct {
+	Name         string `xml:"Name"`
+	CreationDate string `xml:"CreationDate"`
+}
+
+// s3ListAllMyBucketsResult is the s3 ListBuckets response
+type s3ListAllMyBucketsResult struct {
+	XMLName xml.Name   `xml:"ListAllMyBucketsResult"`
+	Xmlns   string     `xml:"xmlns,attr"`
+	Owner   s3Owner    `xml:"Owner"`
+	Buckets []s3Bucket `xml:"Buckets>Bucket"`
+}
+
+// s3Object is the object in s3 ListObjectsV2 response
+type s3Object struct {
+	Key          string `xml:"Key"`
+	LastModified string `xml:"LastModified"`
+	ETag         string `xml:"ETag"`
+	Size         uint64 `xml:"Size"`
+	StorageClass string `xml:"StorageClass"`
+}
+
+// s3CommonPrefix is the common prefix in s3 ListObjectsV2 response
+type s3CommonPrefix struct {
+	Prefix string `xml:"Prefix"`
+}
+
+// s3ListBucketResult is the s3 ListObjectsV2 response
+type s3ListBucketResult struct {
+	XMLName               xml.Name         `xml:"ListBucketResult"`
+	Xmlns                 string           `xml:"xmlns,attr"`
+	Name                  string           `xml:"Name"`
+	Prefix                string           `xml:"Prefix"`
+	Delimiter             string           `xml:"Delimiter,omitempty"`
+	StartAfter            string           `xml:"StartAfter,omitempty"`
+	MaxKeys               uint64           `xml:"MaxKeys"`
+	KeyCount              uint64           `xml:"KeyCount"`
+	IsTruncated           bool             `xml:"IsTruncated"`
+	ContinuationToken     string           `xml:"ContinuationToken,omitempty"`
+	NextContinuationToken string           `xml:"NextContinuationToken,omitempty"`
+	Contents              []s3Object       `xml:"Contents"`
+	CommonPrefixes        []s3CommonPrefix `xml:"CommonPrefixes"`
+}
+
+// isS3BucketHost returns whether the request is sent to virtual-hosted style bucket endpoint
+func (gateway *Gateway) isS3BucketHost(r *http.Request, _ *mux.RouteMatch) bool {
+	host := r.Host
+	if !strings.Contains(gateway.config.Domain, ":") {
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+	}
+	return strings.HasSuffix(host, "."+gateway.config.Domain)
+}
+
+// makeETag returns the ETag of object which is derived from the integrity hash
+func makeETag(objectInfo *storagetypes.ObjectInfo) string {
+	if len(objectInfo.GetChecksums()) == 0 {
+		return ""
+	}
+	return "\"" + hex.EncodeToString(objectInfo.GetChecksums()[0]) + "\""
+}
+
+// makeObjectHeaders sets the ETag and Last-Modified headers of object
+func makeObjectHeaders(w http.ResponseWriter, objectInfo *storagetypes.ObjectInfo) {
+	if etag := makeETag(objectInfo); etag != "" {
+		w.Header().Set(model.ETagHeader, etag)
+	}
+	w.Header().Set(model.LastModifiedHeader, time.Unix(objectInfo.GetCreateAt(), 0).UTC().Format(http.TimeFormat))
+}
+
+// makeObjectMetaHeaders sets the metadata headers of object, which are used to response the head object request
+func makeObjectMetaHeaders(w http.ResponseWriter, objectInfo *storagetypes.ObjectInfo) {
+	checksums := make([]string, 0, len(objectInfo.GetChecksums()))
+	for _, checksum := range objectInfo.GetChecksums() {
+		checksums = append(checksums, hex.EncodeToString(checksum))
+	}
+	w.Header().Set(model.ContentTypeHeader, objectInfo.GetContentType())
+	w.Header().Set(model.ContentLengthHeader, util.Uint64ToString(objectInfo.GetPayloadSize()))
+	w.Header().Set(model.GnfdObjectIDHeader, objectInfo.Id.String())
+	w.Header().Set(model.GnfdObjectStatusHeader, objectInfo.GetObjectStatus().String())
+	w.Header().Set(model.GnfdChecksumsHeader, strings.Join(checksums, ","))
+	w.Header().Set(model.GnfdSecondarySPsHeader, strings.Join(objectInfo.GetSecondarySpAddresses(), ","))
+	makeObjectHeaders(w, objectInfo)
+}
+
+// xmlResponse writes the s3 xml response
+func xmlResponse(w http.ResponseWriter, reqContext *requestContext, v interface{}) error {
+	body, err := xml.Marshal(v)
+	if err != nil {
+		return err
+	}
+	w.Header().Set(model.GnfdRequestIDHeader, reqContext.requestID)
+	w.Header().Set(model.ContentTypeHeader, model.ContentTypeXMLHeaderValue)
+	_, err = w.Write(append([]byte(xml.Header), body...))
+	return err
+}
+
+// s3ListBucketsHandler handles the s3 ListBuckets request, which lists the buckets owned by the signer
+func (gateway *Gateway) s3ListBucketsHandler(w http.ResponseWriter, r *http.Request) {
+	var (
+		err            error
+		errDescription *errorDescription
+		reqContext     *requestContext
+		addr           sdk.AccAddress
+	)
+
+	reqContext = newRequestContext(r)
+	defer func() {
+		if errDescription != nil {
+			_ = errDescription.errorResponse(w, reqContext)
+		}
+		if errDescription != nil && errDescription.statusCode != http.StatusOK {
+			log.Errorf("action(%v) statusCode(%v) %v", s3ListBucketsRouterName, errDescription.statusCode, reqContext.generateRequestDetail())
+		} else {
+			log.Infof("action(%v) statusCode(200) %v", s3ListBucketsRouterName, reqContext.generateRequestDetail())
+		}
+	}()
+
+	if gateway.metadata == nil {
+		log.Error("failed to list buckets due to not config metadata")
+		errDescription = NotExistComponentError
+		return
+	}
+
+	if addr, err = gateway.verifySignature(reqContext); err != nil {
+		log.Errorw("failed to verify signature", "error", err)
+		errDescription = makeErrorDescription(err)
+		return
+	}
+	if err = gateway.checkAuthorization(reqContext, addr); err != nil {
+		log.Errorw("failed to check authorization", "error", err)
+		errDescription = makeErrorDescription(err)
+		return
+	}
+
+	req := &metatypes.GetUserBucketsRequest{
+		AccountId: addr.String(),
+	}
+	ctx := log.Context(context.Background(), req)
+	resp, err := gateway.metadata.GetUserBuckets(ctx, req)
+	if err != nil {
+		log.Errorf("failed to get user buckets", "error", err)
+		errDescription = makeErrorDescription(err)
+		return
+	}
+
+	result := &s3ListAllMyBucketsResult{
+		Xmlns: s3XMLNamespace,
+		Owner: s3Owner{ID: addr.String(), DisplayName: addr.String()},
+	}
+	for _, bucket := range resp.GetBuckets() {
+		if bucket.GetRemoved() || bucket.GetBucketInfo() == nil {
+			continue
+		}
+		result.Buckets = append(result.Buckets, s3Bucket{
+			Name:         bucket.GetBucketInfo().GetBucketName(),
+			CreationDate: time.Unix(bucket.GetBucketInfo().GetCreateAt(), 0).UTC().Format(s3ListTimeFormat),
+		})
+	}
+	if err = xmlResponse(w, reqContext, result); err != nil {
+		log.Errorw("failed to write list buckets response", "error", err)
+	}
+}
+
+// s3ListObjectsV2Handler handles the s3 ListObjectsV2 request, which is only allowed for the bucket owner
+func (gateway *Gateway) s3ListObjectsV2Handler(w http.ResponseWriter, r *http.Request) {
+	var (
+		err            error
+		errDescription *errorDescription
+		reqContext     *requestContext
+		addr           sdk.AccAddress
+		resp           *metatypes.ListObjectsByBucketNameResponse
+	)
+
+	reqContext = newRequestContext(r)
+	defer func() {
+		if errDescription != nil {
+			_ = errDescription.errorResponse(w, reqContext)
+		}
+		if errDescription != nil && errDescription.statusCode != http.StatusOK {
+			log.Errorf("action(%v) statusCode(%v) %v", s3ListObjectsV2RouterName, errDescription.statusCode, reqContext.generateRequestDetail())
+		} else {
+			log.Infof("action(%v) statusCode(200) %v", s3ListObjectsV2RouterName, reqContext.generateRequestDetail())
+		}
+	}()
+
+	if gateway.metadata == nil {
+		log.Error("failed to list objects due to not config metadata")
+		errDescription = NotExistComponentError
+		return
+	}
+
+	if err = s3util.CheckValidBucketName(reqContext.bucketName); err != nil {
+		log.Errorw("failed to check bucket name", "bucket_name", reqContext.bucketName, "error", err)
+		errDescription = InvalidBucketName
+		return
+	}
+	if addr, err = gateway.verifySignature(reqContext); err != nil {
+		log.Errorw("failed to verify signature", "error", err)
+		errDescription = makeErrorDescription(err)
+		return
+	}
+	if err = gateway.checkAuthorization(reqContext, addr); err != nil {
+		log.Errorw("failed to check authorization", "error", err)
+		errDescription = makeErrorDescription(err)
+		return
+	}
+
+	if resp, errDescription = gateway.listObjectsByBucketName(reqContext); errDescription != nil {
+		return
+	}
+
+	query := reqContext.request.URL.Query()
+	result := &s3ListBucketResult{
+		Xmlns:                 s3XMLNamespace,
+		Name:                  reqContext.bucketName,
+		Prefix:                resp.GetPrefix(),
+		Delimiter:             resp.GetDelimiter(),
+		StartAfter:            query.Get(model.ListObjectsStartAfterQuery),
+		MaxKeys:               resp.GetMaxKeys(),
+		KeyCount:              resp.GetKeyCount(),
+		IsTruncated:           resp.GetIsTruncated(),
+		ContinuationToken:     query.Get(model.ListObjectsContinuationTokenQuery),
+		NextContinuationToken: resp.GetNextContinuationToken(),
+	}
+	if !result.IsTruncated {
+		result.NextContinuationToken = ""
+	}
+	for _, object := range resp.GetObjects() {
+		objectInfo := object.GetObjectInfo()
+		if object.GetRemoved() || objectInfo == nil {
+			continue
+		}
+		result.Contents = append(result.Contents, s3Object{
+			Key:          objectInfo.GetObjectName(),
+			LastModified: time.Unix(objectInfo.GetCreateAt(), 0).UTC().Format(s3ListTimeFormat),
+			ETag:         makeETag(objectInfo),
+			Size:         objectInfo.GetPayloadSize(),
+			StorageClass: s3StorageClass,
+		})
+	}
+	for _, prefix := range resp.GetCommonPrefixes() {
+		result.CommonPrefixes = append(result.CommonPrefixes, s3CommonPrefix{Prefix: prefix})
+	}
+	if err = xmlResponse(w, reqContext, result); err != nil {
+		log.Errorw("failed to write list objects response", "error", err)
+	}
+}
+
+// s3HeadObjectHandler handles the s3 HeadObject request
+func (gateway *Gateway) s3HeadObjectHandler(w http.ResponseWriter, r *http.Request) {
+	var (
+		err            error
+		errDescription *errorDescription
+		reqContext     *requestContext
+		addr           sdk.AccAddress
+	)
+
+	reqContext = newRequestContext(r)
+	defer func() {
+		if errDescription != nil {
+			_ = errDescription.errorResponse(w, reqContext)
+		}
+		if errDescription != nil && errDescription.statusCode != http.StatusOK {
+			log.Errorf("action(%v) statusCode(%v) %v", s3HeadObjectRouterName, errDescription.statusCode, reqContext.generateRequestDetail())
+		} else {
+			log.Infof("action(%v) statusCode(200) %v", s3HeadObjectRouterName, reqContext.generateRequestDetail())
+		}
+	}()
+
+	if err = s3util.CheckValidBucketName(reqContext.bucketName); err != nil {
+		log.Errorw("failed to check bucket name", "bucket_name", reqContext.bucketName, "error", err)
+		errDescription = InvalidBucketName
+		return
+	}
+	if err = s3util.CheckValidObjectName(reqContext.objectName); err != nil {
+		log.Errorw("failed to check object name", "object_name", reqContext.objectName, "error", err)
+		errDescription = InvalidKey
+		return
+	}
+	if addr, err = gateway.verifySignature(reqContext); err != nil {
+		log.Errorw("failed to verify signature", "error", err)
+		errDescription = makeErrorDescription(err)
+		return
+	}
+	if err = gateway.checkAuthorization(reqContext, addr); err != nil {
+		log.Errorw("failed to check authorization", "error", err)
+		errDescription = makeErrorDescription(err)
+		return
+	}
+
+	switch checkPreconditions(reqContext.request, reqContext.objectInfo) {
+	case http.StatusNotModified:
+		notModifiedResponse(w, reqContext, reqContext.objectInfo)
+		return
+	case http.StatusPreconditionFailed:
+		errDescription = PreconditionFailed
+		return
+	}
+
+	w.Header().Set(model.GnfdRequestIDHeader, reqContext.requestID)
+	makeObjectMetaHeaders(w, reqContext.objectInfo)
+	w.WriteHeader(http.StatusOK)
+}
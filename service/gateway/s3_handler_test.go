@@ -0,0 +1,35 @@
+package gateway
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	storagetypes "github.com/bnb-chain/greenfield/x/storage/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/greenfield-storage-provider/model"
+)
+
+func TestMakeObjectMetaHeaders(t *testing.T) {
+	objectInfo := &storagetypes.ObjectInfo{
+		Id:                   sdkmath.NewUint(7),
+		PayloadSize:          1024,
+		ContentType:          "text/plain",
+		CreateAt:             1681747200,
+		ObjectStatus:         storagetypes.OBJECT_STATUS_SEALED,
+		Checksums:            [][]byte{{0x01, 0xab}, {0x02}},
+		SecondarySpAddresses: []string{"0x01", "0x02"},
+	}
+	w := httptest.NewRecorder()
+	makeObjectMetaHeaders(w, objectInfo)
+
+	assert.Equal(t, "text/plain", w.Header().Get(model.ContentTypeHeader))
+	assert.Equal(t, "1024", w.Header().Get(model.ContentLengthHeader))
+	assert.Equal(t, "7", w.Header().Get(model.GnfdObjectIDHeader))
+	assert.Equal(t, "OBJECT_STATUS_SEALED", w.Header().Get(model.GnfdObjectStatusHeader))
+	assert.Equal(t, "01ab,02", w.Header().Get(model.GnfdChecksumsHeader))
+	assert.Equal(t, "0x01,0x02", w.Header().Get(model.GnfdSecondarySPsHeader))
+	assert.Equal(t, "\"01ab\"", w.Header().Get(model.ETagHeader))
+	assert.Equal(t, "Mon, 17 Apr 2023 16:00:00 GMT", w.Header().Get(model.LastModifiedHeader))
+}
@@ -0,0 +1,240 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/gorilla/mux"
+
+	"github.com/bnb-chain/greenfield-storage-provider/model"
+	merrors "github.com/bnb-chain/greenfield-storage-provider/model/errors"
+	"github.com/bnb-chain/greenfield-storage-provider/pkg/log"
+	authtypes "github.com/bnb-chain/greenfield-storage-provider/service/auth/types"
+)
+
+const (
+	// s3MaxRequestTimeSkew defines the max skew between the s3 request time and the server time
+	s3MaxRequestTimeSkew = 15 * time.Minute
+	// s3TimeFormat defines the ISO 8601 basic format of s3 request time
+	s3TimeFormat = "20060102T150405Z"
+	// s3StreamingPayloadPrefix defines the payload hash prefix of the aws-chunked streaming upload
+	s3StreamingPayloadPrefix = "STREAMING-"
+	// s3ScopeTerminator defines the terminator of s3 credential scope
+	s3ScopeTerminator = "aws4_request"
+	// s3UnsignedPayload defines the payload hash of the request whose payload is not signed
+	s3UnsignedPayload = "UNSIGNED-PAYLOAD"
+)
+
+// s3RequiredSignedHeaders defines the headers which must be signed, so that the signature can not be replayed
+// to another host or after the request time skew, and the payload hash can not be replaced
+var s3RequiredSignedHeaders = []string{"host", "x-amz-content-sha256", "x-amz-date"}
+
+// s3Authorization is the parsed AWS signature version 4 Authorization header, e.g.
+// AWS4-HMAC-SHA256 Credential=AKID/20230101/us-east-1/s3/aws4_request, SignedHeaders=host;x-amz-date, Signature=hex
+type s3Authorization struct {
+	accessKeyID   string
+	date          string
+	region        string
+	service       string
+	signedHeaders []string
+	signature     string
+}
+
+// isS3Request returns whether the request is signed by s3 signature version 4, which is used to route s3 requests
+func isS3Request(r *http.Request, _ *mux.RouteMatch) bool {
+	return strings.HasPrefix(r.Header.Get(model.GnfdAuthorizationHeader), model.SignAlgorithmS3V4+" ")
+}
+
+// parseS3Authorization parses the AWS signature version 4 Authorization header without the algorithm prefix
+func parseS3Authorization(requestSignature string) (*s3Authorization, error) {
+	auth := &s3Authorization{}
+	for _, item := range strings.Split(requestSignature, ",") {
+		pair := strings.SplitN(strings.TrimSpace(item), "=", 2)
+		if len(pair) != 2 {
+			return nil, merrors.ErrAuthorizationFormat
+		}
+		switch pair[0] {
+		case "Credential":
+			scope := strings.Split(pair[1], "/")
+			if len(scope) != 5 || scope[4] != s3ScopeTerminator {
+				return nil, merrors.ErrAuthorizationFormat
+			}
+			auth.accessKeyID, auth.date, auth.region, auth.service = scope[0], scope[1], scope[2], scope[3]
+		case "SignedHeaders":
+			auth.signedHeaders = strings.Split(pair[1], ";")
+		case "Signature":
+			auth.signature = pair[1]
+		default:
+			return nil, merrors.ErrAuthorizationFormat
+		}
+	}
+	if auth.accessKeyID == "" || len(auth.signedHeaders) == 0 || auth.signature == "" {
+		return nil, merrors.ErrAuthorizationFormat
+	}
+	signed := make(map[string]struct{}, len(auth.signedHeaders))
+	for _, header := range auth.signedHeaders {
+		signed[header] = struct{}{}
+	}
+	for _, header := range s3RequiredSignedHeaders {
+		if _, ok := signed[header]; !ok {
+			return nil, merrors.ErrAuthorizationFormat
+		}
+	}
+	return auth, nil
+}
+
+// s3StringToSign builds the AWS signature version 4 string to sign of the request, the payload is not hashed
+// here, it is verified against the signed x-amz-content-sha256 header while reading the request body.
+func s3StringToSign(r *http.Request, auth *s3Authorization, requestTime string) string {
+	canonicalRequest := strings.Join([]string{
+		r.Method,
+		s3URIEncode(r.URL.Path, false),
+		s3CanonicalQuery(r.URL.Query()),
+		s3CanonicalHeaders(r, auth.signedHeaders),
+		strings.Join(auth.signedHeaders, ";"),
+		r.Header.Get(model.AmzContentSha256Header),
+	}, "\n")
+	hash := sha256.Sum256([]byte(canonicalRequest))
+	scope := strings.Join([]string{auth.date, auth.region, auth.service, s3ScopeTerminator}, "/")
+	return strings.Join([]string{model.SignAlgorithmS3V4, requestTime, scope, hex.EncodeToString(hash[:])}, "\n")
+}
+
+func s3CanonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var pairs []string
+	for _, k := range keys {
+		values := append([]string{}, query[k]...)
+		sort.Strings(values)
+		for _, v := range values {
+			pairs = append(pairs, s3URIEncode(k, true)+"="+s3URIEncode(v, true))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+func s3CanonicalHeaders(r *http.Request, signedHeaders []string) string {
+	var sb strings.Builder
+	for _, name := range signedHeaders {
+		var value string
+		switch name {
+		case "host":
+			value = r.Host
+		case "content-length":
+			value = strconv.FormatInt(r.ContentLength, 10)
+		default:
+			value = strings.Join(r.Header.Values(name), ",")
+		}
+		sb.WriteString(name + ":" + strings.Join(strings.Fields(value), " ") + "\n")
+	}
+	return sb.String()
+}
+
+// s3URIEncode encodes the string as the AWS signature version 4 requires, which escapes every byte except
+// the unreserved characters in RFC 3986, and the slash is kept if encodeSlash is false.
+func s3URIEncode(s string, encodeSlash bool) string {
+	const hexChars = "0123456789ABCDEF"
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') ||
+			c == '-' || c == '_' || c == '.' || c == '~' || (c == '/' && !encodeSlash) {
+			sb.WriteByte(c)
+			continue
+		}
+		sb.WriteByte('%')
+		sb.WriteByte(hexChars[c>>4])
+		sb.WriteByte(hexChars[c&15])
+	}
+	return sb.String()
+}
+
+// verifyS3Signature used to verify request type s3 signature version 4, return (address, nil) if check succeed
+func (g *Gateway) verifyS3Signature(reqContext *requestContext, requestSignature string) (sdk.AccAddress, error) {
+	if g.auth == nil {
+		log.Error("failed to verify s3 signature due to not config auth client")
+		return nil, merrors.ErrUnsupportedSignType
+	}
+	auth, err := parseS3Authorization(requestSignature)
+	if err != nil {
+		return nil, err
+	}
+	payloadHash := reqContext.request.Header.Get(model.AmzContentSha256Header)
+	if payloadHash == "" {
+		return nil, merrors.ErrAuthorizationFormat
+	}
+	if strings.HasPrefix(payloadHash, s3StreamingPayloadPrefix) {
+		return nil, merrors.ErrUnsupportedPayloadSigning
+	}
+	var expectedHash []byte
+	if payloadHash != s3UnsignedPayload {
+		if expectedHash, err = hex.DecodeString(payloadHash); err != nil || len(expectedHash) != sha256.Size {
+			return nil, merrors.ErrAuthorizationFormat
+		}
+	}
+	requestTime := reqContext.request.Header.Get(model.AmzDateHeader)
+	t, err := time.Parse(s3TimeFormat, requestTime)
+	if err != nil || !strings.HasPrefix(requestTime, auth.date) {
+		return nil, merrors.ErrAuthorizationFormat
+	}
+	if skew := time.Since(t); skew > s3MaxRequestTimeSkew || skew < -s3MaxRequestTimeSkew {
+		return nil, merrors.ErrRequestTimeTooSkewed
+	}
+
+	req := &authtypes.VerifyS3SignatureRequest{
+		AccessKeyId:  auth.accessKeyID,
+		Date:         auth.date,
+		Region:       auth.region,
+		Service:      auth.service,
+		StringToSign: s3StringToSign(reqContext.request, auth, requestTime),
+		Signature:    auth.signature,
+	}
+	ctx := log.Context(context.Background(), req)
+	resp, err := g.auth.VerifyS3Signature(ctx, req)
+	if err != nil {
+		log.CtxErrorw(ctx, "failed to verify s3 signature", "error", err)
+		return nil, merrors.GRPCErrorToInnerError(err)
+	}
+	if !resp.GetResult() {
+		return nil, merrors.ErrSignatureConsistent
+	}
+	if expectedHash != nil && reqContext.request.Body != nil {
+		reqContext.request.Body = &s3PayloadVerifier{
+			ReadCloser:   reqContext.request.Body,
+			hash:         sha256.New(),
+			expectedHash: expectedHash,
+		}
+	}
+	return sdk.AccAddressFromHexUnsafe(resp.GetAccountId())
+}
+
+// s3PayloadVerifier verifies the request body against the signed payload hash, the mismatch is returned as
+// the error of the last read instead of io.EOF, so that the partial payload is never committed.
+type s3PayloadVerifier struct {
+	io.ReadCloser
+	hash         hash.Hash
+	expectedHash []byte
+}
+
+func (v *s3PayloadVerifier) Read(p []byte) (int, error) {
+	n, err := v.ReadCloser.Read(p)
+	v.hash.Write(p[:n])
+	if err == io.EOF && !bytes.Equal(v.hash.Sum(nil), v.expectedHash) {
+		return n, merrors.ErrPayloadHashMismatch
+	}
+	return n, err
+}
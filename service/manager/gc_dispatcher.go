@@ -0,0 +1,316 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/bnb-chain/greenfield-storage-provider/model"
+	"github.com/bnb-chain/greenfield-storage-provider/pkg/log"
+	"github.com/bnb-chain/greenfield-storage-provider/pkg/metrics"
+	servicetypes "github.com/bnb-chain/greenfield-storage-provider/service/types"
+	"github.com/bnb-chain/greenfield-storage-provider/store/sqldb"
+)
+
+const (
+	// defaultGCBlockSpanPerTask defines the number of blocks processed by one gc task
+	defaultGCBlockSpanPerTask = 100
+	// defaultGCBlockSpanBeforeLatestBlock defines the gc lag behind the latest block
+	defaultGCBlockSpanBeforeLatestBlock = 60
+	// defaultGCParallelTaskNumber defines the max number of the gc tasks which are processed by task nodes in parallel
+	defaultGCParallelTaskNumber = 4
+	// defaultGCTaskMaxRetry defines the retry number after which a failed gc task is reported as exhausted
+	defaultGCTaskMaxRetry = 5
+	// defaultGCTaskRetryBackoff defines the backoff before the first retry of a failed gc task, it doubles
+	// after every failed retry
+	defaultGCTaskRetryBackoff = 10 * time.Second
+	// defaultGCTaskMaxRetryBackoff defines the max backoff between the retries of a failed gc task, the
+	// exhausted gc tasks are retried at this interval
+	defaultGCTaskMaxRetryBackoff = 30 * time.Minute
+	// defaultGCTaskTimeout defines the timeout of a gc task
+	defaultGCTaskTimeout = 10 * time.Minute
+)
+
+// GCDispatcher is responsible for splitting the block ranges into gc tasks and dispatching them to task nodes,
+// the task nodes release the space occupied by the deleted objects in the piece-store.
+// The gc tasks are tracked in sp-db as JOB_TYPE_DELETE_OBJECT jobs, the failed tasks are dispatched again
+// with exponential backoff, and the unfinished tasks are recovered after restart. The tasks which run out of
+// the retry budget are reported and keep being retried at the max backoff, the gc checkpoint never moves
+// past an unfinished task, so the pieces of its deleted objects are not leaked.
+type GCDispatcher struct {
+	manager *Manager
+	// nextGCBlock is the first block which has not been assigned to any gc task
+	nextGCBlock uint64
+	// gcBlockProgress is the last block whose deleted objects have been fully processed
+	gcBlockProgress *sqldb.GCBlockProgress
+	tasks           []*sqldb.GCTask
+	// retryTime records the earliest time to dispatch the failed gc task again by job id
+	retryTime map[uint64]time.Time
+	stopCh    chan struct{}
+}
+
+// Start is a non-blocking function that starts a goroutine execution logic internally.
+func (d *GCDispatcher) Start() {
+	d.stopCh = make(chan struct{})
+	d.retryTime = make(map[uint64]time.Time)
+	d.loadGCTasks()
+	go d.startGC()
+	log.Infow("start gc dispatcher", "next_gc_block", d.nextGCBlock, "unfinished_task_number", len(d.tasks))
+}
+
+// Stop is responsible for stop dispatching gc tasks.
+func (d *GCDispatcher) Stop() {
+	close(d.stopCh)
+	log.Info("stop gc dispatcher")
+}
+
+// loadGCTasks loads the gc checkpoint and the unfinished gc tasks from sp-db,
+// gc starts from block 0 if there is no checkpoint.
+func (d *GCDispatcher) loadGCTasks() {
+	progress, err := d.manager.spDB.GetGCBlockProgress()
+	if err == nil {
+		d.gcBlockProgress = progress
+		d.nextGCBlock = progress.EndBlockNumber + 1
+		log.Infow("succeed to load gc progress", "start_block", progress.StartBlockNumber,
+			"end_block", progress.EndBlockNumber)
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Errorw("failed to load gc progress, gc starts from block 0", "error", err)
+	}
+
+	tasks, err := d.manager.spDB.ListUnfinishedGCTasks()
+	if err != nil {
+		log.Errorw("failed to load unfinished gc tasks", "error", err)
+		return
+	}
+	for _, task := range tasks {
+		if task.EndBlockNumber >= d.nextGCBlock {
+			d.nextGCBlock = task.EndBlockNumber + 1
+		}
+		if task.RetryCount > 0 {
+			d.retryTime[task.JobID] = time.Now().Add(gcTaskRetryBackoff(task.RetryCount))
+		}
+		d.tasks = append(d.tasks, task)
+	}
+	d.reportExhaustedGCTasks()
+}
+
+// gcTaskRetryBackoff returns the backoff before dispatching the gc task which has failed retryCount times.
+func gcTaskRetryBackoff(retryCount uint32) time.Duration {
+	backoff := defaultGCTaskRetryBackoff
+	for i := uint32(1); i < retryCount && backoff < defaultGCTaskMaxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > defaultGCTaskMaxRetryBackoff {
+		backoff = defaultGCTaskMaxRetryBackoff
+	}
+	return backoff
+}
+
+// reportExhaustedGCTasks reports the gc tasks which have run out of the retry budget, they block the gc
+// checkpoint until they succeed.
+func (d *GCDispatcher) reportExhaustedGCTasks() {
+	exhausted := 0
+	for _, task := range d.tasks {
+		if task.RetryCount < defaultGCTaskMaxRetry {
+			continue
+		}
+		exhausted++
+		log.Errorw("gc task has run out of retry budget and blocks gc progress", "job_id", task.JobID,
+			"start_block", task.StartBlockNumber, "end_block", task.EndBlockNumber, "retry_count", task.RetryCount,
+			"next_retry_time", d.retryTime[task.JobID])
+	}
+	metrics.GCExhaustedTaskGauge.WithLabelValues(model.ManagerService).Set(float64(exhausted))
+}
+
+// sleep waits for the duration, returns false if the gc dispatcher is stopped.
+func (d *GCDispatcher) sleep(duration time.Duration) bool {
+	select {
+	case <-d.stopCh:
+		return false
+	case <-time.After(duration):
+		return true
+	}
+}
+
+// startGC starts an execution logic internally.
+func (d *GCDispatcher) startGC() {
+	for {
+		select {
+		case <-d.stopCh:
+			return
+		default:
+		}
+		height, err := d.manager.chain.GetCurrentHeight(context.Background())
+		if err != nil {
+			log.Errorw("failed to query current chain height", "error", err)
+			d.sleep(1 * time.Second)
+			continue
+		}
+
+		d.createGCTasks(height)
+		tasks := d.dueGCTasks()
+		if len(tasks) == 0 {
+			log.Infow("skip gc and try again later", "next_gc_block", d.nextGCBlock, "latest_block", height,
+				"unfinished_task_number", len(d.tasks))
+			d.sleep(10 * time.Second)
+			continue
+		}
+		d.dispatchGCTasks(tasks)
+		d.updateGCProgress()
+	}
+}
+
+// createGCTasks splits the block range behind the latest block into gc tasks.
+// The exhausted tasks do not occupy the parallel task slots, so that gc keeps going behind them.
+func (d *GCDispatcher) createGCTasks(latestBlock uint64) {
+	for d.activeGCTaskNumber() < defaultGCParallelTaskNumber {
+		startBlock := d.nextGCBlock
+		endBlock := d.nextGCBlock + defaultGCBlockSpanPerTask - 1
+		if startBlock+defaultGCBlockSpanBeforeLatestBlock > latestBlock {
+			return
+		}
+		if endBlock+defaultGCBlockSpanBeforeLatestBlock > latestBlock {
+			endBlock = latestBlock - defaultGCBlockSpanBeforeLatestBlock
+		}
+		task, err := d.manager.spDB.CreateGCTask(startBlock, endBlock)
+		if err != nil {
+			log.Errorw("failed to create gc task", "start_block", startBlock, "end_block", endBlock, "error", err)
+			return
+		}
+		d.tasks = append(d.tasks, task)
+		d.nextGCBlock = endBlock + 1
+	}
+}
+
+// activeGCTaskNumber returns the number of the unfinished gc tasks which have not run out of the retry budget.
+func (d *GCDispatcher) activeGCTaskNumber() int {
+	number := 0
+	for _, task := range d.tasks {
+		if task.RetryCount < defaultGCTaskMaxRetry {
+			number++
+		}
+	}
+	return number
+}
+
+// dueGCTasks returns the unfinished gc tasks whose retry backoff has elapsed.
+func (d *GCDispatcher) dueGCTasks() []*sqldb.GCTask {
+	var tasks []*sqldb.GCTask
+	now := time.Now()
+	for _, task := range d.tasks {
+		if retryTime, ok := d.retryTime[task.JobID]; ok && now.Before(retryTime) {
+			continue
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks
+}
+
+// dispatchGCTasks dispatches the gc tasks to task nodes in parallel and waits for the outcome, the done tasks
+// are removed and the failed tasks are scheduled to retry after backoff.
+func (d *GCDispatcher) dispatchGCTasks(tasks []*sqldb.GCTask) {
+	var wg sync.WaitGroup
+	for _, task := range tasks {
+		wg.Add(1)
+		go func(task *sqldb.GCTask) {
+			defer wg.Done()
+			d.dispatchGCTask(task)
+		}(task)
+	}
+	wg.Wait()
+	for _, task := range tasks {
+		if task.JobState == servicetypes.JobState_JOB_STATE_GC_OBJECT_ERROR {
+			d.retryTime[task.JobID] = time.Now().Add(gcTaskRetryBackoff(task.RetryCount))
+		}
+	}
+
+	remaining := d.tasks[:0]
+	for _, task := range d.tasks {
+		if task.JobState == servicetypes.JobState_JOB_STATE_GC_OBJECT_DONE {
+			delete(d.retryTime, task.JobID)
+			continue
+		}
+		remaining = append(remaining, task)
+	}
+	d.tasks = remaining
+	d.reportExhaustedGCTasks()
+}
+
+// dispatchGCTask dispatches a gc task to task node and records the outcome to sp-db, the task is only done if
+// the whole block range is processed, otherwise the processed blocks are cut from the task.
+func (d *GCDispatcher) dispatchGCTask(task *sqldb.GCTask) {
+	task.JobState = servicetypes.JobState_JOB_STATE_GC_OBJECT_DOING
+	if err := d.manager.spDB.UpdateGCTask(task.JobID, task.JobState, task.RetryCount); err != nil {
+		log.Errorw("failed to update gc task state", "job_id", task.JobID, "error", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultGCTaskTimeout)
+	defer cancel()
+	resp, err := d.manager.taskNode.GCObject(ctx, task.JobID, task.StartBlockNumber, task.EndBlockNumber)
+	if err == nil && resp.GetEndBlockNumber() < task.StartBlockNumber {
+		err = fmt.Errorf("invalid gc end block %d", resp.GetEndBlockNumber())
+	}
+	switch {
+	case err != nil:
+		task.JobState = servicetypes.JobState_JOB_STATE_GC_OBJECT_ERROR
+		task.RetryCount++
+		log.Errorw("failed to gc block range, will retry later", "job_id", task.JobID,
+			"start_block", task.StartBlockNumber, "end_block", task.EndBlockNumber,
+			"retry_count", task.RetryCount, "error", err)
+	case resp.GetEndBlockNumber() < task.EndBlockNumber:
+		// the block range is partially processed, e.g. the block syncer lags behind or there are too many deleted
+		// objects, the rest of the block range is kept in the task and dispatched again
+		log.Infow("succeed to gc part of block range", "job_id", task.JobID,
+			"start_block", task.StartBlockNumber, "end_block", resp.GetEndBlockNumber(),
+			"deleted_object_number", resp.GetDeletedObjectNumber(), "failed_object_number", resp.GetFailedObjectNumber())
+		task.StartBlockNumber = resp.GetEndBlockNumber() + 1
+		if err = d.manager.spDB.UpdateGCTaskStartBlock(task.JobID, task.StartBlockNumber); err != nil {
+			log.Errorw("failed to update gc task start block", "job_id", task.JobID, "error", err)
+		}
+	default:
+		task.JobState = servicetypes.JobState_JOB_STATE_GC_OBJECT_DONE
+		log.Infow("succeed to gc block range", "job_id", task.JobID,
+			"start_block", task.StartBlockNumber, "end_block", task.EndBlockNumber,
+			"deleted_object_number", resp.GetDeletedObjectNumber(), "failed_object_number", resp.GetFailedObjectNumber())
+	}
+	if err = d.manager.spDB.UpdateGCTask(task.JobID, task.JobState, task.RetryCount); err != nil {
+		log.Errorw("failed to update gc task state", "job_id", task.JobID, "error", err)
+	}
+}
+
+// updateGCProgress advances the gc checkpoint to the block before the earliest unfinished gc task including
+// the exhausted ones, so the blocks which are fully processed will not be scanned again after restart.
+func (d *GCDispatcher) updateGCProgress() {
+	if d.nextGCBlock == 0 {
+		return
+	}
+	endBlock := d.nextGCBlock - 1
+	for _, task := range d.tasks {
+		if task.StartBlockNumber == 0 {
+			return
+		}
+		if task.StartBlockNumber-1 < endBlock {
+			endBlock = task.StartBlockNumber - 1
+		}
+	}
+
+	progress := &sqldb.GCBlockProgress{EndBlockNumber: endBlock}
+	if d.gcBlockProgress != nil {
+		if d.gcBlockProgress.EndBlockNumber >= endBlock {
+			return
+		}
+		progress.StartBlockNumber = d.gcBlockProgress.EndBlockNumber + 1
+	}
+	if err := d.manager.spDB.SetGCBlockProgress(progress); err != nil {
+		log.Errorw("failed to persist gc progress", "start_block", progress.StartBlockNumber,
+			"end_block", progress.EndBlockNumber, "error", err)
+		return
+	}
+	d.gcBlockProgress = progress
+	log.Infow("succeed to update gc progress", "start_block", progress.StartBlockNumber,
+		"end_block", progress.EndBlockNumber)
+}
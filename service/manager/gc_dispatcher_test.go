@@ -0,0 +1,127 @@
+package manager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+
+	tasknodetypes "github.com/bnb-chain/greenfield-storage-provider/service/tasknode/types"
+	servicetypes "github.com/bnb-chain/greenfield-storage-provider/service/types"
+	"github.com/bnb-chain/greenfield-storage-provider/store/sqldb"
+)
+
+// fakeGCTaskNode is the task node which processes the gc block ranges up to the mocked end blocks in order
+type fakeGCTaskNode struct {
+	taskNodeAPI
+	endBlocks []uint64
+}
+
+func (n *fakeGCTaskNode) GCObject(ctx context.Context, jobID, startBlock, endBlock uint64, opts ...grpc.CallOption) (
+	*tasknodetypes.GCObjectResponse, error) {
+	resp := &tasknodetypes.GCObjectResponse{EndBlockNumber: n.endBlocks[0]}
+	n.endBlocks = n.endBlocks[1:]
+	return resp, nil
+}
+
+func TestGCTaskRetryBackoff(t *testing.T) {
+	assert.Equal(t, defaultGCTaskRetryBackoff, gcTaskRetryBackoff(1))
+	assert.Equal(t, 4*defaultGCTaskRetryBackoff, gcTaskRetryBackoff(3))
+	assert.Equal(t, defaultGCTaskMaxRetryBackoff, gcTaskRetryBackoff(100))
+}
+
+func TestGCDispatcher_ExhaustedTaskBlocksProgress(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	spDB := sqldb.NewMockSPDB(ctrl)
+	spDB.EXPECT().SetGCBlockProgress(&sqldb.GCBlockProgress{StartBlockNumber: 51, EndBlockNumber: 99}).Return(nil)
+
+	exhausted := &sqldb.GCTask{JobID: 1, JobState: servicetypes.JobState_JOB_STATE_GC_OBJECT_ERROR,
+		StartBlockNumber: 100, EndBlockNumber: 199, RetryCount: defaultGCTaskMaxRetry}
+	d := &GCDispatcher{
+		manager:         &Manager{spDB: spDB},
+		nextGCBlock:     300,
+		gcBlockProgress: &sqldb.GCBlockProgress{EndBlockNumber: 50},
+		tasks:           []*sqldb.GCTask{exhausted},
+		retryTime:       map[uint64]time.Time{1: time.Now().Add(time.Hour)},
+	}
+	// the exhausted task does not occupy the parallel task slots and waits for its backoff
+	assert.Equal(t, 0, d.activeGCTaskNumber())
+	assert.Empty(t, d.dueGCTasks())
+	// the checkpoint stops right before the exhausted task
+	d.updateGCProgress()
+	assert.Equal(t, uint64(99), d.gcBlockProgress.EndBlockNumber)
+}
+
+func TestGCDispatcher_PartiallyProcessedTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	spDB := sqldb.NewMockSPDB(ctrl)
+	task := &sqldb.GCTask{JobID: 1, StartBlockNumber: 100, EndBlockNumber: 199}
+	d := &GCDispatcher{
+		// the block syncer lags behind, then the deleted objects exceed the list limit, then the range is finished
+		manager:         &Manager{spDB: spDB, taskNode: &fakeGCTaskNode{endBlocks: []uint64{149, 180, 199}}},
+		nextGCBlock:     200,
+		gcBlockProgress: &sqldb.GCBlockProgress{EndBlockNumber: 99},
+		tasks:           []*sqldb.GCTask{task},
+		retryTime:       make(map[uint64]time.Time),
+	}
+
+	doing := servicetypes.JobState_JOB_STATE_GC_OBJECT_DOING
+	gomock.InOrder(
+		spDB.EXPECT().UpdateGCTask(uint64(1), doing, uint32(0)).Return(nil),
+		spDB.EXPECT().UpdateGCTaskStartBlock(uint64(1), uint64(150)).Return(nil),
+		spDB.EXPECT().UpdateGCTask(uint64(1), doing, uint32(0)).Return(nil),
+		spDB.EXPECT().SetGCBlockProgress(&sqldb.GCBlockProgress{StartBlockNumber: 100, EndBlockNumber: 149}).Return(nil),
+	)
+	d.dispatchGCTasks(d.dueGCTasks())
+	d.updateGCProgress()
+	// the unprocessed tail is kept in the task and dispatched again without backoff
+	assert.Equal(t, []*sqldb.GCTask{task}, d.tasks)
+	assert.Equal(t, uint64(150), task.StartBlockNumber)
+	assert.Equal(t, []*sqldb.GCTask{task}, d.dueGCTasks())
+
+	gomock.InOrder(
+		spDB.EXPECT().UpdateGCTask(uint64(1), doing, uint32(0)).Return(nil),
+		spDB.EXPECT().UpdateGCTaskStartBlock(uint64(1), uint64(181)).Return(nil),
+		spDB.EXPECT().UpdateGCTask(uint64(1), doing, uint32(0)).Return(nil),
+		spDB.EXPECT().SetGCBlockProgress(&sqldb.GCBlockProgress{StartBlockNumber: 150, EndBlockNumber: 180}).Return(nil),
+	)
+	d.dispatchGCTasks(d.dueGCTasks())
+	d.updateGCProgress()
+
+	gomock.InOrder(
+		spDB.EXPECT().UpdateGCTask(uint64(1), doing, uint32(0)).Return(nil),
+		spDB.EXPECT().UpdateGCTask(uint64(1), servicetypes.JobState_JOB_STATE_GC_OBJECT_DONE, uint32(0)).Return(nil),
+		spDB.EXPECT().SetGCBlockProgress(&sqldb.GCBlockProgress{StartBlockNumber: 181, EndBlockNumber: 199}).Return(nil),
+	)
+	d.dispatchGCTasks(d.dueGCTasks())
+	d.updateGCProgress()
+	assert.Empty(t, d.tasks)
+}
+
+func TestGCDispatcher_UnprocessedTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	spDB := sqldb.NewMockSPDB(ctrl)
+	task := &sqldb.GCTask{JobID: 1, StartBlockNumber: 100, EndBlockNumber: 199}
+	d := &GCDispatcher{
+		manager:         &Manager{spDB: spDB, taskNode: &fakeGCTaskNode{endBlocks: []uint64{99}}},
+		nextGCBlock:     200,
+		gcBlockProgress: &sqldb.GCBlockProgress{EndBlockNumber: 99},
+		tasks:           []*sqldb.GCTask{task},
+		retryTime:       make(map[uint64]time.Time),
+	}
+	gomock.InOrder(
+		spDB.EXPECT().UpdateGCTask(uint64(1), servicetypes.JobState_JOB_STATE_GC_OBJECT_DOING, uint32(0)).Return(nil),
+		spDB.EXPECT().UpdateGCTask(uint64(1), servicetypes.JobState_JOB_STATE_GC_OBJECT_ERROR, uint32(1)).Return(nil),
+	)
+	// the task whose end block is before its start block is retried after backoff, and the checkpoint is kept
+	d.dispatchGCTasks(d.dueGCTasks())
+	d.updateGCProgress()
+	assert.Equal(t, uint64(100), task.StartBlockNumber)
+	assert.Empty(t, d.dueGCTasks())
+}
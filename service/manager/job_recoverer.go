@@ -0,0 +1,218 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	storagetypes "github.com/bnb-chain/greenfield/x/storage/types"
+
+	merrors "github.com/bnb-chain/greenfield-storage-provider/model/errors"
+	"github.com/bnb-chain/greenfield-storage-provider/pkg/log"
+	servicetypes "github.com/bnb-chain/greenfield-storage-provider/service/types"
+	"github.com/bnb-chain/greenfield-storage-provider/store/sqldb"
+	"github.com/bnb-chain/greenfield-storage-provider/util"
+)
+
+const (
+	// defaultJobRecoveryInterval defines the interval between two recovery loops
+	defaultJobRecoveryInterval = 60 * time.Second
+	// defaultJobStuckTimeout defines the job in doing state which is not modified for the duration is stuck,
+	// e.g. the service crashed in the middle of the job
+	defaultJobStuckTimeout = 30 * time.Minute
+	// defaultJobMaxRetry defines the max number of times a job is recovered
+	defaultJobMaxRetry = 3
+	// defaultJobRecoveryNumberPerLoop defines the max number of the jobs recovered in one loop
+	defaultJobRecoveryNumberPerLoop = 100
+	// defaultListenSealTimeoutHeight defines the block number waiting for the object to be sealed
+	defaultListenSealTimeoutHeight = 10
+)
+
+var (
+	// replicateFailedJobStates defines the failed states which are recovered by replicating object again
+	replicateFailedJobStates = []servicetypes.JobState{
+		servicetypes.JobState_JOB_STATE_REPLICATE_OBJECT_ERROR,
+	}
+	// sealFailedJobStates defines the failed states which are recovered by sealing object again
+	sealFailedJobStates = []servicetypes.JobState{
+		servicetypes.JobState_JOB_STATE_SIGN_OBJECT_ERROR,
+		servicetypes.JobState_JOB_STATE_SEAL_OBJECT_ERROR,
+	}
+	// stuckJobStates defines the doing states which are recovered if the job is stuck
+	stuckJobStates = []servicetypes.JobState{
+		servicetypes.JobState_JOB_STATE_ALLOC_SECONDARY_DOING,
+		servicetypes.JobState_JOB_STATE_REPLICATE_OBJECT_DOING,
+		servicetypes.JobState_JOB_STATE_SIGN_OBJECT_DOING,
+		servicetypes.JobState_JOB_STATE_SEAL_OBJECT_DOING,
+	}
+
+	errMissingSecondarySpSignatures = errors.New("missing secondary sp signatures")
+)
+
+// JobRecoverer is responsible for recovering the upload jobs which are failed in replicating or sealing,
+// or stuck in doing state after crash. The jobs are re-driven through TaskNode.ReplicateObject or
+// SignerClient.SealObjectOnChain, and every job is recovered at most defaultJobMaxRetry times.
+type JobRecoverer struct {
+	manager *Manager
+	stopCh  chan struct{}
+}
+
+// Start is a non-blocking function that starts a goroutine execution logic internally.
+func (r *JobRecoverer) Start() {
+	r.stopCh = make(chan struct{})
+	go r.startRecover()
+	log.Info("start job recoverer")
+}
+
+// Stop is responsible for stop recovering jobs.
+func (r *JobRecoverer) Stop() {
+	close(r.stopCh)
+	log.Info("stop job recoverer")
+}
+
+// startRecover recovers the jobs periodically.
+func (r *JobRecoverer) startRecover() {
+	ticker := time.NewTicker(defaultJobRecoveryInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			r.recoverJobs()
+		case <-r.stopCh:
+			return
+		}
+	}
+}
+
+// recoverJobs lists the failed jobs and the stuck jobs from sp-db, and recovers them one by one.
+func (r *JobRecoverer) recoverJobs() {
+	now := time.Now()
+	failedJobs, err := r.manager.spDB.ListUploadJobsByState(append(replicateFailedJobStates, sealFailedJobStates...),
+		now, defaultJobMaxRetry, defaultJobRecoveryNumberPerLoop)
+	if err != nil {
+		log.Errorw("failed to list failed jobs", "error", err)
+		return
+	}
+	stuckJobs, err := r.manager.spDB.ListUploadJobsByState(stuckJobStates,
+		now.Add(-defaultJobStuckTimeout), defaultJobMaxRetry, defaultJobRecoveryNumberPerLoop)
+	if err != nil {
+		log.Errorw("failed to list stuck jobs", "error", err)
+		return
+	}
+	for _, job := range append(failedJobs, stuckJobs...) {
+		select {
+		case <-r.stopCh:
+			return
+		default:
+		}
+		r.recoverJob(job)
+	}
+}
+
+// recoverJob recovers a job according to its state, the retry count is increased before recovering,
+// so a job which crashes the recovery will not be recovered endlessly.
+func (r *JobRecoverer) recoverJob(job *sqldb.UploadJobMeta) {
+	var (
+		ctx        = log.WithValue(context.Background(), "object_id", util.Uint64ToString(job.ObjectID))
+		jobID      = job.JobContext.GetJobId()
+		state      = job.JobContext.GetJobState()
+		retryCount = job.JobContext.GetRetryCount() + 1
+	)
+	if err := r.manager.spDB.UpdateJobRetryCount(jobID, retryCount); err != nil {
+		log.CtxErrorw(ctx, "failed to update job retry count", "job_id", jobID, "error", err)
+		return
+	}
+
+	objectInfo, err := r.manager.chain.QueryObjectInfoByID(ctx, util.Uint64ToString(job.ObjectID))
+	if errors.Is(err, merrors.ErrNoSuchObject) {
+		// the object has been deleted or rejected, no need to recover
+		if err = r.manager.spDB.UpdateJobRetryCount(jobID, defaultJobMaxRetry); err != nil {
+			log.CtxErrorw(ctx, "failed to update job retry count", "job_id", jobID, "error", err)
+		}
+		log.CtxInfow(ctx, "skip recovering job whose object is not found on chain", "job_id", jobID, "job_state", state)
+		return
+	}
+	if err != nil {
+		log.CtxErrorw(ctx, "failed to query object info from chain", "job_id", jobID, "error", err)
+		return
+	}
+	if objectInfo.GetObjectStatus() == storagetypes.OBJECT_STATUS_SEALED {
+		if err = r.manager.spDB.UpdateJobState(job.ObjectID, servicetypes.JobState_JOB_STATE_SEAL_OBJECT_DONE); err != nil {
+			log.CtxErrorw(ctx, "failed to update job state", "job_id", jobID, "error", err)
+			return
+		}
+		log.CtxInfow(ctx, "succeed to recover job whose object has been sealed", "job_id", jobID, "job_state", state)
+		return
+	}
+
+	log.CtxInfow(ctx, "start to recover job", "job_id", jobID, "job_state", state, "retry_count", retryCount)
+	switch state {
+	case servicetypes.JobState_JOB_STATE_SIGN_OBJECT_ERROR,
+		servicetypes.JobState_JOB_STATE_SEAL_OBJECT_ERROR,
+		servicetypes.JobState_JOB_STATE_SIGN_OBJECT_DOING,
+		servicetypes.JobState_JOB_STATE_SEAL_OBJECT_DOING:
+		err = r.sealObject(ctx, objectInfo)
+		if errors.Is(err, errMissingSecondarySpSignatures) {
+			err = r.replicateObject(ctx, objectInfo)
+		}
+	default:
+		err = r.replicateObject(ctx, objectInfo)
+	}
+	if err != nil {
+		log.CtxErrorw(ctx, "failed to recover job", "job_id", jobID, "job_state", state,
+			"retry_count", retryCount, "error", err)
+		return
+	}
+	log.CtxInfow(ctx, "succeed to recover job", "job_id", jobID, "job_state", state, "retry_count", retryCount)
+}
+
+// replicateObject replicates the object to secondary sps by task node, the object is sealed by task node
+// after replicating, and the job state is updated by task node.
+func (r *JobRecoverer) replicateObject(ctx context.Context, objectInfo *storagetypes.ObjectInfo) error {
+	return r.manager.taskNode.ReplicateObject(ctx, objectInfo)
+}
+
+// sealObject seals the object with the secondary sp signatures persisted in sp-db, and waits for the object
+// to be sealed on chain.
+func (r *JobRecoverer) sealObject(ctx context.Context, objectInfo *storagetypes.ObjectInfo) error {
+	objectID := objectInfo.Id.Uint64()
+	sdbObjectInfo, err := r.manager.spDB.GetObjectInfo(objectID)
+	if err != nil {
+		return err
+	}
+	signatures, err := r.manager.spDB.GetSecondarySpSignatures(objectID)
+	if err != nil {
+		return err
+	}
+	addresses := sdbObjectInfo.GetSecondarySpAddresses()
+	if len(signatures) == 0 || len(signatures) != len(addresses) {
+		return errMissingSecondarySpSignatures
+	}
+
+	r.updateJobState(ctx, objectID, servicetypes.JobState_JOB_STATE_SIGN_OBJECT_DOING)
+	if _, err = r.manager.signer.SealObjectOnChain(ctx, &storagetypes.MsgSealObject{
+		Operator:              r.manager.config.SpOperatorAddress,
+		BucketName:            objectInfo.GetBucketName(),
+		ObjectName:            objectInfo.GetObjectName(),
+		SecondarySpAddresses:  addresses,
+		SecondarySpSignatures: signatures,
+	}); err != nil {
+		r.updateJobState(ctx, objectID, servicetypes.JobState_JOB_STATE_SIGN_OBJECT_ERROR)
+		return err
+	}
+	r.updateJobState(ctx, objectID, servicetypes.JobState_JOB_STATE_SEAL_OBJECT_DOING)
+	if err = r.manager.chain.ListenObjectSeal(ctx, objectInfo.GetBucketName(), objectInfo.GetObjectName(),
+		defaultListenSealTimeoutHeight); err != nil {
+		r.updateJobState(ctx, objectID, servicetypes.JobState_JOB_STATE_SEAL_OBJECT_ERROR)
+		return err
+	}
+	r.updateJobState(ctx, objectID, servicetypes.JobState_JOB_STATE_SEAL_OBJECT_DONE)
+	return nil
+}
+
+// updateJobState updates the job state by object id, the error is only logged.
+func (r *JobRecoverer) updateJobState(ctx context.Context, objectID uint64, state servicetypes.JobState) {
+	if err := r.manager.spDB.UpdateJobState(objectID, state); err != nil {
+		log.CtxErrorw(ctx, "failed to update job state", "job_state", state, "error", err)
+	}
+}
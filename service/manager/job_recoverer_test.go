@@ -0,0 +1,257 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	sdkmath "cosmossdk.io/math"
+	storagetypes "github.com/bnb-chain/greenfield/x/storage/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+
+	merrors "github.com/bnb-chain/greenfield-storage-provider/model/errors"
+	servicetypes "github.com/bnb-chain/greenfield-storage-provider/service/types"
+	"github.com/bnb-chain/greenfield-storage-provider/store/sqldb"
+)
+
+// fakeRecoveryChain is the chain which returns the mocked object and records the listened objects
+type fakeRecoveryChain struct {
+	chainAPI
+	object   *storagetypes.ObjectInfo
+	err      error
+	listened []string
+}
+
+func (c *fakeRecoveryChain) QueryObjectInfoByID(ctx context.Context, objectID string) (*storagetypes.ObjectInfo, error) {
+	return c.object, c.err
+}
+
+func (c *fakeRecoveryChain) ListenObjectSeal(ctx context.Context, bucket, object string, timeOutHeight int) error {
+	c.listened = append(c.listened, object)
+	return nil
+}
+
+// fakeRecoverySigner is the signer which records the seal messages
+type fakeRecoverySigner struct {
+	signerAPI
+	err  error
+	msgs []*storagetypes.MsgSealObject
+}
+
+func (s *fakeRecoverySigner) SealObjectOnChain(ctx context.Context, sealObject *storagetypes.MsgSealObject,
+	opts ...grpc.CallOption) ([]byte, error) {
+	s.msgs = append(s.msgs, sealObject)
+	return nil, s.err
+}
+
+// fakeRecoveryTaskNode is the task node which records the replicated objects
+type fakeRecoveryTaskNode struct {
+	taskNodeAPI
+	replicated []uint64
+}
+
+func (n *fakeRecoveryTaskNode) ReplicateObject(ctx context.Context, object *storagetypes.ObjectInfo,
+	opts ...grpc.CallOption) error {
+	n.replicated = append(n.replicated, object.Id.Uint64())
+	return nil
+}
+
+func newRecoveryJob(state servicetypes.JobState, retryCount uint32) *sqldb.UploadJobMeta {
+	return &sqldb.UploadJobMeta{
+		ObjectID:   1,
+		JobContext: &servicetypes.JobContext{JobId: 10, JobState: state, RetryCount: retryCount},
+	}
+}
+
+func newRecoveryObject(status storagetypes.ObjectStatus) *storagetypes.ObjectInfo {
+	return &storagetypes.ObjectInfo{Id: sdkmath.NewUint(1), BucketName: "bucket", ObjectName: "object", ObjectStatus: status}
+}
+
+func setupJobRecoverer(spDB sqldb.SPDB, chain *fakeRecoveryChain) (*JobRecoverer, *fakeRecoverySigner,
+	*fakeRecoveryTaskNode) {
+	signer := &fakeRecoverySigner{}
+	taskNode := &fakeRecoveryTaskNode{}
+	return &JobRecoverer{manager: &Manager{
+		config:   &ManagerConfig{SpOperatorAddress: "operator"},
+		spDB:     spDB,
+		chain:    chain,
+		signer:   signer,
+		taskNode: taskNode,
+	}}, signer, taskNode
+}
+
+func TestJobRecoverer_RecoverJobsListsFailedAndStuckJobs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	spDB := sqldb.NewMockSPDB(ctrl)
+	var (
+		listedStates    [][]servicetypes.JobState
+		modifiedBefores []time.Time
+	)
+	spDB.EXPECT().ListUploadJobsByState(gomock.Any(), gomock.Any(), uint32(defaultJobMaxRetry),
+		defaultJobRecoveryNumberPerLoop).DoAndReturn(
+		func(states []servicetypes.JobState, modifiedBefore time.Time, maxRetry uint32, limit int) (
+			[]*sqldb.UploadJobMeta, error) {
+			listedStates = append(listedStates, states)
+			modifiedBefores = append(modifiedBefores, modifiedBefore)
+			return nil, nil
+		}).Times(2)
+	r, _, _ := setupJobRecoverer(spDB, &fakeRecoveryChain{})
+	r.recoverJobs()
+
+	assert.Equal(t, 2, len(modifiedBefores))
+	assert.Equal(t, append(replicateFailedJobStates, sealFailedJobStates...), listedStates[0])
+	assert.Equal(t, stuckJobStates, listedStates[1])
+	// the failed jobs are recovered at once, and the doing jobs are recovered only if they are stuck
+	assert.WithinDuration(t, time.Now(), modifiedBefores[0], time.Minute)
+	assert.Equal(t, defaultJobStuckTimeout, modifiedBefores[0].Sub(modifiedBefores[1]))
+}
+
+func TestJobRecoverer_RecoverJobsStopsOnListError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	spDB := sqldb.NewMockSPDB(ctrl)
+	spDB.EXPECT().ListUploadJobsByState(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return([]*sqldb.UploadJobMeta{newRecoveryJob(servicetypes.JobState_JOB_STATE_REPLICATE_OBJECT_ERROR, 0)}, nil)
+	spDB.EXPECT().ListUploadJobsByState(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("mock error"))
+	r, _, taskNode := setupJobRecoverer(spDB, &fakeRecoveryChain{})
+	r.recoverJobs()
+	assert.Equal(t, 0, len(taskNode.replicated))
+}
+
+func TestJobRecoverer_ReplicateObject(t *testing.T) {
+	for _, state := range []servicetypes.JobState{
+		servicetypes.JobState_JOB_STATE_REPLICATE_OBJECT_ERROR,
+		servicetypes.JobState_JOB_STATE_ALLOC_SECONDARY_DOING,
+		servicetypes.JobState_JOB_STATE_REPLICATE_OBJECT_DOING,
+	} {
+		t.Run(state.String(), func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			spDB := sqldb.NewMockSPDB(ctrl)
+			spDB.EXPECT().UpdateJobRetryCount(uint64(10), uint32(defaultJobMaxRetry)).Return(nil)
+			r, signer, taskNode := setupJobRecoverer(spDB,
+				&fakeRecoveryChain{object: newRecoveryObject(storagetypes.OBJECT_STATUS_CREATED)})
+			r.recoverJob(newRecoveryJob(state, defaultJobMaxRetry-1))
+			assert.Equal(t, []uint64{1}, taskNode.replicated)
+			assert.Equal(t, 0, len(signer.msgs))
+		})
+	}
+}
+
+func TestJobRecoverer_SealObjectWithPersistedSignatures(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	spDB := sqldb.NewMockSPDB(ctrl)
+	addresses := []string{"sp1", "sp2"}
+	signatures := [][]byte{[]byte("signature1"), []byte("signature2")}
+	gomock.InOrder(
+		spDB.EXPECT().UpdateJobRetryCount(uint64(10), uint32(1)).Return(nil),
+		spDB.EXPECT().GetObjectInfo(uint64(1)).Return(&storagetypes.ObjectInfo{SecondarySpAddresses: addresses}, nil),
+		spDB.EXPECT().GetSecondarySpSignatures(uint64(1)).Return(signatures, nil),
+		spDB.EXPECT().UpdateJobState(uint64(1), servicetypes.JobState_JOB_STATE_SIGN_OBJECT_DOING).Return(nil),
+		spDB.EXPECT().UpdateJobState(uint64(1), servicetypes.JobState_JOB_STATE_SEAL_OBJECT_DOING).Return(nil),
+		spDB.EXPECT().UpdateJobState(uint64(1), servicetypes.JobState_JOB_STATE_SEAL_OBJECT_DONE).Return(nil),
+	)
+	chain := &fakeRecoveryChain{object: newRecoveryObject(storagetypes.OBJECT_STATUS_CREATED)}
+	r, signer, taskNode := setupJobRecoverer(spDB, chain)
+	r.recoverJob(newRecoveryJob(servicetypes.JobState_JOB_STATE_SEAL_OBJECT_ERROR, 0))
+
+	assert.Equal(t, 1, len(signer.msgs))
+	assert.Equal(t, "operator", signer.msgs[0].GetOperator())
+	assert.Equal(t, "bucket", signer.msgs[0].GetBucketName())
+	assert.Equal(t, "object", signer.msgs[0].GetObjectName())
+	assert.Equal(t, addresses, signer.msgs[0].GetSecondarySpAddresses())
+	assert.Equal(t, signatures, signer.msgs[0].GetSecondarySpSignatures())
+	assert.Equal(t, []string{"object"}, chain.listened)
+	assert.Equal(t, 0, len(taskNode.replicated))
+}
+
+func TestJobRecoverer_SealObjectFailed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	spDB := sqldb.NewMockSPDB(ctrl)
+	gomock.InOrder(
+		spDB.EXPECT().UpdateJobRetryCount(uint64(10), uint32(2)).Return(nil),
+		spDB.EXPECT().GetObjectInfo(uint64(1)).Return(&storagetypes.ObjectInfo{SecondarySpAddresses: []string{"sp1"}}, nil),
+		spDB.EXPECT().GetSecondarySpSignatures(uint64(1)).Return([][]byte{[]byte("signature1")}, nil),
+		spDB.EXPECT().UpdateJobState(uint64(1), servicetypes.JobState_JOB_STATE_SIGN_OBJECT_DOING).Return(nil),
+		spDB.EXPECT().UpdateJobState(uint64(1), servicetypes.JobState_JOB_STATE_SIGN_OBJECT_ERROR).Return(nil),
+	)
+	chain := &fakeRecoveryChain{object: newRecoveryObject(storagetypes.OBJECT_STATUS_CREATED)}
+	r, signer, taskNode := setupJobRecoverer(spDB, chain)
+	signer.err = errors.New("mock error")
+	r.recoverJob(newRecoveryJob(servicetypes.JobState_JOB_STATE_SIGN_OBJECT_DOING, 1))
+
+	assert.Equal(t, 1, len(signer.msgs))
+	assert.Equal(t, 0, len(chain.listened))
+	assert.Equal(t, 0, len(taskNode.replicated))
+}
+
+func TestJobRecoverer_SignObjectWithoutSignaturesIsReplicated(t *testing.T) {
+	cases := []struct {
+		name       string
+		addresses  []string
+		signatures [][]byte
+	}{
+		{name: "no signatures", addresses: []string{"sp1"}},
+		{name: "mismatched signatures", addresses: []string{"sp1", "sp2"}, signatures: [][]byte{[]byte("signature1")}},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			spDB := sqldb.NewMockSPDB(ctrl)
+			spDB.EXPECT().UpdateJobRetryCount(uint64(10), uint32(1)).Return(nil)
+			spDB.EXPECT().GetObjectInfo(uint64(1)).Return(&storagetypes.ObjectInfo{SecondarySpAddresses: tt.addresses}, nil)
+			spDB.EXPECT().GetSecondarySpSignatures(uint64(1)).Return(tt.signatures, nil)
+			r, signer, taskNode := setupJobRecoverer(spDB,
+				&fakeRecoveryChain{object: newRecoveryObject(storagetypes.OBJECT_STATUS_CREATED)})
+			r.recoverJob(newRecoveryJob(servicetypes.JobState_JOB_STATE_SIGN_OBJECT_ERROR, 0))
+			assert.Equal(t, 0, len(signer.msgs))
+			assert.Equal(t, []uint64{1}, taskNode.replicated)
+		})
+	}
+}
+
+func TestJobRecoverer_SkipObjectNotOnChain(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	spDB := sqldb.NewMockSPDB(ctrl)
+	gomock.InOrder(
+		spDB.EXPECT().UpdateJobRetryCount(uint64(10), uint32(1)).Return(nil),
+		// the retry budget is used up, so the job is no longer listed
+		spDB.EXPECT().UpdateJobRetryCount(uint64(10), uint32(defaultJobMaxRetry)).Return(nil),
+	)
+	r, _, taskNode := setupJobRecoverer(spDB, &fakeRecoveryChain{err: merrors.ErrNoSuchObject})
+	r.recoverJob(newRecoveryJob(servicetypes.JobState_JOB_STATE_REPLICATE_OBJECT_ERROR, 0))
+	assert.Equal(t, 0, len(taskNode.replicated))
+}
+
+func TestJobRecoverer_SealedObjectIsDone(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	spDB := sqldb.NewMockSPDB(ctrl)
+	spDB.EXPECT().UpdateJobRetryCount(uint64(10), uint32(1)).Return(nil)
+	spDB.EXPECT().UpdateJobState(uint64(1), servicetypes.JobState_JOB_STATE_SEAL_OBJECT_DONE).Return(nil)
+	r, signer, taskNode := setupJobRecoverer(spDB,
+		&fakeRecoveryChain{object: newRecoveryObject(storagetypes.OBJECT_STATUS_SEALED)})
+	r.recoverJob(newRecoveryJob(servicetypes.JobState_JOB_STATE_SEAL_OBJECT_DOING, 0))
+	assert.Equal(t, 0, len(signer.msgs))
+	assert.Equal(t, 0, len(taskNode.replicated))
+}
+
+func TestJobRecoverer_SkipJobIfRetryCountNotUpdated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	spDB := sqldb.NewMockSPDB(ctrl)
+	spDB.EXPECT().UpdateJobRetryCount(uint64(10), uint32(1)).Return(errors.New("mock error"))
+	chain := &fakeRecoveryChain{object: newRecoveryObject(storagetypes.OBJECT_STATUS_CREATED)}
+	r, _, taskNode := setupJobRecoverer(spDB, chain)
+	r.recoverJob(newRecoveryJob(servicetypes.JobState_JOB_STATE_REPLICATE_OBJECT_ERROR, 0))
+	assert.Equal(t, 0, len(taskNode.replicated))
+}
@@ -6,17 +6,64 @@ import (
 	"sync/atomic"
 	"time"
 
+	sptypes "github.com/bnb-chain/greenfield/x/sp/types"
+	storagetypes "github.com/bnb-chain/greenfield/x/storage/types"
+	"google.golang.org/grpc"
+
 	"github.com/bnb-chain/greenfield-storage-provider/model"
 	gnfd "github.com/bnb-chain/greenfield-storage-provider/pkg/greenfield"
 	"github.com/bnb-chain/greenfield-storage-provider/pkg/lifecycle"
 	"github.com/bnb-chain/greenfield-storage-provider/pkg/log"
 	metadataclient "github.com/bnb-chain/greenfield-storage-provider/service/metadata/client"
+	metatypes "github.com/bnb-chain/greenfield-storage-provider/service/metadata/types"
+	signerclient "github.com/bnb-chain/greenfield-storage-provider/service/signer/client"
+	tasknodeclient "github.com/bnb-chain/greenfield-storage-provider/service/tasknode/client"
+	tasknodetypes "github.com/bnb-chain/greenfield-storage-provider/service/tasknode/types"
 	psclient "github.com/bnb-chain/greenfield-storage-provider/store/piecestore/client"
 	"github.com/bnb-chain/greenfield-storage-provider/store/sqldb"
 )
 
 var _ lifecycle.Service = &Manager{}
 
+var (
+	_ taskNodeAPI = &tasknodeclient.TaskNodeClient{}
+	_ metadataAPI = &metadataclient.MetadataClient{}
+	_ chainAPI    = &gnfd.Greenfield{}
+	_ signerAPI   = &signerclient.SignerClient{}
+)
+
+// chainAPI is the greenfield chain used by manager, it is implemented by gnfd.Greenfield
+type chainAPI interface {
+	GetCurrentHeight(ctx context.Context) (uint64, error)
+	QuerySPInfo(ctx context.Context) ([]*sptypes.StorageProvider, error)
+	QueryStorageParams(ctx context.Context) (*storagetypes.Params, error)
+	QueryObjectInfoByID(ctx context.Context, objectID string) (*storagetypes.ObjectInfo, error)
+	ListenObjectSeal(ctx context.Context, bucket, object string, timeOutHeight int) error
+}
+
+// signerAPI is the signer service used by manager, it is implemented by signerclient.SignerClient
+type signerAPI interface {
+	SealObjectOnChain(ctx context.Context, sealObject *storagetypes.MsgSealObject, opts ...grpc.CallOption) ([]byte, error)
+	Close() error
+}
+
+// metadataAPI is the metadata service used by manager, it is implemented by metadataclient.MetadataClient
+type metadataAPI interface {
+	GetObjectByID(ctx context.Context, in *metatypes.GetObjectByIDRequest, opts ...grpc.CallOption) (
+		*metatypes.GetObjectByIDResponse, error)
+	Close() error
+}
+
+// taskNodeAPI is the task node service used by manager, it is implemented by tasknodeclient.TaskNodeClient
+type taskNodeAPI interface {
+	ReplicateObject(ctx context.Context, object *storagetypes.ObjectInfo, opts ...grpc.CallOption) error
+	GCObject(ctx context.Context, jobID, startBlock, endBlock uint64, opts ...grpc.CallOption) (
+		*tasknodetypes.GCObjectResponse, error)
+	RepairObjectPiece(ctx context.Context, objectID uint64, redundancyIdx int32, opts ...grpc.CallOption) (
+		*tasknodetypes.RepairObjectPieceResponse, error)
+	Close() error
+}
+
 var (
 	// RefreshSPInfoAndStorageParamsTimer define the period of refresh sp info and storage params
 	RefreshSPInfoAndStorageParamsTimer = 5 * 60
@@ -24,16 +71,24 @@ var (
 
 // Manager module is responsible for implementing internal management functions.
 // Currently, it supports periodic update of sp info list and storage params information in sp-db.
-// TODO: support gc and configuration management, etc.
+// It also dispatches the gc tasks to task nodes, reconciles the orphan pieces in piece store,
+// scrubs the locally stored pieces, migrates the cold pieces out of the hot tier and recovers the failed or stuck upload jobs.
+// TODO: support configuration management, etc.
 type Manager struct {
-	config     *ManagerConfig
-	running    atomic.Value
-	stopCh     chan struct{}
-	chain      *gnfd.Greenfield
-	spDB       sqldb.SPDB
-	metadata   *metadataclient.MetadataClient
-	pieceStore *psclient.StoreClient
-	gcWorker   *GCWorker
+	config          *ManagerConfig
+	running         atomic.Value
+	stopCh          chan struct{}
+	chain           chainAPI
+	spDB            sqldb.SPDB
+	metadata        metadataAPI
+	pieceStore      *psclient.StoreClient
+	taskNode        taskNodeAPI
+	signer          signerAPI
+	gcDispatcher    *GCDispatcher
+	pieceReconciler *OrphanPieceReconciler
+	pieceScrubber   *PieceScrubber
+	tierMigrator    *PieceTierMigrator
+	jobRecoverer    *JobRecoverer
 }
 
 // NewManagerService returns an instance of manager
@@ -44,9 +99,22 @@ func NewManagerService(cfg *ManagerConfig) (*Manager, error) {
 	)
 
 	manager = &Manager{
-		config:   cfg,
-		stopCh:   make(chan struct{}),
-		gcWorker: &GCWorker{},
+		config:       cfg,
+		stopCh:       make(chan struct{}),
+		gcDispatcher: &GCDispatcher{},
+		jobRecoverer: &JobRecoverer{},
+	}
+	manager.pieceReconciler = &OrphanPieceReconciler{manager: manager, config: cfg.ReconcilerConfig}
+	if manager.pieceReconciler.config == nil {
+		manager.pieceReconciler.config = DefaultOrphanPieceReconcilerConfig
+	}
+	manager.pieceScrubber = &PieceScrubber{manager: manager, config: cfg.ScrubberConfig}
+	if manager.pieceScrubber.config == nil {
+		manager.pieceScrubber.config = DefaultPieceScrubberConfig
+	}
+	manager.tierMigrator = &PieceTierMigrator{manager: manager, config: cfg.TierMigratorConfig}
+	if manager.tierMigrator.config == nil {
+		manager.tierMigrator.config = DefaultPieceTierMigratorConfig
 	}
 	if manager.chain, err = gnfd.NewGreenfield(cfg.ChainConfig); err != nil {
 		log.Errorw("failed to create chain client", "error", err)
@@ -64,6 +132,14 @@ func NewManagerService(cfg *ManagerConfig) (*Manager, error) {
 		log.Errorw("failed to create piece store client", "error", err)
 		return nil, err
 	}
+	if manager.taskNode, err = tasknodeclient.NewTaskNodeClient(cfg.TaskNodeGrpcAddress); err != nil {
+		log.Errorw("failed to create task node client", "error", err)
+		return nil, err
+	}
+	if manager.signer, err = signerclient.NewSignerClient(cfg.SignerGrpcAddress); err != nil {
+		log.Errorw("failed to create signer client", "error", err)
+		return nil, err
+	}
 
 	return manager, nil
 }
@@ -79,8 +155,13 @@ func (m *Manager) Start(ctx context.Context) error {
 		return errors.New("manager has already started")
 	}
 
-	m.gcWorker.manager = m
-	m.gcWorker.Start()
+	m.gcDispatcher.manager = m
+	m.gcDispatcher.Start()
+	m.pieceReconciler.Start()
+	m.pieceScrubber.Start()
+	m.tierMigrator.Start()
+	m.jobRecoverer.manager = m
+	m.jobRecoverer.Start()
 
 	go m.eventLoop()
 	return nil
@@ -137,7 +218,14 @@ func (m *Manager) Stop(ctx context.Context) error {
 	if m.running.Swap(false) == false {
 		return errors.New("manager has already stop")
 	}
-	m.gcWorker.Stop()
+	m.gcDispatcher.Stop()
+	m.pieceReconciler.Stop()
+	m.pieceScrubber.Stop()
+	m.tierMigrator.Stop()
+	m.jobRecoverer.Stop()
 	close(m.stopCh)
+	m.metadata.Close()
+	m.taskNode.Close()
+	m.signer.Close()
 	return nil
 }
@@ -11,6 +11,78 @@ type ManagerConfig struct {
 	SpOperatorAddress   string
 	ChainConfig         *gnfd.GreenfieldChainConfig
 	SpDBConfig          *config.SQLDBConfig
-	MetadataGrpcAddress string
 	PieceStoreConfig    *storage.PieceStoreConfig
+	MetadataGrpcAddress string
+	TaskNodeGrpcAddress string
+	SignerGrpcAddress   string
+	ReconcilerConfig    *OrphanPieceReconcilerConfig
+	ScrubberConfig      *PieceScrubberConfig
+	TierMigratorConfig  *PieceTierMigratorConfig
+}
+
+// OrphanPieceReconcilerConfig defines the orphan piece reconciler config
+type OrphanPieceReconcilerConfig struct {
+	// Enabled defines whether to start the orphan piece reconciler
+	Enabled bool
+	// DryRun defines whether only to report the orphan pieces without deleting them
+	DryRun bool
+	// IntervalSeconds defines the interval between two scans of the piece store
+	IntervalSeconds int64
+	// GracePeriodSeconds defines the pieces modified in the grace period are skipped,
+	// which avoids treating the pieces of the uploading objects as orphans
+	GracePeriodSeconds int64
+}
+
+var DefaultOrphanPieceReconcilerConfig = &OrphanPieceReconcilerConfig{
+	Enabled:            false,
+	DryRun:             true,
+	IntervalSeconds:    24 * 60 * 60,
+	GracePeriodSeconds: 24 * 60 * 60,
+}
+
+// PieceScrubberConfig defines the piece scrubber config
+type PieceScrubberConfig struct {
+	// Enabled defines whether to start the piece scrubber
+	Enabled bool
+	// AutoRepair defines whether to trigger the task node to repair the corrupt pieces
+	AutoRepair bool
+	// IntervalSeconds defines the interval between two rounds of scrubbing
+	IntervalSeconds int64
+	// SampleNumber defines the number of objects scrubbed in one round, the objects are sampled
+	// in the order of object id, and the scrubber starts over after all the objects are scrubbed
+	SampleNumber int
+}
+
+var DefaultPieceScrubberConfig = &PieceScrubberConfig{
+	Enabled:         false,
+	AutoRepair:      true,
+	IntervalSeconds: 60 * 60,
+	SampleNumber:    100,
+}
+
+// PieceTierMigratorConfig defines the piece tier migrator config, the migrator only works if the hot tier
+// of piece store is configured
+type PieceTierMigratorConfig struct {
+	// Enabled defines whether to start the piece tier migrator
+	Enabled bool
+	// IntervalSeconds defines the interval between two scans of the hot tier
+	IntervalSeconds int64
+	// MinAgeSeconds defines the pieces modified in the period are kept in the hot tier
+	MinAgeSeconds int64
+	// ReadWindowSeconds defines the period in which the read records of objects are counted
+	ReadWindowSeconds int64
+	// HotReadThreshold defines the objects read at least the number of times in the read window
+	// are kept in the hot tier
+	HotReadThreshold int64
+	// BatchSize defines the number of objects whose read records are counted in one query
+	BatchSize int
+}
+
+var DefaultPieceTierMigratorConfig = &PieceTierMigratorConfig{
+	Enabled:           false,
+	IntervalSeconds:   60 * 60,
+	MinAgeSeconds:     7 * 24 * 60 * 60,
+	ReadWindowSeconds: 7 * 24 * 60 * 60,
+	HotReadThreshold:  1,
+	BatchSize:         100,
 }
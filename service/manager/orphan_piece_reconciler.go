@@ -0,0 +1,231 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	storagetypes "github.com/bnb-chain/greenfield/x/storage/types"
+	"gorm.io/gorm"
+
+	"github.com/bnb-chain/greenfield-storage-provider/model"
+	merrors "github.com/bnb-chain/greenfield-storage-provider/model/errors"
+	"github.com/bnb-chain/greenfield-storage-provider/model/piecestore"
+	"github.com/bnb-chain/greenfield-storage-provider/pkg/log"
+	"github.com/bnb-chain/greenfield-storage-provider/pkg/metrics"
+	metatypes "github.com/bnb-chain/greenfield-storage-provider/service/metadata/types"
+	servicetypes "github.com/bnb-chain/greenfield-storage-provider/service/types"
+	"github.com/bnb-chain/greenfield-storage-provider/store/piecestore/storage"
+)
+
+// define the reasons of orphan pieces
+const (
+	// orphanReasonDeleted defines the object has been deleted on chain, but gc has not caught up
+	orphanReasonDeleted = "deleted"
+	// orphanReasonUnsealed defines the object is not sealed, and the job of the object is failed
+	orphanReasonUnsealed = "unsealed"
+	// orphanReasonUnknown defines the object can not be found in both metadata and sp-db
+	orphanReasonUnknown = "unknown"
+)
+
+// define the actions on orphan pieces
+const (
+	orphanActionReported     = "reported"
+	orphanActionDeleted      = "deleted"
+	orphanActionDeleteFailed = "delete_failed"
+)
+
+// OrphanPieceReconciler is responsible for finding the pieces in piece store whose objects never sealed,
+// were rejected, or were deleted before gc caught up. Every piece key is decoded to the object id, which
+// is cross-checked against the metadata service and the job state in sp-db. The orphan pieces are
+// reported, or deleted if the reconciler is not in dry-run mode.
+type OrphanPieceReconciler struct {
+	manager *Manager
+	config  *OrphanPieceReconcilerConfig
+	stopCh  chan struct{}
+}
+
+// Start is a non-blocking function that starts a goroutine execution logic internally.
+func (r *OrphanPieceReconciler) Start() {
+	r.stopCh = make(chan struct{})
+	if !r.config.Enabled {
+		return
+	}
+	go r.startReconcile()
+	log.Infow("start orphan piece reconciler", "dry_run", r.config.DryRun)
+}
+
+// Stop is responsible for stop reconciling.
+func (r *OrphanPieceReconciler) Stop() {
+	close(r.stopCh)
+	log.Info("stop orphan piece reconciler")
+}
+
+// startReconcile scans the piece store periodically.
+func (r *OrphanPieceReconciler) startReconcile() {
+	ticker := time.NewTicker(time.Duration(r.config.IntervalSeconds) * time.Second)
+	defer ticker.Stop()
+	for {
+		r.reconcile()
+		select {
+		case <-ticker.C:
+		case <-r.stopCh:
+			return
+		}
+	}
+}
+
+// reconcile scans all the pieces in piece store once.
+func (r *OrphanPieceReconciler) reconcile() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pieces, err := r.manager.pieceStore.ListAllPieces(ctx, "", "")
+	if errors.Is(err, merrors.ErrUnsupportedMethod) {
+		log.Warnw("skip reconciling orphan pieces, piece store does not support listing pieces")
+		return
+	}
+	if err != nil {
+		log.Errorw("failed to list pieces", "error", err)
+		return
+	}
+
+	var (
+		scannedNumber   uint64
+		orphanNumber    uint64
+		lastObjectID    uint64
+		lastReason      string
+		lastChecked     bool
+		graceDeadline   = time.Now().Add(-time.Duration(r.config.GracePeriodSeconds) * time.Second)
+		scannedCounter  = metrics.OrphanPieceScannedCounter.WithLabelValues(model.ManagerService)
+		reconcileFailed bool
+	)
+	for piece := range pieces {
+		select {
+		case <-r.stopCh:
+			return
+		default:
+		}
+		if err = storage.ListError(piece); err != nil {
+			log.Errorw("failed to list pieces halfway, the pieces are partially reconciled",
+				"scanned_piece_number", scannedNumber, "error", err)
+			reconcileFailed = true
+			break
+		}
+		scannedNumber++
+		scannedCounter.Inc()
+		if piece.ModTime().After(graceDeadline) {
+			continue
+		}
+		objectID, ok := decodePieceKeyObjectID(piece.Key())
+		if !ok {
+			continue
+		}
+		// the pieces of an object are listed continuously, so the object is only checked once
+		if !lastChecked || objectID != lastObjectID {
+			lastReason, err = r.checkObject(ctx, objectID)
+			if err != nil {
+				log.Errorw("failed to check object of piece", "object_id", objectID, "error", err)
+				lastChecked = false
+				reconcileFailed = true
+				continue
+			}
+			lastObjectID, lastChecked = objectID, true
+		}
+		if lastReason == "" {
+			continue
+		}
+		orphanNumber++
+		r.handleOrphanPiece(piece.Key(), objectID, lastReason)
+	}
+	log.Infow("finish reconciling orphan pieces", "scanned_piece_number", scannedNumber,
+		"orphan_piece_number", orphanNumber, "dry_run", r.config.DryRun, "partially_failed", reconcileFailed)
+}
+
+// checkObject returns the orphan reason of the object's pieces, empty reason means the pieces should be kept.
+func (r *OrphanPieceReconciler) checkObject(ctx context.Context, objectID uint64) (string, error) {
+	resp, err := r.manager.metadata.GetObjectByID(ctx, &metatypes.GetObjectByIDRequest{
+		ObjectId:   objectID,
+		IsFullList: true,
+	})
+	if err != nil {
+		return "", err
+	}
+	object := resp.GetObject()
+	if object != nil {
+		if object.GetRemoved() {
+			return orphanReasonDeleted, nil
+		}
+		if object.GetObjectInfo().GetObjectStatus() == storagetypes.OBJECT_STATUS_SEALED {
+			return "", nil
+		}
+	}
+
+	job, err := r.manager.spDB.GetJobByObjectID(objectID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// the object is created on chain but not sealed, it may be uploaded to other sp as primary sp
+		if object != nil {
+			return "", nil
+		}
+		return orphanReasonUnknown, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	switch job.GetJobState() {
+	case servicetypes.JobState_JOB_STATE_UPLOAD_OBJECT_ERROR:
+		// the failed upload is resumed with the uploaded pieces while the object is created on chain
+		if object != nil {
+			return "", nil
+		}
+		return orphanReasonUnsealed, nil
+	case servicetypes.JobState_JOB_STATE_ALLOC_SECONDARY_ERROR:
+		return orphanReasonUnsealed, nil
+	case servicetypes.JobState_JOB_STATE_REPLICATE_OBJECT_ERROR,
+		servicetypes.JobState_JOB_STATE_SIGN_OBJECT_ERROR,
+		servicetypes.JobState_JOB_STATE_SEAL_OBJECT_ERROR:
+		// the failed job is retried by the job recoverer with the pieces, they are orphan only if the
+		// retries are used up
+		if job.GetRetryCount() >= defaultJobMaxRetry {
+			return orphanReasonUnsealed, nil
+		}
+		return "", nil
+	default:
+		return "", nil
+	}
+}
+
+// handleOrphanPiece reports the orphan piece, and deletes it if the reconciler is not in dry-run mode.
+func (r *OrphanPieceReconciler) handleOrphanPiece(key string, objectID uint64, reason string) {
+	if r.config.DryRun {
+		metrics.OrphanPieceCounter.WithLabelValues(reason, orphanActionReported).Inc()
+		log.Warnw("found orphan piece", "key", key, "object_id", objectID, "reason", reason)
+		return
+	}
+	if err := r.manager.pieceStore.DeletePiece(key); err != nil {
+		metrics.OrphanPieceCounter.WithLabelValues(reason, orphanActionDeleteFailed).Inc()
+		log.Errorw("failed to delete orphan piece", "key", key, "object_id", objectID, "reason", reason, "error", err)
+		return
+	}
+	metrics.OrphanPieceCounter.WithLabelValues(reason, orphanActionDeleted).Inc()
+	log.Infow("succeed to delete orphan piece", "key", key, "object_id", objectID, "reason", reason)
+}
+
+// decodePieceKeyObjectID decodes the object id from segment piece key or ec piece key,
+// returns false if the key is not a piece key.
+func decodePieceKeyObjectID(key string) (uint64, bool) {
+	var (
+		objectID uint64
+		err      error
+	)
+	switch strings.Count(key, "_") {
+	case 1:
+		objectID, _, err = piecestore.DecodeSegmentPieceKey(key)
+	case 2:
+		objectID, _, _, err = piecestore.DecodeECPieceKey(key)
+	default:
+		return 0, false
+	}
+	return objectID, err == nil
+}
@@ -0,0 +1,118 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	storagetypes "github.com/bnb-chain/greenfield/x/storage/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"gorm.io/gorm"
+
+	metatypes "github.com/bnb-chain/greenfield-storage-provider/service/metadata/types"
+	servicetypes "github.com/bnb-chain/greenfield-storage-provider/service/types"
+	"github.com/bnb-chain/greenfield-storage-provider/store/sqldb"
+)
+
+// fakeMetadata is the metadata service which returns the mocked object
+type fakeMetadata struct {
+	metadataAPI
+	object *metatypes.Object
+	err    error
+}
+
+func (m *fakeMetadata) GetObjectByID(ctx context.Context, in *metatypes.GetObjectByIDRequest, opts ...grpc.CallOption) (
+	*metatypes.GetObjectByIDResponse, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &metatypes.GetObjectByIDResponse{Object: m.object}, nil
+}
+
+func TestOrphanPieceReconciler_CheckObject(t *testing.T) {
+	newObject := func(status storagetypes.ObjectStatus, removed bool) *metatypes.Object {
+		return &metatypes.Object{
+			ObjectInfo: &storagetypes.ObjectInfo{Id: sdkmath.NewUint(1), ObjectStatus: status},
+			Removed:    removed,
+		}
+	}
+	var (
+		created = newObject(storagetypes.OBJECT_STATUS_CREATED, false)
+		sealed  = newObject(storagetypes.OBJECT_STATUS_SEALED, false)
+		removed = newObject(storagetypes.OBJECT_STATUS_SEALED, true)
+		mockErr = errors.New("mock error")
+	)
+	newJob := func(state servicetypes.JobState, retryCount uint32) *servicetypes.JobContext {
+		return &servicetypes.JobContext{JobState: state, RetryCount: retryCount}
+	}
+	cases := []struct {
+		name         string
+		object       *metatypes.Object
+		metadataErr  error
+		job          *servicetypes.JobContext
+		jobErr       error
+		wantedReason string
+		wantedErr    bool
+	}{
+		{name: "failed to get object", metadataErr: mockErr, wantedErr: true},
+		{name: "removed object", object: removed, wantedReason: orphanReasonDeleted},
+		{name: "sealed object", object: sealed},
+		{name: "created object without job", object: created, jobErr: gorm.ErrRecordNotFound},
+		{name: "unknown object without job", jobErr: gorm.ErrRecordNotFound, wantedReason: orphanReasonUnknown},
+		{name: "failed to get job", object: created, jobErr: mockErr, wantedErr: true},
+		{name: "resumable upload of created object", object: created,
+			job: newJob(servicetypes.JobState_JOB_STATE_UPLOAD_OBJECT_ERROR, 0)},
+		{name: "failed upload of unknown object", job: newJob(servicetypes.JobState_JOB_STATE_UPLOAD_OBJECT_ERROR, 0),
+			wantedReason: orphanReasonUnsealed},
+		{name: "failed to alloc secondary sps", object: created,
+			job: newJob(servicetypes.JobState_JOB_STATE_ALLOC_SECONDARY_ERROR, 0), wantedReason: orphanReasonUnsealed},
+		{name: "failed replication to be recovered", object: created,
+			job: newJob(servicetypes.JobState_JOB_STATE_REPLICATE_OBJECT_ERROR, defaultJobMaxRetry-1)},
+		{name: "failed seal without retries", object: created,
+			job: newJob(servicetypes.JobState_JOB_STATE_SEAL_OBJECT_ERROR, defaultJobMaxRetry), wantedReason: orphanReasonUnsealed},
+		{name: "uploading object", object: created, job: newJob(servicetypes.JobState_JOB_STATE_UPLOAD_OBJECT_DOING, 0)},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			spDB := sqldb.NewMockSPDB(ctrl)
+			if tt.job != nil || tt.jobErr != nil {
+				spDB.EXPECT().GetJobByObjectID(uint64(1)).Return(tt.job, tt.jobErr)
+			}
+			r := &OrphanPieceReconciler{manager: &Manager{
+				spDB:     spDB,
+				metadata: &fakeMetadata{object: tt.object, err: tt.metadataErr},
+			}}
+			reason, err := r.checkObject(context.TODO(), 1)
+			assert.Equal(t, tt.wantedErr, err != nil)
+			assert.Equal(t, tt.wantedReason, reason)
+		})
+	}
+}
+
+func TestDecodePieceKeyObjectID(t *testing.T) {
+	cases := []struct {
+		key            string
+		wantedObjectID uint64
+		wantedOK       bool
+	}{
+		{"1_s0", 1, true},
+		{"100_s2_p3", 100, true},
+		{"invalid", 0, false},
+		{"x_s0", 0, false},
+		{"1_s0_p1_x", 0, false},
+	}
+	for _, tt := range cases {
+		t.Run(tt.key, func(t *testing.T) {
+			objectID, ok := decodePieceKeyObjectID(tt.key)
+			assert.Equal(t, tt.wantedOK, ok)
+			if ok {
+				assert.Equal(t, tt.wantedObjectID, objectID)
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package manager
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bnb-chain/greenfield-common/go/hash"
+	storagetypes "github.com/bnb-chain/greenfield/x/storage/types"
+
+	"github.com/bnb-chain/greenfield-storage-provider/model/piecestore"
+	"github.com/bnb-chain/greenfield-storage-provider/pkg/log"
+	"github.com/bnb-chain/greenfield-storage-provider/pkg/metrics"
+	"github.com/bnb-chain/greenfield-storage-provider/store/sqldb"
+	"github.com/bnb-chain/greenfield-storage-provider/util"
+)
+
+// define the results of scrubbed pieces
+const (
+	scrubResultHealthy = "healthy"
+	scrubResultLost    = "lost"
+	scrubResultCorrupt = "corrupt"
+)
+
+// primaryRedundancyIdx defines the redundancy index of the primary sp, which stores the segment pieces
+const primaryRedundancyIdx = -1
+
+var errNotStoredObject = errors.New("object is not stored by this sp")
+
+// PieceScrubber is responsible for auditing the locally stored pieces before the challengers do. It samples
+// the objects from the integrity metas in sp-db periodically, re-reads every piece of the object from piece
+// store and compares its checksum with the integrity meta. The scrub outcome is recorded in sp-db, and the
+// task node is triggered to repair the corrupt pieces if auto repair is enabled.
+type PieceScrubber struct {
+	manager      *Manager
+	config       *PieceScrubberConfig
+	stopCh       chan struct{}
+	lastObjectID uint64
+}
+
+// Start is a non-blocking function that starts a goroutine execution logic internally.
+func (s *PieceScrubber) Start() {
+	s.stopCh = make(chan struct{})
+	if !s.config.Enabled {
+		return
+	}
+	go s.startScrub()
+	log.Infow("start piece scrubber", "auto_repair", s.config.AutoRepair)
+}
+
+// Stop is responsible for stop scrubbing.
+func (s *PieceScrubber) Stop() {
+	close(s.stopCh)
+	log.Info("stop piece scrubber")
+}
+
+// startScrub scrubs the sampled objects periodically.
+func (s *PieceScrubber) startScrub() {
+	ticker := time.NewTicker(time.Duration(s.config.IntervalSeconds) * time.Second)
+	defer ticker.Stop()
+	for {
+		s.scrub()
+		select {
+		case <-ticker.C:
+		case <-s.stopCh:
+			return
+		}
+	}
+}
+
+// scrub samples the next batch of objects after the last scrubbed one, and starts over from the
+// beginning if all the objects have been scrubbed.
+func (s *PieceScrubber) scrub() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	integrities, err := s.manager.spDB.ListObjectIntegrities(s.lastObjectID, s.config.SampleNumber)
+	if err != nil {
+		log.Errorw("failed to list integrity metas", "error", err)
+		return
+	}
+	if len(integrities) < s.config.SampleNumber {
+		s.lastObjectID = 0
+	} else {
+		s.lastObjectID = integrities[len(integrities)-1].ObjectID
+	}
+
+	var corruptNumber int
+	for _, integrity := range integrities {
+		select {
+		case <-s.stopCh:
+			return
+		default:
+		}
+		result, redundancyIdx := s.scrubObject(ctx, integrity)
+		if result == nil {
+			continue
+		}
+		if result.Status == sqldb.ScrubStatusCorrupt && s.config.AutoRepair {
+			s.repairObject(ctx, result, redundancyIdx)
+		}
+		if result.Status != sqldb.ScrubStatusHealthy {
+			corruptNumber++
+		}
+		if err = s.manager.spDB.SetScrubResult(result); err != nil {
+			log.Errorw("failed to set scrub result", "object_id", integrity.ObjectID, "error", err)
+		}
+	}
+	log.Infow("finish scrubbing pieces", "scrubbed_object_number", len(integrities),
+		"corrupt_object_number", corruptNumber, "auto_repair", s.config.AutoRepair)
+}
+
+// scrubObject re-reads all the pieces of the object stored by this sp, and returns the scrub outcome and
+// the redundancy index of this sp, nil outcome means the object should be skipped.
+func (s *PieceScrubber) scrubObject(ctx context.Context, integrity *sqldb.IntegrityMeta) (*sqldb.ScrubResult, int) {
+	objectInfo, err := s.manager.chain.QueryObjectInfoByID(ctx, util.Uint64ToString(integrity.ObjectID))
+	if err != nil {
+		// the deleted objects are released by gc, and the unsealed ones are reconciled by orphan piece reconciler
+		log.Warnw("skip scrubbing object, failed to query object info", "object_id", integrity.ObjectID, "error", err)
+		return nil, 0
+	}
+	if objectInfo.GetObjectStatus() != storagetypes.OBJECT_STATUS_SEALED {
+		return nil, 0
+	}
+	return s.scrubPieces(ctx, objectInfo, integrity)
+}
+
+// scrubPieces re-reads all the pieces of the sealed object stored by this sp and compares their checksums with
+// the integrity meta, returns the scrub outcome and the redundancy index of this sp.
+func (s *PieceScrubber) scrubPieces(ctx context.Context, objectInfo *storagetypes.ObjectInfo,
+	integrity *sqldb.IntegrityMeta) (*sqldb.ScrubResult, int) {
+	result := &sqldb.ScrubResult{
+		ObjectID: integrity.ObjectID,
+		Status:   sqldb.ScrubStatusHealthy,
+	}
+	redundancyIdx, err := s.getRedundancyIdx(objectInfo, integrity)
+	if err != nil {
+		log.Warnw("skip scrubbing object", "object_id", integrity.ObjectID, "error", err)
+		return nil, 0
+	}
+	params, err := s.manager.spDB.GetStorageParams()
+	if err != nil {
+		log.Errorw("failed to get storage params", "error", err)
+		return nil, 0
+	}
+	segmentCount := piecestore.ComputeSegmentCount(objectInfo.GetPayloadSize(),
+		params.VersionedParams.GetMaxSegmentSize())
+	if int(segmentCount) != len(integrity.Checksum) {
+		result.Status = sqldb.ScrubStatusCorrupt
+		result.ErrorDescription = fmt.Sprintf("mismatch checksum number, segment count: %d, checksum number: %d",
+			segmentCount, len(integrity.Checksum))
+		return result, redundancyIdx
+	}
+
+	for segmentIdx := uint32(0); segmentIdx < segmentCount; segmentIdx++ {
+		var key string
+		if redundancyIdx != primaryRedundancyIdx &&
+			objectInfo.GetRedundancyType() == storagetypes.REDUNDANCY_EC_TYPE {
+			key = piecestore.EncodeECPieceKey(integrity.ObjectID, segmentIdx, uint32(redundancyIdx))
+		} else {
+			key = piecestore.EncodeSegmentPieceKey(integrity.ObjectID, segmentIdx)
+		}
+		result.ScrubbedPieces++
+		data, err := s.manager.pieceStore.GetPiece(ctx, key, 0, 0)
+		if err != nil {
+			metrics.ScrubPieceCounter.WithLabelValues(scrubResultLost).Inc()
+			log.Warnw("found lost piece", "key", key, "error", err)
+			result.CorruptPieceKeys = append(result.CorruptPieceKeys, key)
+			continue
+		}
+		if !bytes.Equal(hash.GenerateChecksum(data), integrity.Checksum[segmentIdx]) {
+			metrics.ScrubPieceCounter.WithLabelValues(scrubResultCorrupt).Inc()
+			log.Warnw("found corrupt piece", "key", key)
+			result.CorruptPieceKeys = append(result.CorruptPieceKeys, key)
+			continue
+		}
+		metrics.ScrubPieceCounter.WithLabelValues(scrubResultHealthy).Inc()
+	}
+	if len(result.CorruptPieceKeys) != 0 {
+		result.Status = sqldb.ScrubStatusCorrupt
+	}
+	return result, redundancyIdx
+}
+
+// getRedundancyIdx returns the redundancy index of this sp for the object, the integrity hash of the
+// primary sp is the first checksum of the object, and the secondary sp's is the checksum at redundancyIdx+1.
+func (s *PieceScrubber) getRedundancyIdx(objectInfo *storagetypes.ObjectInfo, integrity *sqldb.IntegrityMeta) (
+	int, error) {
+	for idx, spAddress := range objectInfo.GetSecondarySpAddresses() {
+		if spAddress == s.manager.config.SpOperatorAddress && idx+1 < len(objectInfo.GetChecksums()) &&
+			bytes.Equal(objectInfo.GetChecksums()[idx+1], integrity.IntegrityHash) {
+			return idx, nil
+		}
+	}
+	if len(objectInfo.GetChecksums()) != 0 && bytes.Equal(objectInfo.GetChecksums()[0], integrity.IntegrityHash) {
+		return primaryRedundancyIdx, nil
+	}
+	return 0, errNotStoredObject
+}
+
+// repairObject triggers the task node to repair the corrupt pieces of the object, the segment pieces stored by
+// the primary sp are reconstructed from the secondary sps.
+func (s *PieceScrubber) repairObject(ctx context.Context, result *sqldb.ScrubResult, redundancyIdx int) {
+	resp, err := s.manager.taskNode.RepairObjectPiece(ctx, result.ObjectID, int32(redundancyIdx))
+	if err != nil {
+		metrics.ScrubRepairCounter.WithLabelValues("failure").Inc()
+		log.Errorw("failed to repair object", "object_id", result.ObjectID, "error", err)
+		result.ErrorDescription = err.Error()
+		return
+	}
+	metrics.ScrubRepairCounter.WithLabelValues("success").Inc()
+	log.Infow("succeed to repair object", "object_id", result.ObjectID,
+		"repaired_piece_number", resp.GetRepairedPieceNumber())
+	result.Status = sqldb.ScrubStatusRepaired
+	result.ErrorDescription = ""
+}
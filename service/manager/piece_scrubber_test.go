@@ -0,0 +1,90 @@
+package manager
+
+import (
+	"context"
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	"github.com/bnb-chain/greenfield-common/go/hash"
+	storagetypes "github.com/bnb-chain/greenfield/x/storage/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	psclient "github.com/bnb-chain/greenfield-storage-provider/store/piecestore/client"
+	"github.com/bnb-chain/greenfield-storage-provider/store/piecestore/storage"
+	"github.com/bnb-chain/greenfield-storage-provider/store/sqldb"
+)
+
+func setupPieceScrubber(t *testing.T) (*PieceScrubber, *sqldb.MockSPDB) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+	spDB := sqldb.NewMockSPDB(ctrl)
+	spDB.EXPECT().GetStorageParams().Return(&storagetypes.Params{
+		VersionedParams: storagetypes.VersionedParams{MaxSegmentSize: 4},
+	}, nil).AnyTimes()
+	pieceStore, err := psclient.NewStoreClient(&storage.PieceStoreConfig{
+		Store: storage.ObjectStorageConfig{Storage: "file", BucketURL: t.TempDir() + "/"},
+	})
+	assert.Nil(t, err)
+	return &PieceScrubber{
+		manager: &Manager{
+			config:     &ManagerConfig{SpOperatorAddress: "sp1"},
+			spDB:       spDB,
+			pieceStore: pieceStore,
+		},
+		config: DefaultPieceScrubberConfig,
+	}, spDB
+}
+
+func TestPieceScrubber_GetRedundancyIdx(t *testing.T) {
+	s, _ := setupPieceScrubber(t)
+	objectInfo := &storagetypes.ObjectInfo{
+		SecondarySpAddresses: []string{"sp0", "sp1"},
+		Checksums:            [][]byte{[]byte("primary"), []byte("secondary0"), []byte("secondary1")},
+	}
+	idx, err := s.getRedundancyIdx(objectInfo, &sqldb.IntegrityMeta{IntegrityHash: []byte("secondary1")})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, idx)
+	idx, err = s.getRedundancyIdx(objectInfo, &sqldb.IntegrityMeta{IntegrityHash: []byte("primary")})
+	assert.Nil(t, err)
+	assert.Equal(t, primaryRedundancyIdx, idx)
+	// the integrity hash of other secondary sp is not stored by this sp
+	_, err = s.getRedundancyIdx(objectInfo, &sqldb.IntegrityMeta{IntegrityHash: []byte("secondary0")})
+	assert.Equal(t, errNotStoredObject, err)
+}
+
+func TestPieceScrubber_ScrubPieces(t *testing.T) {
+	s, _ := setupPieceScrubber(t)
+	segments := [][]byte{[]byte("0123"), []byte("4567"), []byte("89")}
+	var checksums [][]byte
+	for _, segment := range segments {
+		checksums = append(checksums, hash.GenerateChecksum(segment))
+	}
+	integrity := &sqldb.IntegrityMeta{ObjectID: 1, Checksum: checksums, IntegrityHash: []byte("primary")}
+	objectInfo := &storagetypes.ObjectInfo{
+		Id:          sdkmath.NewUint(1),
+		PayloadSize: 10,
+		Checksums:   [][]byte{[]byte("primary")},
+	}
+	for idx, segment := range segments {
+		assert.Nil(t, s.manager.pieceStore.PutPiece("1_s"+string(rune('0'+idx)), segment))
+	}
+
+	result, redundancyIdx := s.scrubPieces(context.TODO(), objectInfo, integrity)
+	assert.Equal(t, primaryRedundancyIdx, redundancyIdx)
+	assert.Equal(t, sqldb.ScrubStatusHealthy, result.Status)
+	assert.Equal(t, uint32(3), result.ScrubbedPieces)
+
+	// the corrupt and the lost pieces are both recorded
+	assert.Nil(t, s.manager.pieceStore.PutPiece("1_s1", []byte("xxxx")))
+	assert.Nil(t, s.manager.pieceStore.DeletePiece("1_s2"))
+	result, _ = s.scrubPieces(context.TODO(), objectInfo, integrity)
+	assert.Equal(t, sqldb.ScrubStatusCorrupt, result.Status)
+	assert.Equal(t, []string{"1_s1", "1_s2"}, result.CorruptPieceKeys)
+
+	// the object whose checksum number mismatches the segment count is corrupt
+	objectInfo.PayloadSize = 20
+	result, _ = s.scrubPieces(context.TODO(), objectInfo, integrity)
+	assert.Equal(t, sqldb.ScrubStatusCorrupt, result.Status)
+	assert.Equal(t, uint32(0), result.ScrubbedPieces)
+}
@@ -0,0 +1,165 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/bnb-chain/greenfield-storage-provider/model"
+	merrors "github.com/bnb-chain/greenfield-storage-provider/model/errors"
+	"github.com/bnb-chain/greenfield-storage-provider/pkg/log"
+	"github.com/bnb-chain/greenfield-storage-provider/pkg/metrics"
+	"github.com/bnb-chain/greenfield-storage-provider/store/piecestore/storage"
+)
+
+// define the results of hot pieces handled by piece tier migrator
+const (
+	tierMigrationMigrated = "migrated"
+	tierMigrationKeptHot  = "kept_hot"
+	tierMigrationFailed   = "failed"
+)
+
+// PieceTierMigrator is responsible for moving the pieces from the hot tier to the cold tier of piece store.
+// The pieces older than the min age are candidates, and the read count of their objects in the read window
+// is queried from the read records in sp-db, the pieces of the objects read less than the hot read threshold
+// are migrated to the cold tier. The reads of migrated pieces fall back to the cold tier transparently.
+type PieceTierMigrator struct {
+	manager *Manager
+	config  *PieceTierMigratorConfig
+	stopCh  chan struct{}
+}
+
+// Start is a non-blocking function that starts a goroutine execution logic internally.
+func (t *PieceTierMigrator) Start() {
+	t.stopCh = make(chan struct{})
+	if !t.config.Enabled {
+		return
+	}
+	go t.startMigrate()
+	log.Infow("start piece tier migrator", "min_age_seconds", t.config.MinAgeSeconds,
+		"hot_read_threshold", t.config.HotReadThreshold)
+}
+
+// Stop is responsible for stop migrating.
+func (t *PieceTierMigrator) Stop() {
+	close(t.stopCh)
+	log.Info("stop piece tier migrator")
+}
+
+// startMigrate scans the hot tier periodically.
+func (t *PieceTierMigrator) startMigrate() {
+	ticker := time.NewTicker(time.Duration(t.config.IntervalSeconds) * time.Second)
+	defer ticker.Stop()
+	for {
+		t.migrate()
+		select {
+		case <-ticker.C:
+		case <-t.stopCh:
+			return
+		}
+	}
+}
+
+// migrate scans all the pieces in the hot tier once.
+func (t *PieceTierMigrator) migrate() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pieces, err := t.manager.pieceStore.ListAllHotPieces(ctx, "", "")
+	if errors.Is(err, merrors.ErrUnsupportedMethod) {
+		log.Warnw("skip migrating pieces, piece store is not tiered or does not support listing pieces")
+		return
+	}
+	if err != nil {
+		log.Errorw("failed to list hot pieces", "error", err)
+		return
+	}
+
+	var (
+		now            = time.Now()
+		ageDeadline    = now.Add(-time.Duration(t.config.MinAgeSeconds) * time.Second)
+		readStartUs    = now.Add(-time.Duration(t.config.ReadWindowSeconds) * time.Second).UnixMicro()
+		batchSize      = t.config.BatchSize
+		objectIDs      []uint64
+		objectPieces   = make(map[uint64][]string)
+		scannedNumber  uint64
+		migratedNumber uint64
+		migratedBytes  int64
+	)
+	if batchSize <= 0 {
+		batchSize = DefaultPieceTierMigratorConfig.BatchSize
+	}
+	flush := func() {
+		number, size := t.migrateBatch(ctx, objectIDs, objectPieces, readStartUs)
+		migratedNumber += number
+		migratedBytes += size
+		objectIDs, objectPieces = nil, make(map[uint64][]string)
+	}
+	for piece := range pieces {
+		select {
+		case <-t.stopCh:
+			return
+		default:
+		}
+		if err = storage.ListError(piece); err != nil {
+			log.Errorw("failed to list hot pieces halfway, the pieces are partially migrated",
+				"scanned_piece_number", scannedNumber, "error", err)
+			break
+		}
+		scannedNumber++
+		if piece.ModTime().After(ageDeadline) {
+			continue
+		}
+		objectID, ok := decodePieceKeyObjectID(piece.Key())
+		if !ok {
+			continue
+		}
+		if _, ok = objectPieces[objectID]; !ok {
+			if len(objectIDs) >= batchSize {
+				flush()
+			}
+			objectIDs = append(objectIDs, objectID)
+		}
+		objectPieces[objectID] = append(objectPieces[objectID], piece.Key())
+	}
+	flush()
+	log.Infow("finish migrating pieces", "scanned_piece_number", scannedNumber,
+		"migrated_piece_number", migratedNumber, "migrated_bytes", migratedBytes)
+}
+
+// migrateBatch migrates the pieces of the objects which are read less than the hot read threshold,
+// returns the number and the total size of the migrated pieces.
+func (t *PieceTierMigrator) migrateBatch(ctx context.Context, objectIDs []uint64, objectPieces map[uint64][]string,
+	readStartUs int64) (uint64, int64) {
+	if len(objectIDs) == 0 {
+		return 0, 0
+	}
+	readCounts, err := t.manager.spDB.GetObjectsReadCount(objectIDs, readStartUs)
+	if err != nil {
+		log.Errorw("failed to get objects read count", "object_number", len(objectIDs), "error", err)
+		return 0, 0
+	}
+	var (
+		migratedNumber uint64
+		migratedBytes  int64
+	)
+	for _, objectID := range objectIDs {
+		if readCounts[objectID] >= t.config.HotReadThreshold {
+			metrics.PieceTierMigrationCounter.WithLabelValues(tierMigrationKeptHot).Add(float64(len(objectPieces[objectID])))
+			continue
+		}
+		for _, key := range objectPieces[objectID] {
+			size, err := t.manager.pieceStore.MigratePiece(ctx, key)
+			if err != nil {
+				metrics.PieceTierMigrationCounter.WithLabelValues(tierMigrationFailed).Inc()
+				log.Errorw("failed to migrate piece", "key", key, "object_id", objectID, "error", err)
+				continue
+			}
+			metrics.PieceTierMigrationCounter.WithLabelValues(tierMigrationMigrated).Inc()
+			metrics.PieceTierMigratedBytesCounter.WithLabelValues(model.ManagerService).Add(float64(size))
+			migratedNumber++
+			migratedBytes += size
+		}
+	}
+	return migratedNumber, migratedBytes
+}
@@ -0,0 +1,92 @@
+package manager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	psclient "github.com/bnb-chain/greenfield-storage-provider/store/piecestore/client"
+	"github.com/bnb-chain/greenfield-storage-provider/store/piecestore/storage"
+	"github.com/bnb-chain/greenfield-storage-provider/store/sqldb"
+)
+
+func setupPieceTierMigrator(t *testing.T, pieceConfig *storage.PieceStoreConfig) (*PieceTierMigrator, *sqldb.MockSPDB) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+	spDB := sqldb.NewMockSPDB(ctrl)
+	pieceStore, err := psclient.NewStoreClient(pieceConfig)
+	assert.Nil(t, err)
+	return &PieceTierMigrator{
+		manager: &Manager{spDB: spDB, pieceStore: pieceStore},
+		config: &PieceTierMigratorConfig{
+			Enabled:           true,
+			ReadWindowSeconds: 60,
+			HotReadThreshold:  3,
+			BatchSize:         2,
+		},
+		stopCh: make(chan struct{}),
+	}, spDB
+}
+
+func listHotPieceKeys(t *testing.T, m *PieceTierMigrator) []string {
+	ch, err := m.manager.pieceStore.ListAllHotPieces(context.TODO(), "", "")
+	assert.Nil(t, err)
+	var keys []string
+	for obj := range ch {
+		keys = append(keys, obj.Key())
+	}
+	return keys
+}
+
+func TestPieceTierMigrator_Migrate(t *testing.T) {
+	m, spDB := setupPieceTierMigrator(t, &storage.PieceStoreConfig{
+		Store: storage.ObjectStorageConfig{Storage: "file", BucketURL: t.TempDir() + "/"},
+		Tier:  storage.TieredStoreConfig{HotStore: storage.ObjectStorageConfig{Storage: "file", BucketURL: t.TempDir() + "/"}},
+	})
+	for _, key := range []string{"1_s0", "1_s1", "2_s0", "3_s0", "invalid"} {
+		assert.Nil(t, m.manager.pieceStore.PutPiece(key, []byte(key)))
+	}
+
+	// the read counts are queried in batches, the object read frequently is kept in the hot tier
+	gomock.InOrder(
+		spDB.EXPECT().GetObjectsReadCount([]uint64{1, 2}, gomock.Any()).Return(map[uint64]int64{2: 3}, nil),
+		spDB.EXPECT().GetObjectsReadCount([]uint64{3}, gomock.Any()).Return(map[uint64]int64{3: 2}, nil),
+	)
+	m.migrate()
+	assert.Equal(t, []string{"2_s0", "invalid"}, listHotPieceKeys(t, m))
+
+	// the migrated pieces are read from the cold tier transparently
+	for _, key := range []string{"1_s0", "1_s1", "2_s0", "3_s0"} {
+		data, err := m.manager.pieceStore.GetPiece(context.TODO(), key, 0, 0)
+		assert.Nil(t, err)
+		assert.Equal(t, key, string(data))
+	}
+}
+
+func TestPieceTierMigrator_SkipYoungPieces(t *testing.T) {
+	m, _ := setupPieceTierMigrator(t, &storage.PieceStoreConfig{
+		Store: storage.ObjectStorageConfig{Storage: "file", BucketURL: t.TempDir() + "/"},
+		Tier:  storage.TieredStoreConfig{HotStore: storage.ObjectStorageConfig{Storage: "file", BucketURL: t.TempDir() + "/"}},
+	})
+	m.config.MinAgeSeconds = 3600
+	assert.Nil(t, m.manager.pieceStore.PutPiece("1_s0", []byte("1_s0")))
+
+	// the read counts are not queried since no piece is old enough
+	m.migrate()
+	assert.Equal(t, []string{"1_s0"}, listHotPieceKeys(t, m))
+}
+
+func TestPieceTierMigrator_NotTiered(t *testing.T) {
+	m, _ := setupPieceTierMigrator(t, &storage.PieceStoreConfig{
+		Store: storage.ObjectStorageConfig{Storage: "file", BucketURL: t.TempDir() + "/"},
+	})
+	assert.Nil(t, m.manager.pieceStore.PutPiece("1_s0", []byte("1_s0")))
+
+	// the migration is skipped without querying the read counts
+	m.migrate()
+	data, err := m.manager.pieceStore.GetPiece(context.TODO(), "1_s0", 0, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, "1_s0", string(data))
+}
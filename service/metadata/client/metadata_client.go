@@ -132,6 +132,17 @@ func (client *MetadataClient) GetObjectMeta(ctx context.Context, in *metatypes.G
 	return resp, nil
 }
 
+// GetObjectByID get object info by an object id
+func (client *MetadataClient) GetObjectByID(ctx context.Context, in *metatypes.GetObjectByIDRequest, opts ...grpc.CallOption) (*metatypes.GetObjectByIDResponse, error) {
+	resp, err := client.metadata.GetObjectByID(ctx, in, opts...)
+	ctx = log.Context(ctx, resp)
+	if err != nil {
+		log.CtxErrorw(ctx, "failed to send get object by object id rpc", "error", err)
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetPaymentByBucketName get bucket payment info by a bucket name
 func (client *MetadataClient) GetPaymentByBucketName(ctx context.Context, in *metatypes.GetPaymentByBucketNameRequest, opts ...grpc.CallOption) (*metatypes.GetPaymentByBucketNameResponse, error) {
 	resp, err := client.metadata.GetPaymentByBucketName(ctx, in, opts...)
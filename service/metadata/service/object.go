@@ -3,10 +3,14 @@ package service
 import (
 	"context"
 	"encoding/base64"
+	"errors"
 
 	"cosmossdk.io/math"
 	"github.com/bnb-chain/greenfield/types/s3util"
 	"github.com/bnb-chain/greenfield/x/storage/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
 
 	"github.com/bnb-chain/greenfield-storage-provider/pkg/log"
 	metatypes "github.com/bnb-chain/greenfield-storage-provider/service/metadata/types"
@@ -125,6 +129,14 @@ func (metadata *Metadata) ListDeletedObjectsByBlockNumberRange(ctx context.Conte
 		log.CtxErrorw(ctx, "failed to list deleted objects by block number range", "error", err)
 		return nil, err
 	}
+	// the deleted objects are listed in the order of block number, if the list is truncated by the size limit, the
+	// last block may be partially listed, so the end block is moved before it and the rest is left to the next list
+	if len(objects) >= model.DeletedObjectsDefaultSize {
+		endBlockNumber = objects[len(objects)-1].UpdateAt - 1
+		if endBlockNumber < req.StartBlockNumber {
+			log.CtxErrorw(ctx, "too many deleted objects in one block to list", "block_number", req.StartBlockNumber)
+		}
+	}
 
 	res := make([]*metatypes.Object, 0)
 	for _, object := range objects {
@@ -178,6 +190,11 @@ func (metadata *Metadata) GetObjectMeta(ctx context.Context, req *metatypes.GetO
 	}
 
 	object, err = metadata.bsDB.GetObjectByName(req.ObjectName, req.BucketName, req.IsFullList)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// the not found code is mapped to NoSuchKey by the gateway
+		log.CtxErrorw(ctx, "failed to get object by object name due to object not found", "error", err)
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
 	if err != nil {
 		log.CtxErrorw(ctx, "failed to get object by object name", "error", err)
 		return nil, err
@@ -214,3 +231,50 @@ func (metadata *Metadata) GetObjectMeta(ctx context.Context, req *metatypes.GetO
 	log.CtxInfo(ctx, "succeed to get object meta")
 	return resp, nil
 }
+
+// GetObjectByID get object info by an object id
+func (metadata *Metadata) GetObjectByID(ctx context.Context, req *metatypes.GetObjectByIDRequest) (resp *metatypes.GetObjectByIDResponse, err error) {
+	var (
+		object *model.Object
+		res    *metatypes.Object
+	)
+
+	ctx = log.Context(ctx, req)
+	object, err = metadata.bsDB.GetObjectByID(req.ObjectId, req.IsFullList)
+	if err != nil {
+		log.CtxErrorw(ctx, "failed to get object by object id", "error", err)
+		return nil, err
+	}
+
+	if object != nil {
+		res = &metatypes.Object{
+			ObjectInfo: &types.ObjectInfo{
+				Owner:                object.Owner.String(),
+				BucketName:           object.BucketName,
+				ObjectName:           object.ObjectName,
+				Id:                   math.NewUintFromBigInt(object.ObjectID.Big()),
+				PayloadSize:          object.PayloadSize,
+				ContentType:          object.ContentType,
+				CreateAt:             object.CreateTime,
+				ObjectStatus:         types.ObjectStatus(types.ObjectStatus_value[object.ObjectStatus]),
+				RedundancyType:       types.RedundancyType(types.RedundancyType_value[object.RedundancyType]),
+				SourceType:           types.SourceType(types.SourceType_value[object.SourceType]),
+				Checksums:            object.Checksums,
+				SecondarySpAddresses: object.SecondarySpAddresses,
+				Visibility:           types.VisibilityType(types.VisibilityType_value[object.Visibility]),
+			},
+			LockedBalance: object.LockedBalance.String(),
+			Removed:       object.Removed,
+			UpdateAt:      object.UpdateAt,
+			DeleteAt:      object.DeleteAt,
+			DeleteReason:  object.DeleteReason,
+			Operator:      object.Operator.String(),
+			CreateTxHash:  object.CreateTxHash.String(),
+			UpdateTxHash:  object.UpdateTxHash.String(),
+			SealTxHash:    object.SealTxHash.String(),
+		}
+	}
+	resp = &metatypes.GetObjectByIDResponse{Object: res}
+	log.CtxInfo(ctx, "succeed to get object by object id")
+	return resp, nil
+}
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+
+	merrors "github.com/bnb-chain/greenfield-storage-provider/model/errors"
+	metatypes "github.com/bnb-chain/greenfield-storage-provider/service/metadata/types"
+	"github.com/bnb-chain/greenfield-storage-provider/store/bsdb"
+)
+
+func TestGetObjectMeta_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := bsdb.NewMockBSDB(ctrl)
+	m := &Metadata{name: "mockMetadata", bsDB: mockDB}
+
+	mockDB.EXPECT().GetObjectByName("object", "bucket", true).Return(nil, gorm.ErrRecordNotFound)
+	_, err := m.GetObjectMeta(context.Background(),
+		&metatypes.GetObjectMetaRequest{ObjectName: "object", BucketName: "bucket", IsFullList: true})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, merrors.ErrNoSuchObject, merrors.GRPCErrorToInnerError(err))
+
+	mockDB.EXPECT().GetObjectByName("object", "bucket", true).Return(nil, errors.New("connection refused"))
+	_, err = m.GetObjectMeta(context.Background(),
+		&metatypes.GetObjectMetaRequest{ObjectName: "object", BucketName: "bucket", IsFullList: true})
+	assert.NotEqual(t, codes.NotFound, status.Code(err))
+}
@@ -54,17 +54,19 @@ func (p *P2PClient) Close() error {
 	return p.conn.Close()
 }
 
-// GetApproval asks the approval to other SP.
-func (p *P2PClient) GetApproval(ctx context.Context, object *storagetypes.ObjectInfo, expected int64, timeout int64, opts ...grpc.CallOption) (
-	map[string]*p2ptypes.GetApprovalResponse, map[string]*p2ptypes.GetApprovalResponse, error) {
+// GetApproval asks the approval to other SP except the excluded ones, returns the accepted, refused,
+// timed out, pending and unreachable SPs.
+func (p *P2PClient) GetApproval(ctx context.Context, object *storagetypes.ObjectInfo, expected int64, timeout int64,
+	excludedSPs []string, opts ...grpc.CallOption) (*types.GetApprovalResponse, error) {
 	req := &types.GetApprovalRequest{
 		Approval:       &p2ptypes.GetApprovalRequest{ObjectInfo: object},
 		ExpectedAccept: expected,
 		Timeout:        timeout,
+		ExcludedSps:    excludedSPs,
 	}
 	resp, err := p.p2p.GetApproval(ctx, req, opts...)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
-	return resp.GetAccept(), resp.GetRefuse(), nil
+	return resp, nil
 }
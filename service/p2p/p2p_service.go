@@ -13,15 +13,20 @@ var _ p2ptypes.P2PServiceServer = &P2PServer{}
 func (p *P2PServer) GetApproval(ctx context.Context, req *p2ptypes.GetApprovalRequest) (*p2ptypes.GetApprovalResponse, error) {
 	ctx = log.Context(ctx, req)
 	objectInfo := req.GetApproval().GetObjectInfo()
-	accept, refuse, err := p.node.GetApproval(objectInfo, int(req.GetExpectedAccept()), req.GetTimeout())
+	result, err := p.node.GetApproval(objectInfo, int(req.GetExpectedAccept()), req.GetTimeout(), req.GetExcludedSps())
 	if err != nil {
 		log.CtxErrorw(ctx, "failed to get approval", "object_id", objectInfo.Id.Uint64(), "error", err)
 		return nil, err
 	}
 	resp := &p2ptypes.GetApprovalResponse{
-		Accept: accept,
-		Refuse: refuse,
+		Accept:      result.Accept,
+		Refuse:      result.Refuse,
+		Timeout:     result.Timeout,
+		Pending:     result.Pending,
+		Unreachable: result.Unreachable,
 	}
-	log.CtxInfow(ctx, "succeed to get approval", "object_id", objectInfo.Id.Uint64(), "accept", len(accept), "refuse", len(refuse))
+	log.CtxInfow(ctx, "succeed to get approval", "object_id", objectInfo.Id.Uint64(), "accept", len(result.Accept),
+		"refuse", len(result.Refuse), "timeout", len(result.Timeout),
+		"pending", len(result.Pending), "unreachable", len(result.Unreachable), "excluded", len(req.GetExcludedSps()))
 	return resp, nil
 }
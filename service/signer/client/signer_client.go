@@ -11,6 +11,7 @@ import (
 	"github.com/bnb-chain/greenfield-storage-provider/pkg/metrics"
 	p2ptpyes "github.com/bnb-chain/greenfield-storage-provider/pkg/p2p/types"
 	"github.com/bnb-chain/greenfield-storage-provider/service/signer/types"
+	servicetypes "github.com/bnb-chain/greenfield-storage-provider/service/types"
 	utilgrpc "github.com/bnb-chain/greenfield-storage-provider/util/grpc"
 )
 
@@ -166,3 +167,25 @@ func (client *SignerClient) SignReplicateApprovalRspMsg(ctx context.Context, app
 	}
 	return resp.GetApproval(), nil
 }
+
+func (client *SignerClient) SignRecoveryPieceApproval(ctx context.Context, approval *servicetypes.RecoveryPieceApproval, opts ...grpc.CallOption) (*servicetypes.RecoveryPieceApproval, error) {
+	req := &types.SignRecoveryPieceApprovalRequest{
+		Approval: approval,
+	}
+	resp, err := client.signer.SignRecoveryPieceApproval(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetApproval(), nil
+}
+
+func (client *SignerClient) SignPresignedURL(ctx context.Context, presignedURL *servicetypes.PresignedURL, opts ...grpc.CallOption) (*servicetypes.PresignedURL, error) {
+	req := &types.SignPresignedURLRequest{
+		PresignedUrl: presignedURL,
+	}
+	resp, err := client.signer.SignPresignedURL(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetPresignedUrl(), nil
+}
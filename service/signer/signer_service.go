@@ -174,6 +174,32 @@ func (signer *SignerServer) SignReplicateApprovalRspMsg(ctx context.Context, req
 	}, nil
 }
 
+// SignRecoveryPieceApproval signs the approval of getting the pieces from other sps to recover the lost pieces
+func (signer *SignerServer) SignRecoveryPieceApproval(ctx context.Context, req *types.SignRecoveryPieceApprovalRequest) (*types.SignRecoveryPieceApprovalResponse, error) {
+	msg := req.GetApproval()
+	sig, err := signer.client.Sign(client.SignOperator, msg.GetSignBytes())
+	if err != nil {
+		return nil, err
+	}
+	msg.Signature = sig
+	return &types.SignRecoveryPieceApprovalResponse{
+		Approval: msg,
+	}, nil
+}
+
+// SignPresignedURL signs the pre-signed url of getting an object issued to the user
+func (signer *SignerServer) SignPresignedURL(ctx context.Context, req *types.SignPresignedURLRequest) (*types.SignPresignedURLResponse, error) {
+	msg := req.GetPresignedUrl()
+	sig, err := signer.client.Sign(client.SignOperator, msg.GetSignBytes())
+	if err != nil {
+		return nil, err
+	}
+	msg.Signature = sig
+	return &types.SignPresignedURLResponse{
+		PresignedUrl: msg,
+	}, nil
+}
+
 // IPWhitelistInterceptor returns a new unary server interceptors that performs per-request ip whitelist.
 func (signer *SignerServer) IPWhitelistInterceptor() grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
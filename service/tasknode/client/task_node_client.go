@@ -66,3 +66,26 @@ func (client *TaskNodeClient) QueryReplicatingObject(ctx context.Context, object
 	}
 	return resp.GetReplicatePieceInfo(), err
 }
+
+// GCObject release the pieces of the deleted objects in the block range [startBlock, endBlock] from piece store
+func (client *TaskNodeClient) GCObject(ctx context.Context, jobID, startBlock, endBlock uint64,
+	opts ...grpc.CallOption) (*types.GCObjectResponse, error) {
+	return client.taskNode.GCObject(ctx, &types.GCObjectRequest{
+		JobId:            jobID,
+		StartBlockNumber: startBlock,
+		EndBlockNumber:   endBlock,
+	}, opts...)
+}
+
+// RepairObjectPiece repair the lost or corrupt pieces of an object stored by this sp, redundancyIdx is the index
+// of this sp in the secondary sps of the object, and < 0 means this sp is the primary sp of the object
+func (client *TaskNodeClient) RepairObjectPiece(ctx context.Context, objectID uint64, redundancyIdx int32,
+	opts ...grpc.CallOption) (*types.RepairObjectPieceResponse, error) {
+	req := &types.RepairObjectPieceRequest{ObjectId: objectID}
+	if redundancyIdx < 0 {
+		req.Primary = true
+	} else {
+		req.RedundancyIdx = uint32(redundancyIdx)
+	}
+	return client.taskNode.RepairObjectPiece(ctx, req, opts...)
+}
@@ -0,0 +1,224 @@
+package tasknode
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	storagetypes "github.com/bnb-chain/greenfield/x/storage/types"
+	"gorm.io/gorm"
+
+	"github.com/bnb-chain/greenfield-storage-provider/model"
+	"github.com/bnb-chain/greenfield-storage-provider/model/piecestore"
+	"github.com/bnb-chain/greenfield-storage-provider/pkg/log"
+	"github.com/bnb-chain/greenfield-storage-provider/pkg/rcmgr"
+	metatypes "github.com/bnb-chain/greenfield-storage-provider/service/metadata/types"
+	"github.com/bnb-chain/greenfield-storage-provider/service/tasknode/types"
+	"github.com/bnb-chain/greenfield-storage-provider/store/sqldb"
+)
+
+const (
+	// defaultGCObjectMaxRetry defines the max retry number of deleting the failed pieces of an object
+	defaultGCObjectMaxRetry = 10
+	// defaultGCRetryObjectNumberPerTask defines the max number of the failed objects retried in one gc task
+	defaultGCRetryObjectNumberPerTask = 100
+	// defaultGCObjectRetryLease defines the time after which a claimed failed object is regarded as abandoned
+	// and can be claimed by another gc task
+	defaultGCObjectRetryLease = 10 * time.Minute
+	// approximateGCObjectTaskMemSize defines the approximate memory of a gc task, the deleted objects
+	// list of a block range is at most one gRPC message
+	approximateGCObjectTaskMemSize = model.MaxCallMsgSize
+)
+
+// gcObjectTask releases the pieces of the deleted objects in the block range [startBlock, endBlock]
+// from piece store, the outcome of every object is recorded to sp-db.
+type gcObjectTask struct {
+	ctx           context.Context
+	taskNode      *TaskNode
+	jobID         uint64
+	startBlock    uint64
+	endBlock      uint64
+	storageParams *storagetypes.Params
+}
+
+// newGCObjectTask returns a gcObjectTask instance
+func newGCObjectTask(ctx context.Context, task *TaskNode, req *types.GCObjectRequest) (*gcObjectTask, error) {
+	if req.GetStartBlockNumber() > req.GetEndBlockNumber() {
+		return nil, errors.New("invalid gc block range")
+	}
+	return &gcObjectTask{
+		ctx:        ctx,
+		taskNode:   task,
+		jobID:      req.GetJobId(),
+		startBlock: req.GetStartBlockNumber(),
+		endBlock:   req.GetEndBlockNumber(),
+	}, nil
+}
+
+// init is used to load the storage params which are used to generate the piece keys
+func (t *gcObjectTask) init() error {
+	var err error
+	if t.storageParams, err = t.taskNode.spDB.GetStorageParams(); err != nil {
+		log.CtxErrorw(t.ctx, "failed to query storage params", "error", err)
+		return err
+	}
+	return nil
+}
+
+// execute is used to gc the deleted objects in the block range, the memory of the task is reserved
+// with low priority so that gc will not starve the replicate tasks.
+func (t *gcObjectTask) execute() (*types.GCObjectResponse, error) {
+	scopeSpan, err := t.taskNode.rcScope.BeginSpan()
+	if err != nil {
+		log.CtxErrorw(t.ctx, "failed to begin span", "error", err)
+		return nil, err
+	}
+	defer func() {
+		scopeSpan.Done()
+		log.CtxDebugw(t.ctx, "release memory to resource manager",
+			"release_size", approximateGCObjectTaskMemSize, "resource_state", rcmgr.GetServiceState(model.TaskNodeService))
+	}()
+	if err = scopeSpan.ReserveMemory(approximateGCObjectTaskMemSize, rcmgr.ReservationPriorityLow); err != nil {
+		log.CtxErrorw(t.ctx, "failed to reserve memory from resource manager",
+			"reserve_size", approximateGCObjectTaskMemSize, "error", err)
+		return nil, err
+	}
+	log.CtxDebugw(t.ctx, "reserve memory from resource manager",
+		"reserve_size", approximateGCObjectTaskMemSize, "resource_state", rcmgr.GetServiceState(model.TaskNodeService))
+
+	response, err := t.taskNode.metadata.ListDeletedObjectsByBlockNumberRange(t.ctx,
+		&metatypes.ListDeletedObjectsByBlockNumberRangeRequest{
+			StartBlockNumber: int64(t.startBlock),
+			EndBlockNumber:   int64(t.endBlock),
+			IsFullList:       true,
+		})
+	if err != nil {
+		log.CtxErrorw(t.ctx, "failed to query deleted objects", "error", err)
+		return nil, err
+	}
+	// the deleted objects are only listed up to the latest block of the block syncer, so none of the block range
+	// is processed if the block syncer lags behind the start block
+	if response.GetEndBlockNumber() < int64(t.startBlock) {
+		log.CtxErrorw(t.ctx, "failed to gc objects due to no block is listed", "start_block", t.startBlock,
+			"end_block", response.GetEndBlockNumber())
+		return nil, errors.New("no block of the gc block range is listed")
+	}
+	resp := &types.GCObjectResponse{EndBlockNumber: uint64(response.GetEndBlockNumber())}
+	for _, object := range response.GetObjects() {
+		if t.gcObject(object.GetObjectInfo(), resp.GetEndBlockNumber()) {
+			resp.DeletedObjectNumber++
+		} else {
+			resp.FailedObjectNumber++
+		}
+	}
+	t.retryFailedGCObjects()
+	log.CtxInfow(t.ctx, "succeed to gc objects", "end_block", resp.GetEndBlockNumber(),
+		"deleted_object_number", resp.GetDeletedObjectNumber(), "failed_object_number", resp.GetFailedObjectNumber())
+	return resp, nil
+}
+
+// gcObject is used to gc the segment and ec pieces of an object, and record the outcome to sp-db.
+// The object is skipped if it has been deleted successfully, e.g. the gc task is dispatched again, and the
+// object which has failed before is only retried by the gc task claiming it, so that its retry count is kept.
+// Returns false if some pieces are failed to delete.
+func (t *gcObjectTask) gcObject(objectInfo *storagetypes.ObjectInfo, blockNumber uint64) bool {
+	objectID := objectInfo.Id.Uint64()
+	progress, err := t.taskNode.spDB.GetGCObjectProgress(objectID)
+	if err == nil && progress.Status == sqldb.GCObjectStatusDeleted {
+		log.CtxDebugw(t.ctx, "skip gc object which has been deleted", "object_id", objectID)
+		return true
+	}
+	if err == nil {
+		log.CtxDebugw(t.ctx, "skip gc object which is left to the failed object retry", "object_id", objectID,
+			"retry_count", progress.RetryCount)
+		return false
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		log.CtxWarnw(t.ctx, "failed to query gc object progress", "object_id", objectID, "error", err)
+	}
+
+	var failedKeys []string
+	var lastErr error
+	for _, key := range t.generateGCKeyList(objectInfo) {
+		if err = t.taskNode.pieceStore.DeletePiece(key); err != nil {
+			log.CtxWarnw(t.ctx, "failed to delete piece", "object_id", objectID, "key", key, "error", err)
+			failedKeys = append(failedKeys, key)
+			lastErr = err
+		}
+	}
+
+	progress = &sqldb.GCObjectProgress{
+		ObjectID:    objectID,
+		BlockNumber: blockNumber,
+		Status:      sqldb.GCObjectStatusDeleted,
+	}
+	if len(failedKeys) != 0 {
+		progress.Status = sqldb.GCObjectStatusFailed
+		progress.FailedPieceKeys = failedKeys
+		progress.ErrorDescription = lastErr.Error()
+	}
+	if err = t.taskNode.spDB.SetGCObjectProgress(progress); err != nil {
+		log.CtxErrorw(t.ctx, "failed to persist gc object progress", "object_id", objectID, "error", err)
+	}
+	if len(failedKeys) != 0 {
+		log.CtxErrorw(t.ctx, "failed to gc object piece store, will retry later", "object_info", objectInfo,
+			"failed_piece_number", len(failedKeys))
+		return false
+	}
+	log.CtxInfow(t.ctx, "succeed to gc object piece store", "object_info", objectInfo)
+	return true
+}
+
+// retryFailedGCObjects retries to delete the pieces which are failed to delete in previous gc tasks, the failed
+// objects are claimed in sp-db first, so the parallel gc tasks never retry the same object.
+func (t *gcObjectTask) retryFailedGCObjects() {
+	progresses, err := t.taskNode.spDB.ClaimFailedGCObjects(defaultGCObjectMaxRetry, defaultGCRetryObjectNumberPerTask,
+		defaultGCObjectRetryLease)
+	if err != nil {
+		log.CtxErrorw(t.ctx, "failed to claim failed gc objects", "error", err)
+		return
+	}
+	for _, progress := range progresses {
+		var failedKeys []string
+		var lastErr error
+		for _, key := range progress.FailedPieceKeys {
+			if err = t.taskNode.pieceStore.DeletePiece(key); err != nil {
+				failedKeys = append(failedKeys, key)
+				lastErr = err
+			}
+		}
+		progress.RetryCount++
+		progress.FailedPieceKeys = failedKeys
+		if len(failedKeys) == 0 {
+			progress.Status = sqldb.GCObjectStatusDeleted
+			progress.ErrorDescription = ""
+		} else {
+			progress.Status = sqldb.GCObjectStatusFailed
+			progress.ErrorDescription = lastErr.Error()
+		}
+		if err = t.taskNode.spDB.SetGCObjectProgress(progress); err != nil {
+			log.CtxErrorw(t.ctx, "failed to persist gc object progress", "object_id", progress.ObjectID, "error", err)
+			continue
+		}
+		log.CtxInfow(t.ctx, "retry to gc object", "object_id", progress.ObjectID, "retry_count", progress.RetryCount,
+			"remaining_failed_piece_number", len(failedKeys))
+	}
+}
+
+// generateGCKeyList is used to generate the segment piece keys and the ec piece keys of the object
+// which should be deleted by this sp.
+func (t *gcObjectTask) generateGCKeyList(objectInfo *storagetypes.ObjectInfo) []string {
+	maxSegmentSize := t.storageParams.VersionedParams.GetMaxSegmentSize()
+	keyList := piecestore.GenerateObjectSegmentKeyList(objectInfo.Id.Uint64(), objectInfo.GetPayloadSize(), maxSegmentSize)
+	if objectInfo.GetRedundancyType() != storagetypes.REDUNDANCY_REPLICA_TYPE {
+		return keyList
+	}
+	for redundancyIndex, address := range objectInfo.GetSecondarySpAddresses() {
+		if strings.Compare(t.taskNode.config.SpOperatorAddress, address) == 0 {
+			keyList = append(keyList, piecestore.GenerateObjectECKeyList(
+				objectInfo.Id.Uint64(), objectInfo.GetPayloadSize(), maxSegmentSize, uint64(redundancyIndex))...)
+		}
+	}
+	return keyList
+}
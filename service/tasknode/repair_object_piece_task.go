@@ -0,0 +1,252 @@
+package tasknode
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/bnb-chain/greenfield-common/go/hash"
+	"github.com/bnb-chain/greenfield-common/go/redundancy"
+	storagetypes "github.com/bnb-chain/greenfield/x/storage/types"
+
+	"github.com/bnb-chain/greenfield-storage-provider/model"
+	merrors "github.com/bnb-chain/greenfield-storage-provider/model/errors"
+	"github.com/bnb-chain/greenfield-storage-provider/model/piecestore"
+	"github.com/bnb-chain/greenfield-storage-provider/pkg/log"
+	"github.com/bnb-chain/greenfield-storage-provider/pkg/rcmgr"
+	gatewayclient "github.com/bnb-chain/greenfield-storage-provider/service/gateway/client"
+	"github.com/bnb-chain/greenfield-storage-provider/service/tasknode/types"
+	"github.com/bnb-chain/greenfield-storage-provider/store/sqldb"
+	"github.com/bnb-chain/greenfield-storage-provider/util"
+)
+
+var errMismatchRepairedPiece = errors.New("repaired piece mismatches the integrity meta")
+
+// repairObjectPieceTask repairs the lost or corrupt pieces of an object stored by this sp. The segment is fetched
+// from the primary sp, or reconstructed from the pieces of the secondary sps if the primary sp is unavailable or
+// is this sp, then the piece of this sp is re-encoded from the segment, verified against the integrity meta and
+// stored in the piece store.
+type repairObjectPieceTask struct {
+	ctx           context.Context
+	taskNode      *TaskNode
+	objectID      uint64
+	primary       bool
+	redundancyIdx uint32
+	objectInfo    *storagetypes.ObjectInfo
+	storageParams *storagetypes.Params
+	integrity     *sqldb.IntegrityMeta
+	getter        *gatewayclient.RecoveryPieceGetter
+}
+
+// newRepairObjectPieceTask returns a repairObjectPieceTask instance
+func newRepairObjectPieceTask(ctx context.Context, task *TaskNode, req *types.RepairObjectPieceRequest) *repairObjectPieceTask {
+	return &repairObjectPieceTask{
+		ctx:           ctx,
+		taskNode:      task,
+		objectID:      req.GetObjectId(),
+		primary:       req.GetPrimary(),
+		redundancyIdx: req.GetRedundancyIdx(),
+	}
+}
+
+// init is used to load the object info, the storage params and the integrity meta, and check this sp is the
+// primary sp of the object, or the secondary sp of the object at the redundancy index
+func (t *repairObjectPieceTask) init() error {
+	var err error
+	if t.objectInfo, err = t.taskNode.chain.QueryObjectInfoByID(t.ctx, util.Uint64ToString(t.objectID)); err != nil {
+		log.CtxErrorw(t.ctx, "failed to query object info", "error", err)
+		return err
+	}
+	if t.objectInfo.GetObjectStatus() != storagetypes.OBJECT_STATUS_SEALED {
+		log.CtxErrorw(t.ctx, "failed to repair object which is not sealed", "object_status", t.objectInfo.GetObjectStatus())
+		return merrors.ErrCheckObjectSealed
+	}
+	if t.primary {
+		bucketInfo, err := t.taskNode.chain.QueryBucketInfo(t.ctx, t.objectInfo.GetBucketName())
+		if err != nil {
+			log.CtxErrorw(t.ctx, "failed to query bucket info", "error", err)
+			return err
+		}
+		if strings.Compare(bucketInfo.GetPrimarySpAddress(), t.taskNode.config.SpOperatorAddress) != 0 {
+			log.CtxErrorw(t.ctx, "failed to repair object whose primary sp is not this sp",
+				"primary_sp", bucketInfo.GetPrimarySpAddress())
+			return merrors.ErrSPMismatch
+		}
+	} else {
+		secondarySPs := t.objectInfo.GetSecondarySpAddresses()
+		if int(t.redundancyIdx) >= len(secondarySPs) ||
+			strings.Compare(secondarySPs[t.redundancyIdx], t.taskNode.config.SpOperatorAddress) != 0 {
+			log.CtxErrorw(t.ctx, "failed to repair object which is not stored by this sp at the redundancy index",
+				"secondary_sps", secondarySPs)
+			return merrors.ErrSPMismatch
+		}
+	}
+	if t.storageParams, err = t.taskNode.spDB.GetStorageParams(); err != nil {
+		log.CtxErrorw(t.ctx, "failed to query storage params", "error", err)
+		return err
+	}
+	if t.integrity, err = t.taskNode.spDB.GetObjectIntegrity(t.objectID); err != nil {
+		log.CtxErrorw(t.ctx, "failed to get integrity meta", "error", err)
+		return err
+	}
+	if err = hash.VerifyIntegrityHash(t.objectInfo.GetChecksums()[t.checksumIdx()], t.integrity.Checksum); err != nil {
+		log.CtxErrorw(t.ctx, "failed to verify integrity meta", "error", err)
+		return err
+	}
+	t.getter = gatewayclient.NewRecoveryPieceGetter(t.objectInfo, t.taskNode.config.SpOperatorAddress,
+		t.taskNode.spDB, t.taskNode.signer)
+	return nil
+}
+
+// execute checks the pieces of the object one by one, and repairs the lost or corrupt ones, the memory of one
+// segment is reserved with low priority so that repairing will not starve the replicate tasks.
+func (t *repairObjectPieceTask) execute() (*types.RepairObjectPieceResponse, error) {
+	var (
+		resp         = &types.RepairObjectPieceResponse{}
+		segmentCount = piecestore.ComputeSegmentCount(t.objectInfo.GetPayloadSize(),
+			t.storageParams.VersionedParams.GetMaxSegmentSize())
+		// the pieces from other sps, the segment and the re-encoded pieces of one segment are held in memory
+		approximateMemSize = int(t.storageParams.VersionedParams.GetMaxSegmentSize()) * 3
+	)
+	if int(segmentCount) != len(t.integrity.Checksum) {
+		log.CtxErrorw(t.ctx, "failed to repair object due to mismatch checksum number",
+			"segment_count", segmentCount, "checksum_number", len(t.integrity.Checksum))
+		return nil, merrors.ErrMismatchChecksumNum
+	}
+	scopeSpan, err := t.taskNode.rcScope.BeginSpan()
+	if err != nil {
+		log.CtxErrorw(t.ctx, "failed to begin span", "error", err)
+		return nil, err
+	}
+	defer func() {
+		scopeSpan.Done()
+		log.CtxDebugw(t.ctx, "release memory to resource manager",
+			"release_size", approximateMemSize, "resource_state", rcmgr.GetServiceState(model.TaskNodeService))
+	}()
+	if err = scopeSpan.ReserveMemory(approximateMemSize, rcmgr.ReservationPriorityLow); err != nil {
+		log.CtxErrorw(t.ctx, "failed to reserve memory from resource manager",
+			"reserve_size", approximateMemSize, "error", err)
+		return nil, err
+	}
+
+	for segmentIdx := uint32(0); segmentIdx < segmentCount; segmentIdx++ {
+		key := t.pieceKey(segmentIdx)
+		data, err := t.taskNode.pieceStore.GetPiece(t.ctx, key, 0, 0)
+		if err == nil && bytes.Equal(hash.GenerateChecksum(data), t.integrity.Checksum[segmentIdx]) {
+			continue
+		}
+		log.CtxWarnw(t.ctx, "piece is lost or corrupt, try to repair it", "piece_key", key, "error", err)
+		if data, err = t.repairPiece(segmentIdx); err != nil {
+			log.CtxErrorw(t.ctx, "failed to repair piece", "piece_key", key, "error", err)
+			return resp, err
+		}
+		if !bytes.Equal(hash.GenerateChecksum(data), t.integrity.Checksum[segmentIdx]) {
+			log.CtxErrorw(t.ctx, "failed to verify repaired piece", "piece_key", key)
+			return resp, errMismatchRepairedPiece
+		}
+		if err = t.taskNode.pieceStore.PutPiece(key, data); err != nil {
+			log.CtxErrorw(t.ctx, "failed to put repaired piece", "piece_key", key, "error", err)
+			return resp, err
+		}
+		resp.RepairedPieceNumber++
+		log.CtxInfow(t.ctx, "succeed to repair piece", "piece_key", key)
+	}
+	log.CtxInfow(t.ctx, "succeed to repair object", "segment_count", segmentCount,
+		"repaired_piece_number", resp.GetRepairedPieceNumber())
+	return resp, nil
+}
+
+// checksumIdx returns the index of the integrity hash of this sp in the checksums of the object, the integrity
+// hash of the primary sp is the first checksum, and the secondary sp's is the checksum at redundancyIdx+1.
+func (t *repairObjectPieceTask) checksumIdx() int {
+	if t.primary {
+		return 0
+	}
+	return int(t.redundancyIdx) + 1
+}
+
+// pieceKey returns the key of the piece stored by this sp, the secondary sp stores the ec pieces if the object
+// is ec type, otherwise stores the segment pieces.
+func (t *repairObjectPieceTask) pieceKey(segmentIdx uint32) string {
+	if !t.primary && t.objectInfo.GetRedundancyType() == storagetypes.REDUNDANCY_EC_TYPE {
+		return piecestore.EncodeECPieceKey(t.objectID, segmentIdx, t.redundancyIdx)
+	}
+	return piecestore.EncodeSegmentPieceKey(t.objectID, segmentIdx)
+}
+
+// repairPiece gets the segment and re-encodes the piece of this sp.
+func (t *repairObjectPieceTask) repairPiece(segmentIdx uint32) ([]byte, error) {
+	segment, err := t.getSegment(segmentIdx)
+	if err != nil {
+		return nil, err
+	}
+	if t.primary || t.objectInfo.GetRedundancyType() != storagetypes.REDUNDANCY_EC_TYPE {
+		return segment, nil
+	}
+	pieces, err := redundancy.EncodeRawSegment(segment,
+		int(t.storageParams.VersionedParams.GetRedundantDataChunkNum()),
+		int(t.storageParams.VersionedParams.GetRedundantParityChunkNum()))
+	if err != nil {
+		return nil, err
+	}
+	return pieces[t.redundancyIdx], nil
+}
+
+// getSegment gets the segment from the primary sp if this sp is a secondary sp, if failed, gets the segment from
+// the other secondary sps if the object is replica type, otherwise gets enough ec pieces from the other secondary
+// sps and decodes the segment.
+func (t *repairObjectPieceTask) getSegment(segmentIdx uint32) ([]byte, error) {
+	var err error
+	if !t.primary {
+		bucketInfo, err := t.taskNode.chain.QueryBucketInfo(t.ctx, t.objectInfo.GetBucketName())
+		if err != nil {
+			log.CtxWarnw(t.ctx, "failed to query bucket info", "error", err)
+		} else {
+			// the integrity hash of the primary sp is the first checksum of the object
+			segment, err := t.getter.GetRecoveryPiece(t.ctx, bucketInfo.GetPrimarySpAddress(), segmentIdx, -1, 0)
+			if err == nil {
+				return segment, nil
+			}
+		}
+	}
+
+	var (
+		dataChunkNum   = int(t.storageParams.VersionedParams.GetRedundantDataChunkNum())
+		parityChunkNum = int(t.storageParams.VersionedParams.GetRedundantParityChunkNum())
+		pieces         = make([][]byte, dataChunkNum+parityChunkNum)
+		succeedNumber  int
+	)
+	for redundancyIdx, spAddress := range t.objectInfo.GetSecondarySpAddresses() {
+		if !t.primary && uint32(redundancyIdx) == t.redundancyIdx {
+			continue
+		}
+		if t.objectInfo.GetRedundancyType() != storagetypes.REDUNDANCY_EC_TYPE {
+			segment, err := t.getter.GetRecoveryPiece(t.ctx, spAddress, segmentIdx, -1, redundancyIdx+1)
+			if err == nil {
+				return segment, nil
+			}
+			continue
+		}
+		if succeedNumber >= dataChunkNum || redundancyIdx >= len(pieces) {
+			break
+		}
+		if pieces[redundancyIdx], err = t.getter.GetRecoveryPiece(t.ctx, spAddress, segmentIdx,
+			int32(redundancyIdx), redundancyIdx+1); err != nil {
+			continue
+		}
+		succeedNumber++
+	}
+	if t.objectInfo.GetRedundancyType() != storagetypes.REDUNDANCY_EC_TYPE || succeedNumber < dataChunkNum {
+		return nil, gatewayclient.ErrInsufficientRecoveryPieces
+	}
+	// the lost pieces are passed as empty bytes
+	for idx := range pieces {
+		if pieces[idx] == nil {
+			pieces[idx] = []byte{}
+		}
+	}
+	segmentSize := piecestore.ComputeSegmentSize(t.objectInfo.GetPayloadSize(), segmentIdx,
+		t.storageParams.VersionedParams.GetMaxSegmentSize())
+	return redundancy.DecodeRawSegment(pieces, int64(segmentSize), dataChunkNum, parityChunkNum)
+}
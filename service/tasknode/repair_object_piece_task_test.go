@@ -0,0 +1,77 @@
+package tasknode
+
+import (
+	"context"
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	"github.com/bnb-chain/greenfield-common/go/hash"
+	storagetypes "github.com/bnb-chain/greenfield/x/storage/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/greenfield-storage-provider/model"
+	merrors "github.com/bnb-chain/greenfield-storage-provider/model/errors"
+	"github.com/bnb-chain/greenfield-storage-provider/pkg/rcmgr"
+	psclient "github.com/bnb-chain/greenfield-storage-provider/store/piecestore/client"
+	"github.com/bnb-chain/greenfield-storage-provider/store/piecestore/storage"
+	"github.com/bnb-chain/greenfield-storage-provider/store/sqldb"
+)
+
+func setupRepairObjectPieceTask(t *testing.T, redundancyType storagetypes.RedundancyType,
+	pieces ...[]byte) *repairObjectPieceTask {
+	pieceStore, err := psclient.NewStoreClient(&storage.PieceStoreConfig{
+		Store: storage.ObjectStorageConfig{Storage: "file", BucketURL: t.TempDir() + "/"},
+	})
+	assert.Nil(t, err)
+	rcScope, err := rcmgr.ResrcManager().OpenService(model.TaskNodeService)
+	assert.Nil(t, err)
+	var checksums [][]byte
+	for _, piece := range pieces {
+		checksums = append(checksums, hash.GenerateChecksum(piece))
+	}
+	return &repairObjectPieceTask{
+		ctx:           context.TODO(),
+		taskNode:      &TaskNode{pieceStore: pieceStore, rcScope: rcScope},
+		objectID:      1,
+		redundancyIdx: 2,
+		objectInfo: &storagetypes.ObjectInfo{
+			Id:             sdkmath.NewUint(1),
+			PayloadSize:    uint64(16 * len(pieces)),
+			RedundancyType: redundancyType,
+		},
+		storageParams: &storagetypes.Params{
+			VersionedParams: storagetypes.VersionedParams{MaxSegmentSize: 16},
+		},
+		integrity: &sqldb.IntegrityMeta{ObjectID: 1, Checksum: checksums},
+	}
+}
+
+func TestRepairObjectPieceTask_PieceKey(t *testing.T) {
+	task := setupRepairObjectPieceTask(t, storagetypes.REDUNDANCY_EC_TYPE, []byte("piece"))
+	assert.Equal(t, "1_s3_p2", task.pieceKey(3))
+	assert.Equal(t, 3, task.checksumIdx())
+	task.objectInfo.RedundancyType = storagetypes.REDUNDANCY_REPLICA_TYPE
+	assert.Equal(t, "1_s3", task.pieceKey(3))
+
+	// the primary sp stores the segment pieces whose integrity hash is the first checksum
+	task.objectInfo.RedundancyType = storagetypes.REDUNDANCY_EC_TYPE
+	task.primary = true
+	assert.Equal(t, "1_s3", task.pieceKey(3))
+	assert.Equal(t, 0, task.checksumIdx())
+}
+
+func TestRepairObjectPieceTask_SkipIntactPieces(t *testing.T) {
+	task := setupRepairObjectPieceTask(t, storagetypes.REDUNDANCY_EC_TYPE, []byte("piece0"), []byte("piece1"))
+	assert.Nil(t, task.taskNode.pieceStore.PutPiece("1_s0_p2", []byte("piece0")))
+	assert.Nil(t, task.taskNode.pieceStore.PutPiece("1_s1_p2", []byte("piece1")))
+	resp, err := task.execute()
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(0), resp.GetRepairedPieceNumber())
+}
+
+func TestRepairObjectPieceTask_MismatchChecksumNumber(t *testing.T) {
+	task := setupRepairObjectPieceTask(t, storagetypes.REDUNDANCY_EC_TYPE, []byte("piece0"))
+	task.objectInfo.PayloadSize = 40
+	_, err := task.execute()
+	assert.Equal(t, merrors.ErrMismatchChecksumNum, err)
+}
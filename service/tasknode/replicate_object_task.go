@@ -26,7 +26,6 @@ import (
 	"github.com/bnb-chain/greenfield-storage-provider/model/piecestore"
 	"github.com/bnb-chain/greenfield-storage-provider/pkg/log"
 	p2ptypes "github.com/bnb-chain/greenfield-storage-provider/pkg/p2p/types"
-	"github.com/bnb-chain/greenfield-storage-provider/util/maps"
 )
 
 // PieceDataReader defines [][]pieceData Reader.
@@ -114,6 +113,7 @@ func (r *pieceDataReplicator) replicate() (integrityHash []byte, signature []byt
 	if err != nil {
 		log.Errorw("failed to verify sp signature",
 			"sp", r.sp.GetApprovalAddress(), "endpoint", r.sp.GetEndpoint(), "error", err)
+		err = merrors.ErrInvalidSecondarySignature
 		return
 	}
 
@@ -178,7 +178,12 @@ func (t *replicateObjectTask) init() error {
 		log.CtxErrorw(t.ctx, "failed to get approvals", "error", err)
 		return err
 	}
-	t.sortedSpEndpoints = maps.SortKeys(t.approvalResponseMap)
+	t.sortedSpEndpoints = t.taskNode.reputation.sortSPs(t.ctx, t.spMap, t.approvalResponseMap)
+	if len(t.sortedSpEndpoints) < t.redundancyNumber {
+		log.CtxErrorw(t.ctx, "failed to init due to healthy sp is not enough",
+			"healthy_sp_number", len(t.sortedSpEndpoints))
+		return merrors.ErrSPNumber
+	}
 
 	// calculate the reserve memory, which is used in execute time
 	if t.objectInfo.GetRedundancyType() == storagetypes.REDUNDANCY_REPLICA_TYPE {
@@ -270,7 +275,7 @@ func (t *replicateObjectTask) execute(waitCh chan error) {
 	pickSp := func() (sp *sptypes.StorageProvider, approval *p2ptypes.GetApprovalResponse, err error) {
 		t.mux.Lock()
 		defer t.mux.Unlock()
-		if len(t.approvalResponseMap) == 0 {
+		if len(t.sortedSpEndpoints) == 0 {
 			log.CtxError(t.ctx, "backup storage providers exhausted")
 			err = merrors.ErrExhaustedSP
 			return
@@ -336,7 +341,9 @@ func (t *replicateObjectTask) execute(waitCh chan error) {
 						sp:       sp,
 						approval: approval,
 					}
+					replicateStartTime := time.Now()
 					integrityHash, signature, innerErr := r.replicate()
+					t.taskNode.reputation.recordReplication(t.ctx, sp, t.replicateDataSize, time.Since(replicateStartTime), innerErr)
 					if innerErr != nil {
 						log.CtxErrorw(t.ctx, "failed to replicate piece stream", "redundancy_index", rIdx, "error", innerErr)
 						return
@@ -368,6 +375,10 @@ func (t *replicateObjectTask) execute(waitCh chan error) {
 
 	// seal onto the greenfield chain
 	if getNeedReplicateNumber(succeedIndexMap) == 0 { // succeed
+		// persist the secondary sp signatures, so the seal can be recovered without replicating again
+		if err = t.taskNode.spDB.SetSecondarySpSignatures(t.objectInfo.Id.Uint64(), sealMsg.GetSecondarySpSignatures()); err != nil {
+			log.CtxErrorw(t.ctx, "failed to persist secondary sp signatures", "error", err)
+		}
 		retry := 0
 		for {
 			if retry >= MaxSealRetryNumber {
@@ -0,0 +1,136 @@
+package tasknode
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	sptypes "github.com/bnb-chain/greenfield/x/sp/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	merrors "github.com/bnb-chain/greenfield-storage-provider/model/errors"
+	"github.com/bnb-chain/greenfield-storage-provider/pkg/log"
+	p2ptypes "github.com/bnb-chain/greenfield-storage-provider/pkg/p2p/types"
+	"github.com/bnb-chain/greenfield-storage-provider/store/sqldb"
+	"github.com/bnb-chain/greenfield-storage-provider/util/maps"
+)
+
+// reputationSmoothingFactor defines the weight of the latest sample in the moving averages of latency and throughput
+const reputationSmoothingFactor = 0.2
+
+// spReputationKeeper records the replication statistics of secondary sps in sp-db, and sorts the approved
+// secondary sps by their scores, so that the replication tasks prefer the healthy sps.
+type spReputationKeeper struct {
+	spDB   sqldb.SPDB
+	config *SPReputationConfig
+}
+
+// newSPReputationKeeper returns an instance of spReputationKeeper
+func newSPReputationKeeper(spDB sqldb.SPDB, config *SPReputationConfig) *spReputationKeeper {
+	if config == nil {
+		config = DefaultSPReputationConfig
+	}
+	return &spReputationKeeper{spDB: spDB, config: config}
+}
+
+// sortSPs removes the sps in cooling-off period from spMap and approvalMap, and returns the endpoints of
+// the left sps in descending order of score.
+func (k *spReputationKeeper) sortSPs(ctx context.Context, spMap map[string]*sptypes.StorageProvider,
+	approvalMap map[string]*p2ptypes.GetApprovalResponse) []string {
+	endpoints := maps.SortKeys(approvalMap)
+	addresses := make([]string, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		addresses = append(addresses, spMap[endpoint].GetOperatorAddress())
+	}
+	reputations, err := k.spDB.ListSPReputations(addresses)
+	if err != nil {
+		// the replication should not be blocked by the reputation
+		log.CtxWarnw(ctx, "failed to list sp reputations, use the default order", "error", err)
+		return endpoints
+	}
+	reputationMap := make(map[string]*sqldb.SPReputation, len(reputations))
+	for _, reputation := range reputations {
+		reputationMap[reputation.OperatorAddress] = reputation
+	}
+
+	now := time.Now().Unix()
+	healthyEndpoints := make([]string, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		reputation := reputationMap[spMap[endpoint].GetOperatorAddress()]
+		if reputation != nil && reputation.CoolingUntil > now {
+			log.CtxInfow(ctx, "skip the sp in cooling-off period", "sp", reputation.OperatorAddress,
+				"endpoint", endpoint, "cooling_until", reputation.CoolingUntil)
+			delete(spMap, endpoint)
+			delete(approvalMap, endpoint)
+			continue
+		}
+		healthyEndpoints = append(healthyEndpoints, endpoint)
+	}
+	sort.SliceStable(healthyEndpoints, func(i, j int) bool {
+		ri := reputationMap[spMap[healthyEndpoints[i]].GetOperatorAddress()]
+		rj := reputationMap[spMap[healthyEndpoints[j]].GetOperatorAddress()]
+		if si, sj := scoreSP(ri), scoreSP(rj); si != sj {
+			return si > sj
+		}
+		return throughputOf(ri) > throughputOf(rj)
+	})
+	return healthyEndpoints
+}
+
+// scoreSP returns the score of sp in [0, 1], which is the laplace smoothed success rate punished by the
+// signature verification failures, the sp without reputation gets the neutral score 0.5.
+func scoreSP(reputation *sqldb.SPReputation) float64 {
+	if reputation == nil {
+		return 0.5
+	}
+	successRate := float64(reputation.SuccessCount+1) / float64(reputation.SuccessCount+reputation.FailureCount+2)
+	return successRate / float64(reputation.SignatureFailureCount+1)
+}
+
+// throughputOf returns the average throughput of sp, 0 if the sp has no reputation
+func throughputOf(reputation *sqldb.SPReputation) int64 {
+	if reputation == nil {
+		return 0
+	}
+	return reputation.AverageThroughput
+}
+
+// recordReplication updates the reputation of sp by the outcome of replicating dataSize bytes to it, the sp
+// enters the cooling-off period if its signature is invalid or it fails too many times in a row. The
+// replication cancelled by the task is not the fault of sp, so it is not recorded.
+func (k *spReputationKeeper) recordReplication(ctx context.Context, sp *sptypes.StorageProvider,
+	dataSize int64, latency time.Duration, replicateErr error) {
+	if replicateErr != nil && (ctx.Err() != nil || errors.Is(replicateErr, context.Canceled) ||
+		status.Code(replicateErr) == codes.Canceled) {
+		log.CtxDebugw(ctx, "skip recording the cancelled replication", "sp", sp.GetOperatorAddress(),
+			"error", replicateErr)
+		return
+	}
+	replication := &sqldb.SPReplication{
+		OperatorAddress:        sp.GetOperatorAddress(),
+		Success:                replicateErr == nil,
+		SmoothingFactor:        reputationSmoothingFactor,
+		MaxConsecutiveFailures: k.config.MaxConsecutiveFailures,
+		CoolingUntil:           time.Now().Unix() + k.config.CoolingSeconds,
+		SignatureFailureDecay:  k.config.SignatureFailureDecay,
+	}
+	if replication.Success {
+		replication.Latency = latency.Milliseconds()
+		if replication.Latency <= 0 {
+			replication.Latency = 1
+		}
+		replication.Throughput = dataSize * 1000 / replication.Latency
+	} else {
+		replication.SignatureFailure = errors.Is(replicateErr, merrors.ErrInvalidSecondarySignature)
+	}
+	if err := k.spDB.RecordSPReplication(replication); err != nil {
+		log.CtxErrorw(ctx, "failed to record sp replication", "sp", sp.GetOperatorAddress(), "error", err)
+		return
+	}
+	if replication.SignatureFailure {
+		log.CtxWarnw(ctx, "sp enters cooling-off period", "sp", sp.GetOperatorAddress(),
+			"endpoint", sp.GetEndpoint(), "cooling_until", replication.CoolingUntil, "error", replicateErr)
+	}
+}
@@ -0,0 +1,105 @@
+package tasknode
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	sptypes "github.com/bnb-chain/greenfield/x/sp/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	merrors "github.com/bnb-chain/greenfield-storage-provider/model/errors"
+	p2ptypes "github.com/bnb-chain/greenfield-storage-provider/pkg/p2p/types"
+	"github.com/bnb-chain/greenfield-storage-provider/store/sqldb"
+)
+
+func TestSPReputationKeeper_RecordReplication(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	spDB := sqldb.NewMockSPDB(ctrl)
+	keeper := newSPReputationKeeper(spDB, nil)
+	sp := &sptypes.StorageProvider{OperatorAddress: "sp1"}
+
+	spDB.EXPECT().RecordSPReplication(gomock.Any()).DoAndReturn(func(replication *sqldb.SPReplication) error {
+		assert.True(t, replication.Success)
+		assert.Equal(t, int64(500), replication.Latency)
+		assert.Equal(t, int64(2048), replication.Throughput)
+		assert.Equal(t, DefaultSPReputationConfig.SignatureFailureDecay, replication.SignatureFailureDecay)
+		return nil
+	})
+	keeper.recordReplication(context.TODO(), sp, 1024, 500*time.Millisecond, nil)
+
+	spDB.EXPECT().RecordSPReplication(gomock.Any()).DoAndReturn(func(replication *sqldb.SPReplication) error {
+		assert.False(t, replication.Success)
+		assert.True(t, replication.SignatureFailure)
+		assert.Equal(t, DefaultSPReputationConfig.MaxConsecutiveFailures, replication.MaxConsecutiveFailures)
+		assert.Greater(t, replication.CoolingUntil, time.Now().Unix())
+		return nil
+	})
+	keeper.recordReplication(context.TODO(), sp, 1024, time.Second, merrors.ErrInvalidSecondarySignature)
+
+	spDB.EXPECT().RecordSPReplication(gomock.Any()).DoAndReturn(func(replication *sqldb.SPReplication) error {
+		assert.False(t, replication.Success)
+		assert.False(t, replication.SignatureFailure)
+		return errors.New("mock db error")
+	})
+	keeper.recordReplication(context.TODO(), sp, 1024, time.Second, errors.New("mock replicate error"))
+}
+
+func TestSPReputationKeeper_SkipCancelledReplication(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	spDB := sqldb.NewMockSPDB(ctrl)
+	spDB.EXPECT().RecordSPReplication(gomock.Any()).Times(0)
+	keeper := newSPReputationKeeper(spDB, nil)
+	sp := &sptypes.StorageProvider{OperatorAddress: "sp1"}
+
+	keeper.recordReplication(context.TODO(), sp, 1024, time.Second, context.Canceled)
+	keeper.recordReplication(context.TODO(), sp, 1024, time.Second, status.Error(codes.Canceled, "canceled"))
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	keeper.recordReplication(ctx, sp, 1024, time.Second, errors.New("mock replicate error"))
+}
+
+func TestSPReputationKeeper_SortSPs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	spDB := sqldb.NewMockSPDB(ctrl)
+	keeper := newSPReputationKeeper(spDB, nil)
+	spMap := map[string]*sptypes.StorageProvider{
+		"endpoint1": {OperatorAddress: "sp1"},
+		"endpoint2": {OperatorAddress: "sp2"},
+		"endpoint3": {OperatorAddress: "sp3"},
+		"endpoint4": {OperatorAddress: "sp4"},
+	}
+	approvalMap := map[string]*p2ptypes.GetApprovalResponse{
+		"endpoint1": {}, "endpoint2": {}, "endpoint3": {}, "endpoint4": {},
+	}
+	spDB.EXPECT().ListSPReputations([]string{"sp1", "sp2", "sp3", "sp4"}).Return([]*sqldb.SPReputation{
+		{OperatorAddress: "sp1", SuccessCount: 1, FailureCount: 9},
+		{OperatorAddress: "sp2", SuccessCount: 9, FailureCount: 1},
+		{OperatorAddress: "sp3", SuccessCount: 9, CoolingUntil: time.Now().Unix() + 60},
+	}, nil)
+
+	// the sp in cooling-off period is removed, and the sp without reputation gets the neutral score
+	assert.Equal(t, []string{"endpoint2", "endpoint4", "endpoint1"}, keeper.sortSPs(context.TODO(), spMap, approvalMap))
+	assert.NotContains(t, spMap, "endpoint3")
+	assert.NotContains(t, approvalMap, "endpoint3")
+
+	// the default order is used if the reputations are unavailable
+	spDB.EXPECT().ListSPReputations(gomock.Any()).Return(nil, errors.New("mock db error"))
+	assert.Equal(t, []string{"endpoint1", "endpoint2", "endpoint4"}, keeper.sortSPs(context.TODO(), spMap, approvalMap))
+}
+
+func TestScoreSP(t *testing.T) {
+	assert.Equal(t, 0.5, scoreSP(nil))
+	healthy := &sqldb.SPReputation{SuccessCount: 8}
+	assert.Equal(t, 0.9, scoreSP(healthy))
+	// the signature failures punish the score until they decay
+	healthy.SignatureFailureCount = 1
+	assert.Equal(t, 0.45, scoreSP(healthy))
+}
@@ -20,7 +20,6 @@ import (
 	"github.com/bnb-chain/greenfield-storage-provider/pkg/rcmgr"
 	gatewayclient "github.com/bnb-chain/greenfield-storage-provider/service/gateway/client"
 	servicetypes "github.com/bnb-chain/greenfield-storage-provider/service/types"
-	"github.com/bnb-chain/greenfield-storage-provider/util/maps"
 	sptypes "github.com/bnb-chain/greenfield/x/sp/types"
 	"github.com/bnb-chain/greenfield/x/storage/types"
 	storagetypes "github.com/bnb-chain/greenfield/x/storage/types"
@@ -33,6 +32,9 @@ const (
 	ReplicateFactor = 1
 	// GetApprovalTimeout defines the timeout of getting secondary sp approval
 	GetApprovalTimeout = 10
+	// MaxGetApprovalRounds defines max rounds of getting secondary sp approval, the later rounds only
+	// solicit the sps which have not responded
+	MaxGetApprovalRounds = 2
 	// MaxSealRetryNumber defines max number of retrying seal object
 	MaxSealRetryNumber = 3
 )
@@ -189,6 +191,7 @@ func (r *streamPieceDataReplicator) replicate() (integrityHash []byte, signature
 	if err != nil {
 		log.Errorw("failed to verify sp signature",
 			"sp", r.sp.GetApprovalAddress(), "endpoint", r.sp.GetEndpoint(), "error", err)
+		err = merrors.ErrInvalidSecondarySignature
 		return
 	}
 
@@ -253,7 +256,12 @@ func (t *streamReplicateObjectTask) init() error {
 		log.CtxErrorw(t.ctx, "failed to get approvals", "error", err)
 		return err
 	}
-	t.sortedSpEndpoints = maps.SortKeys(t.approvalResponseMap)
+	t.sortedSpEndpoints = t.taskNode.reputation.sortSPs(t.ctx, t.spMap, t.approvalResponseMap)
+	if len(t.sortedSpEndpoints) < t.redundancyNumber {
+		log.CtxErrorw(t.ctx, "failed to init due to healthy sp is not enough",
+			"healthy_sp_number", len(t.sortedSpEndpoints))
+		return merrors.ErrSPNumber
+	}
 	// calculate the reserve memory, which is used in execute time
 	t.approximateMemSize = int(float64(t.storageParams.VersionedParams.GetMaxSegmentSize()) *
 		(float64(t.redundancyNumber)/float64(t.storageParams.VersionedParams.GetRedundantDataChunkNum()) + 1))
@@ -336,7 +344,7 @@ func (t *streamReplicateObjectTask) execute(waitCh chan error) {
 	pickSp := func() (sp *sptypes.StorageProvider, approval *p2ptypes.GetApprovalResponse, err error) {
 		t.mux.Lock()
 		defer t.mux.Unlock()
-		if len(t.approvalResponseMap) == 0 {
+		if len(t.sortedSpEndpoints) == 0 {
 			log.CtxError(t.ctx, "backup storage providers exhausted")
 			err = merrors.ErrExhaustedSP
 			return
@@ -400,7 +408,9 @@ func (t *streamReplicateObjectTask) execute(waitCh chan error) {
 					sp:                    sp,
 					approval:              approval,
 				}
+				replicateStartTime := time.Now()
 				integrityHash, signature, innerErr := r.replicate()
+				t.taskNode.reputation.recordReplication(t.ctx, sp, t.replicateDataSize, time.Since(replicateStartTime), innerErr)
 				if innerErr != nil {
 					log.CtxErrorw(t.ctx, "failed to replicate piece stream", "redundancy_index", rIdx, "error", innerErr)
 					return
@@ -429,6 +439,10 @@ func (t *streamReplicateObjectTask) execute(waitCh chan error) {
 
 	// seal onto the greenfield chain
 	if isAllSucceed(succeedIndexMap) {
+		// persist the secondary sp signatures, so the seal can be recovered without replicating again
+		if err = t.taskNode.spDB.SetSecondarySpSignatures(t.objectInfo.Id.Uint64(), sealMsg.GetSecondarySpSignatures()); err != nil {
+			log.CtxErrorw(t.ctx, "failed to persist secondary sp signatures", "error", err)
+		}
 		retry := 0
 		for {
 			if retry >= MaxSealRetryNumber {
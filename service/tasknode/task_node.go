@@ -4,6 +4,7 @@ import (
 	"context"
 	"net"
 
+	storagetypes "github.com/bnb-chain/greenfield/x/storage/types"
 	lru "github.com/hashicorp/golang-lru"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
@@ -14,7 +15,9 @@ import (
 	"github.com/bnb-chain/greenfield-storage-provider/pkg/log"
 	"github.com/bnb-chain/greenfield-storage-provider/pkg/metrics"
 	"github.com/bnb-chain/greenfield-storage-provider/pkg/rcmgr"
+	metadataclient "github.com/bnb-chain/greenfield-storage-provider/service/metadata/client"
 	p2pclient "github.com/bnb-chain/greenfield-storage-provider/service/p2p/client"
+	p2pservicetypes "github.com/bnb-chain/greenfield-storage-provider/service/p2p/types"
 	signerclient "github.com/bnb-chain/greenfield-storage-provider/service/signer/client"
 	"github.com/bnb-chain/greenfield-storage-provider/service/tasknode/types"
 	psclient "github.com/bnb-chain/greenfield-storage-provider/store/piecestore/client"
@@ -24,18 +27,26 @@ import (
 
 var _ lifecycle.Service = &TaskNode{}
 
+// p2pClient defines the p2p service methods used by TaskNode, implemented by p2pclient.P2PClient
+type p2pClient interface {
+	GetApproval(ctx context.Context, object *storagetypes.ObjectInfo, expected int64, timeout int64,
+		excludedSPs []string, opts ...grpc.CallOption) (*p2pservicetypes.GetApprovalResponse, error)
+	Close() error
+}
+
 // TaskNode as background min execution unit, execute storage provider's background tasks
 // implements the gRPC of TaskNodeService,
-// TODO :: TaskNode support more task types, such as gc etc.
 type TaskNode struct {
 	config     *TaskNodeConfig
 	cache      *lru.Cache
 	signer     *signerclient.SignerClient
-	p2p        *p2pclient.P2PClient
+	p2p        p2pClient
+	metadata   *metadataclient.MetadataClient
 	spDB       sqldb.SPDB
 	chain      *greenfield.Greenfield
 	rcScope    rcmgr.ResourceScope
 	pieceStore *psclient.StoreClient
+	reputation *spReputationKeeper
 	grpcServer *grpc.Server
 }
 
@@ -65,6 +76,10 @@ func NewTaskNodeService(cfg *TaskNodeConfig) (*TaskNode, error) {
 		log.Errorw("failed to create p2p server client", "error", err)
 		return nil, err
 	}
+	if taskNode.metadata, err = metadataclient.NewMetadataClient(cfg.MetadataGrpcAddress); err != nil {
+		log.Errorw("failed to create metadata client", "error", err)
+		return nil, err
+	}
 	if taskNode.chain, err = greenfield.NewGreenfield(cfg.ChainConfig); err != nil {
 		log.Errorw("failed to create chain client", "error", err)
 		return nil, err
@@ -73,6 +88,7 @@ func NewTaskNodeService(cfg *TaskNodeConfig) (*TaskNode, error) {
 		log.Errorw("failed to create sp db client", "error", err)
 		return nil, err
 	}
+	taskNode.reputation = newSPReputationKeeper(taskNode.spDB, cfg.SPReputationConfig)
 	if taskNode.rcScope, err = rcmgr.ResrcManager().OpenService(model.TaskNodeService); err != nil {
 		log.Errorw("failed to open task node resource scope", "error", err)
 		return nil, err
@@ -99,6 +115,7 @@ func (taskNode *TaskNode) Stop(ctx context.Context) error {
 	taskNode.grpcServer.GracefulStop()
 	taskNode.signer.Close()
 	taskNode.p2p.Close()
+	taskNode.metadata.Close()
 	taskNode.chain.Close()
 	taskNode.rcScope.Release()
 	return nil
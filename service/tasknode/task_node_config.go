@@ -8,11 +8,31 @@ import (
 
 // TaskNodeConfig defines TaskNode service config
 type TaskNodeConfig struct {
-	SpOperatorAddress string
-	GRPCAddress       string
-	SignerGrpcAddress string
-	P2PGrpcAddress    string
-	SpDBConfig        *config.SQLDBConfig
-	PieceStoreConfig  *storage.PieceStoreConfig
-	ChainConfig       *greenfield.GreenfieldChainConfig
+	SpOperatorAddress   string
+	GRPCAddress         string
+	SignerGrpcAddress   string
+	P2PGrpcAddress      string
+	MetadataGrpcAddress string
+	SpDBConfig          *config.SQLDBConfig
+	PieceStoreConfig    *storage.PieceStoreConfig
+	ChainConfig         *greenfield.GreenfieldChainConfig
+	SPReputationConfig  *SPReputationConfig
+}
+
+// SPReputationConfig defines the secondary sp reputation config
+type SPReputationConfig struct {
+	// MaxConsecutiveFailures defines the number of consecutive replication failures after which
+	// the secondary sp enters the cooling-off period
+	MaxConsecutiveFailures uint32
+	// CoolingSeconds defines the duration of cooling-off period, in which the secondary sp is not picked
+	CoolingSeconds int64
+	// SignatureFailureDecay defines the number of successful replications after which one signature
+	// failure of the secondary sp is forgiven, the signature failures never decay if it is 0
+	SignatureFailureDecay uint64
+}
+
+var DefaultSPReputationConfig = &SPReputationConfig{
+	MaxConsecutiveFailures: 3,
+	CoolingSeconds:         10 * 60,
+	SignatureFailureDecay:  100,
 }
@@ -2,6 +2,7 @@ package tasknode
 
 import (
 	"context"
+	"strconv"
 
 	merrors "github.com/bnb-chain/greenfield-storage-provider/model/errors"
 	"github.com/bnb-chain/greenfield-storage-provider/pkg/log"
@@ -64,6 +65,37 @@ func (taskNode *TaskNode) ReplicateObject(ctx context.Context, req *types.Replic
 	return resp, nil
 }
 
+// GCObject release the pieces of the deleted objects in a block range from piece store, the memory of the
+// gc task is accounted by resource manager, so several gc tasks can be processed by task nodes in parallel
+func (taskNode *TaskNode) GCObject(ctx context.Context, req *types.GCObjectRequest) (*types.GCObjectResponse, error) {
+	ctx = log.WithValue(ctx, "job_id", strconv.FormatUint(req.GetJobId(), 10))
+	task, err := newGCObjectTask(ctx, taskNode, req)
+	if err != nil {
+		log.CtxErrorw(ctx, "failed to new gc object task", "start_block", req.GetStartBlockNumber(),
+			"end_block", req.GetEndBlockNumber(), "error", err)
+		return nil, err
+	}
+	if err = task.init(); err != nil {
+		log.CtxErrorw(ctx, "failed to init gc object task", "error", err)
+		return nil, err
+	}
+	return task.execute()
+}
+
+// RepairObjectPiece repair the lost or corrupt pieces of an object stored by this sp, the pieces are recovered
+// from the primary sp or the other secondary sps and verified against the local integrity meta
+func (taskNode *TaskNode) RepairObjectPiece(ctx context.Context, req *types.RepairObjectPieceRequest) (
+	*types.RepairObjectPieceResponse, error) {
+	ctx = log.WithValue(ctx, "object_id", strconv.FormatUint(req.GetObjectId(), 10))
+	task := newRepairObjectPieceTask(ctx, taskNode, req)
+	if err := task.init(); err != nil {
+		log.CtxErrorw(ctx, "failed to init repair object piece task", "primary", req.GetPrimary(),
+			"redundancy_idx", req.GetRedundancyIdx(), "error", err)
+		return nil, err
+	}
+	return task.execute()
+}
+
 // QueryReplicatingObject query a replicating object information by object id
 func (taskNode *TaskNode) QueryReplicatingObject(ctx context.Context, req *types.QueryReplicatingObjectRequest) (
 	resp *types.QueryReplicatingObjectResponse, err error) {
@@ -79,16 +111,48 @@ func (taskNode *TaskNode) QueryReplicatingObject(ctx context.Context, req *types
 	return
 }
 
+// getApproval solicits at most high approvals of secondary sps in MaxGetApprovalRounds rounds, the sps which
+// have accepted or refused are not solicited again. It proceeds once at least low approvals are collected, and
+// aborts early if low can not be met even if all the silent sps accept.
 func (taskNode *TaskNode) getApproval(
 	objectInfo *storagetypes.ObjectInfo, low, high int, timeout int64) (
 	map[string]*sptypes.StorageProvider, map[string]*p2ptypes.GetApprovalResponse, error) {
 	var (
 		spList       = make(map[string]*sptypes.StorageProvider)
 		approvalList = make(map[string]*p2ptypes.GetApprovalResponse)
+		approvals    = make(map[string]*p2ptypes.GetApprovalResponse)
+		excludedSPs  []string
 	)
-	approvals, _, err := taskNode.p2p.GetApproval(context.Background(), objectInfo, int64(high), timeout)
-	if err != nil {
-		return spList, approvalList, err
+	for round := 1; round <= MaxGetApprovalRounds; round++ {
+		result, err := taskNode.p2p.GetApproval(context.Background(), objectInfo, int64(high-len(approvals)),
+			timeout, excludedSPs)
+		if err != nil {
+			return spList, approvalList, err
+		}
+		for spOpAddr, approval := range result.GetAccept() {
+			approvals[spOpAddr] = approval
+			excludedSPs = append(excludedSPs, spOpAddr)
+		}
+		for spOpAddr, refusal := range result.GetRefuse() {
+			log.Infow("sp refused approval", "object_id", objectInfo.Id.Uint64(), "sp", spOpAddr,
+				"reason", refusal.GetRefusedReason())
+			excludedSPs = append(excludedSPs, spOpAddr)
+		}
+		log.Infow("finish getting approval", "object_id", objectInfo.Id.Uint64(), "round", round,
+			"accept", len(result.GetAccept()), "refuse", len(result.GetRefuse()), "timeout", result.GetTimeout(),
+			"pending", result.GetPending(), "unreachable", result.GetUnreachable())
+		// proceed with fewer backup secondary sps rather than waiting for another round
+		if len(approvals) >= low {
+			break
+		}
+		// abort early if the quorum can not be met even if all the silent sps which received the request accept,
+		// the unreachable sps are not counted because they are unlikely to be reconnected in the next round
+		if silent := len(result.GetTimeout()) + len(result.GetPending()); len(approvals)+silent < low {
+			log.Errorw("abort getting approval due to insufficient sps", "object_id", objectInfo.Id.Uint64(),
+				"accept", len(approvals), "silent", silent, "unreachable", len(result.GetUnreachable()),
+				"expected", low)
+			break
+		}
 	}
 	if len(approvals) < low {
 		return spList, approvalList, merrors.ErrSPApprovalNumber
@@ -0,0 +1,103 @@
+package tasknode
+
+import (
+	"context"
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	sptypes "github.com/bnb-chain/greenfield/x/sp/types"
+	storagetypes "github.com/bnb-chain/greenfield/x/storage/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+
+	merrors "github.com/bnb-chain/greenfield-storage-provider/model/errors"
+	p2ptypes "github.com/bnb-chain/greenfield-storage-provider/pkg/p2p/types"
+	p2pservicetypes "github.com/bnb-chain/greenfield-storage-provider/service/p2p/types"
+	"github.com/bnb-chain/greenfield-storage-provider/store/sqldb"
+)
+
+// mockP2PClient returns the approval results round by round, and records the requests
+type mockP2PClient struct {
+	results  []*p2pservicetypes.GetApprovalResponse
+	expected []int64
+	excluded [][]string
+}
+
+func (m *mockP2PClient) GetApproval(ctx context.Context, object *storagetypes.ObjectInfo, expected int64,
+	timeout int64, excludedSPs []string, opts ...grpc.CallOption) (*p2pservicetypes.GetApprovalResponse, error) {
+	m.expected = append(m.expected, expected)
+	m.excluded = append(m.excluded, append([]string{}, excludedSPs...))
+	result := m.results[0]
+	m.results = m.results[1:]
+	return result, nil
+}
+
+func (m *mockP2PClient) Close() error { return nil }
+
+func mockApprovals(sps ...string) map[string]*p2ptypes.GetApprovalResponse {
+	approvals := make(map[string]*p2ptypes.GetApprovalResponse, len(sps))
+	for _, sp := range sps {
+		approvals[sp] = &p2ptypes.GetApprovalResponse{SpOperatorAddress: sp}
+	}
+	return approvals
+}
+
+func setupGetApprovalTest(t *testing.T, results ...*p2pservicetypes.GetApprovalResponse) (*TaskNode, *mockP2PClient) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+	spDB := sqldb.NewMockSPDB(ctrl)
+	spDB.EXPECT().GetSpByAddress(gomock.Any(), sqldb.OperatorAddressType).DoAndReturn(
+		func(address string, addressType sqldb.SpAddressType) (*sptypes.StorageProvider, error) {
+			return &sptypes.StorageProvider{OperatorAddress: address, Endpoint: "https://" + address}, nil
+		}).AnyTimes()
+	client := &mockP2PClient{results: results}
+	return &TaskNode{p2p: client, spDB: spDB}, client
+}
+
+func TestGetApproval_SecondRound(t *testing.T) {
+	taskNode, client := setupGetApprovalTest(t,
+		&p2pservicetypes.GetApprovalResponse{
+			Accept:  mockApprovals("sp1"),
+			Refuse:  mockApprovals("sp2"),
+			Timeout: []string{"sp3", "sp4"},
+		},
+		&p2pservicetypes.GetApprovalResponse{
+			Accept: mockApprovals("sp3", "sp4"),
+		})
+	spMap, approvalMap, err := taskNode.getApproval(&storagetypes.ObjectInfo{Id: sdkmath.NewUint(1)}, 2, 4, 1)
+	assert.Nil(t, err)
+	assert.Len(t, spMap, 3)
+	assert.Len(t, approvalMap, 3)
+	assert.Contains(t, approvalMap, "https://sp4")
+	// the second round only solicits the missing approvals from the sps not responding
+	assert.Equal(t, []int64{4, 3}, client.expected)
+	assert.ElementsMatch(t, []string{"sp1", "sp2"}, client.excluded[1])
+}
+
+func TestGetApproval_AbortEarly(t *testing.T) {
+	// the unreachable sps are not counted in the quorum, so the second round is not tried
+	taskNode, client := setupGetApprovalTest(t,
+		&p2pservicetypes.GetApprovalResponse{
+			Accept:      mockApprovals("sp1"),
+			Timeout:     []string{"sp2"},
+			Unreachable: []string{"sp3", "sp4"},
+		})
+	_, _, err := taskNode.getApproval(&storagetypes.ObjectInfo{Id: sdkmath.NewUint(1)}, 3, 6, 1)
+	assert.Equal(t, merrors.ErrSPApprovalNumber, err)
+	assert.Len(t, client.expected, 1)
+}
+
+func TestGetApproval_InsufficientAfterRounds(t *testing.T) {
+	taskNode, client := setupGetApprovalTest(t,
+		&p2pservicetypes.GetApprovalResponse{
+			Accept:  mockApprovals("sp1"),
+			Timeout: []string{"sp2", "sp3"},
+		},
+		&p2pservicetypes.GetApprovalResponse{
+			Timeout: []string{"sp2", "sp3"},
+		})
+	_, _, err := taskNode.getApproval(&storagetypes.ObjectInfo{Id: sdkmath.NewUint(1)}, 2, 4, 1)
+	assert.Equal(t, merrors.ErrSPApprovalNumber, err)
+	assert.Len(t, client.expected, MaxGetApprovalRounds)
+}
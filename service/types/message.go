@@ -0,0 +1,39 @@
+package types
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// presignedURLSignPrefix defines the prefix of the pre-signed url string to sign, which keeps the signature
+// of the operator from being taken as the one of any other message
+const presignedURLSignPrefix = "GNFD-PRESIGNED-URL"
+
+// GetSignBytes returns the recovery piece approval bytes to sign over.
+func (m *RecoveryPieceApproval) GetSignBytes() []byte {
+	fakeMsg := &RecoveryPieceApproval{
+		ObjectId:          m.GetObjectId(),
+		SpOperatorAddress: m.GetSpOperatorAddress(),
+		ExpiredTime:       m.GetExpiredTime(),
+	}
+	bz, _ := json.Marshal(fakeMsg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSignBytes returns the pre-signed url bytes to sign over, the method, the domain of the storage provider
+// and the chain id are bound so that the url can not be replayed to other methods, providers or chains.
+func (m *PresignedURL) GetSignBytes() []byte {
+	return []byte(strings.Join([]string{
+		presignedURLSignPrefix,
+		m.GetMethod(),
+		m.GetDomain(),
+		m.GetChainId(),
+		m.GetBucketName(),
+		m.GetObjectName(),
+		m.GetUserAddress(),
+		strconv.FormatInt(m.GetExpires(), 10),
+	}, "\n"))
+}
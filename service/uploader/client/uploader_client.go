@@ -67,15 +67,17 @@ func (client *UploaderClient) PutObject(ctx context.Context, opts ...grpc.CallOp
 	return client.uploader.PutObject(ctx, opts...)
 }
 
-// QueryUploadProgress is used to query upload object progress
-func (client *UploaderClient) QueryUploadProgress(ctx context.Context, objectID uint64, opts ...grpc.CallOption) (servicetypes.JobState, error) {
+// QueryUploadProgress is used to query upload object progress, the response contains the job state and
+// the indexes of the segments which have been uploaded
+func (client *UploaderClient) QueryUploadProgress(ctx context.Context, objectID uint64, opts ...grpc.CallOption) (
+	*types.QueryUploadProgressResponse, error) {
 	resp, err := client.uploader.QueryUploadProgress(ctx, &types.QueryUploadProgressRequest{ObjectId: objectID}, opts...)
 	if err != nil {
 		errStatus, _ := status.FromError(err)
 		if codes.NotFound == errStatus.Code() {
-			return servicetypes.JobState_JOB_STATE_INIT_UNSPECIFIED, merrors.ErrNoSuchObject
+			return nil, merrors.ErrNoSuchObject
 		}
-		return servicetypes.JobState_JOB_STATE_INIT_UNSPECIFIED, err
+		return nil, err
 	}
-	return resp.GetState(), nil
+	return resp, nil
 }
@@ -3,27 +3,37 @@ package uploader
 import (
 	"bytes"
 	"context"
+	"errors"
 	"io"
 	"math"
+	"sort"
 
 	"github.com/bnb-chain/greenfield-common/go/hash"
 	merrors "github.com/bnb-chain/greenfield-storage-provider/model/errors"
+	"github.com/bnb-chain/greenfield-storage-provider/model/piecestore"
 	"github.com/bnb-chain/greenfield-storage-provider/pkg/log"
 	payloadstream "github.com/bnb-chain/greenfield-storage-provider/pkg/stream"
 	servicetypes "github.com/bnb-chain/greenfield-storage-provider/service/types"
 	"github.com/bnb-chain/greenfield-storage-provider/service/uploader/types"
 	"github.com/bnb-chain/greenfield-storage-provider/store/sqldb"
 	storagetypes "github.com/bnb-chain/greenfield/x/storage/types"
+	"gorm.io/gorm"
 )
 
 var _ types.UploaderServiceServer = &Uploader{}
 
-// PutObject upload an object payload data with object info.
+// PutObject upload an object payload data with object info. The payload is split into segment pieces, the
+// checksum of every piece is persisted once the piece is stored, so that an interrupted upload can be resumed
+// from the missing segments with an offset. The re-uploaded segment is validated against the checksum recorded
+// before, and the integrity hash is only signed once every segment is present. If some segments are still
+// missing at the end of the stream, their indexes are returned in the response.
 func (uploader *Uploader) PutObject(stream types.UploaderService_PutObjectServer) (err error) {
 	var (
-		isInited          bool
-		pieceChecksumList [][]byte
-		objectID          uint64
+		isInited     bool
+		segmentCount uint32
+		objectID     uint64
+		// uploadedChecksums records the checksums of the segments uploaded by the previous streams
+		uploadedChecksums map[uint32][]byte
 		objectInfo        *storagetypes.ObjectInfo
 		params            *storagetypes.Params
 		req               *types.PutObjectRequest
@@ -40,11 +50,34 @@ func (uploader *Uploader) PutObject(stream types.UploaderService_PutObjectServer
 				uploader.spDB.UpdateJobState(objectID, servicetypes.JobState_JOB_STATE_UPLOAD_OBJECT_ERROR)
 			}
 			log.CtxErrorw(ctx, "failed to put object", "error", err)
+			err = merrors.InnerErrorToGRPCError(err)
+			return
+		}
+		if !isInited {
+			err = merrors.ErrDanglingPointer
+			log.CtxErrorw(ctx, "failed to put object due to empty stream", "error", err)
+			return
+		}
+		pieceChecksumList, missingSegments, checkErr := uploader.getPieceChecksumList(objectID, segmentCount)
+		if checkErr != nil {
+			err = checkErr
+			log.CtxErrorw(ctx, "failed to get piece checksums", "error", err)
+			return
+		}
+		if len(missingSegments) != 0 {
+			resp.MissingSegmentIndexes = missingSegments
+			err = stream.SendAndClose(resp)
+			pstream.Close()
+			log.CtxWarnw(ctx, "put partial object, wait for the missing segments",
+				"missing_segment_number", len(missingSegments), "segment_count", segmentCount, "error", err)
 			return
 		}
 		if err = uploader.signIntegrityHash(ctx, objectID, objectInfo.GetChecksums()[0], pieceChecksumList); err != nil {
 			return
 		}
+		if deleteErr := uploader.spDB.DeleteAllPieceChecksums(objectID); deleteErr != nil {
+			log.CtxWarnw(ctx, "failed to delete piece checksums", "error", deleteErr)
+		}
 		if err = uploader.taskNode.ReplicateObject(ctx, objectInfo); err != nil {
 			log.CtxErrorw(ctx, "failed to notify task node to replicate object", "error", err)
 			uploader.spDB.UpdateJobState(objectID, servicetypes.JobState_JOB_STATE_REPLICATE_OBJECT_ERROR)
@@ -58,6 +91,7 @@ func (uploader *Uploader) PutObject(stream types.UploaderService_PutObjectServer
 		log.Errorw("failed to get storage params", "error", err)
 		return
 	}
+	segmentSize := params.VersionedParams.GetMaxSegmentSize()
 
 	// read payload from gRPC stream
 	go func() {
@@ -82,17 +116,23 @@ func (uploader *Uploader) PutObject(stream types.UploaderService_PutObjectServer
 				if int(params.VersionedParams.GetRedundantDataChunkNum()+params.VersionedParams.GetRedundantParityChunkNum()+1) !=
 					len(objectInfo.GetChecksums()) {
 					errCh <- merrors.ErrMismatchChecksumNum
+					return
 				}
 				objectID = objectInfo.Id.Uint64()
+				segmentCount = piecestore.ComputeSegmentCount(objectInfo.GetPayloadSize(), segmentSize)
 				ctx = log.WithValue(ctx, "object_id", objectInfo.Id.String())
-				pstream.InitAsyncPayloadStream(
+				var initErr error
+				if uploadedChecksums, initErr = uploader.initUploadJob(ctx, objectInfo, req.GetOffset(), segmentSize); initErr != nil {
+					errCh <- initErr
+					return
+				}
+				pstream.InitAsyncPayloadStreamFromSegment(
 					objectID,
 					storagetypes.REDUNDANCY_REPLICA_TYPE,
-					params.VersionedParams.GetMaxSegmentSize(),
+					segmentSize,
 					math.MaxUint32, /*useless*/
+					uint32(req.GetOffset()/segmentSize),
 				)
-				uploader.spDB.CreateUploadJob(objectInfo)
-				uploader.spDB.UpdateJobState(objectID, servicetypes.JobState_JOB_STATE_UPLOAD_OBJECT_DOING)
 				isInited = true
 			}
 			pstream.StreamWrite(req.GetPayload())
@@ -112,21 +152,124 @@ func (uploader *Uploader) PutObject(stream types.UploaderService_PutObjectServer
 				err = entry.Error()
 				return
 			}
-			pieceChecksumList = append(pieceChecksumList, hash.GenerateChecksum(entry.Data()))
+			if err = checkSegmentSize(objectInfo.GetPayloadSize(), segmentSize, entry.SegmentIndex(),
+				uint64(len(entry.Data()))); err != nil {
+				log.CtxErrorw(ctx, "failed to check segment size", "segment_index", entry.SegmentIndex(),
+					"piece_len", len(entry.Data()), "error", err)
+				return
+			}
+			checksum := hash.GenerateChecksum(entry.Data())
+			if err = checkSegmentChecksum(uploadedChecksums, entry.SegmentIndex(), checksum); err != nil {
+				log.CtxErrorw(ctx, "failed to check segment checksum", "segment_index", entry.SegmentIndex(), "error", err)
+				return
+			}
 			if err = uploader.pieceStore.PutPiece(entry.PieceKey(), entry.Data()); err != nil {
 				return
 			}
+			if err = uploader.spDB.SetPieceChecksum(objectID, entry.SegmentIndex(), checksum); err != nil {
+				return
+			}
 		case err = <-errCh:
 			return
 		}
 	}
 }
 
+// initUploadJob checks the upload offset, creates the upload job if the object is uploaded for the first time,
+// a resumed upload is only allowed while the job is still in uploading. Returns the checksums of the segments
+// which have been uploaded before.
+func (uploader *Uploader) initUploadJob(ctx context.Context, objectInfo *storagetypes.ObjectInfo, offset uint64,
+	segmentSize uint64) (map[uint32][]byte, error) {
+	if offset%segmentSize != 0 || offset >= objectInfo.GetPayloadSize() {
+		log.CtxErrorw(ctx, "failed to check upload offset", "offset", offset, "segment_size", segmentSize,
+			"payload_size", objectInfo.GetPayloadSize())
+		return nil, merrors.ErrInvalidUploadOffset
+	}
+	objectID := objectInfo.Id.Uint64()
+	job, err := uploader.spDB.GetJobByObjectID(objectID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if err != nil {
+		if offset != 0 {
+			log.CtxErrorw(ctx, "failed to resume uploading object which has not been uploaded", "offset", offset)
+			return nil, merrors.ErrInvalidUploadOffset
+		}
+		if _, err = uploader.spDB.CreateUploadJob(objectInfo); err != nil {
+			return nil, err
+		}
+	} else if job.GetJobState() != servicetypes.JobState_JOB_STATE_INIT_UNSPECIFIED &&
+		job.GetJobState() != servicetypes.JobState_JOB_STATE_UPLOAD_OBJECT_DOING &&
+		job.GetJobState() != servicetypes.JobState_JOB_STATE_UPLOAD_OBJECT_ERROR {
+		log.CtxErrorw(ctx, "failed to put object which has been uploaded", "job_state", job.GetJobState())
+		return nil, merrors.ErrCheckObjectCreated
+	}
+	if err = uploader.spDB.UpdateJobState(objectID, servicetypes.JobState_JOB_STATE_UPLOAD_OBJECT_DOING); err != nil {
+		return nil, err
+	}
+	return uploader.spDB.GetAllPieceChecksums(objectID)
+}
+
+// getPieceChecksumList returns the piece checksums order by segment index, and the indexes of the segments
+// which have not been uploaded, the checksum list is only returned if every segment is present.
+func (uploader *Uploader) getPieceChecksumList(objectID uint64, segmentCount uint32) (
+	pieceChecksumList [][]byte, missingSegments []uint32, err error) {
+	checksums, err := uploader.spDB.GetAllPieceChecksums(objectID)
+	if err != nil {
+		return nil, nil, err
+	}
+	for segmentIndex := uint32(0); segmentIndex < segmentCount; segmentIndex++ {
+		checksum, ok := checksums[segmentIndex]
+		if !ok {
+			missingSegments = append(missingSegments, segmentIndex)
+			continue
+		}
+		pieceChecksumList = append(pieceChecksumList, checksum)
+	}
+	if len(missingSegments) != 0 {
+		return nil, missingSegments, nil
+	}
+	return pieceChecksumList, nil, nil
+}
+
+// checkSegmentChecksum checks the checksum of a segment against the checksum recorded by the previous upload,
+// a segment which is uploaded again must carry the same data, otherwise the integrity hash would never match.
+func checkSegmentChecksum(uploadedChecksums map[uint32][]byte, segmentIndex uint32, checksum []byte) error {
+	uploaded, ok := uploadedChecksums[segmentIndex]
+	if ok && !bytes.Equal(uploaded, checksum) {
+		return merrors.ErrMismatchSegmentChecksum
+	}
+	return nil
+}
+
+// checkSegmentSize checks the size of a segment piece, all the segments are the max segment size
+// except the last one.
+func checkSegmentSize(payloadSize, segmentSize uint64, segmentIndex uint32, pieceSize uint64) error {
+	segmentCount := piecestore.ComputeSegmentCount(payloadSize, segmentSize)
+	if segmentIndex >= segmentCount {
+		return merrors.ErrMismatchSegmentSize
+	}
+	expectedSize := segmentSize
+	if segmentIndex == segmentCount-1 {
+		expectedSize = payloadSize - uint64(segmentIndex)*segmentSize
+	}
+	if pieceSize != expectedSize {
+		return merrors.ErrMismatchSegmentSize
+	}
+	return nil
+}
+
 func (uploader *Uploader) signIntegrityHash(ctx context.Context, objectID uint64, rootHash []byte, pieceChecksumList [][]byte) (err error) {
 	defer func() {
 		if err != nil {
 			log.CtxErrorw(ctx, "failed to sign the integrity hash", "error", err)
 			uploader.spDB.UpdateJobState(objectID, servicetypes.JobState_JOB_STATE_UPLOAD_OBJECT_ERROR)
+			if errors.Is(err, merrors.ErrMismatchIntegrityHash) {
+				// the uploaded segments are inconsistent with the object, the upload restarts from offset 0
+				if deleteErr := uploader.spDB.DeleteAllPieceChecksums(objectID); deleteErr != nil {
+					log.CtxWarnw(ctx, "failed to delete piece checksums", "error", deleteErr)
+				}
+			}
 			return
 		}
 		uploader.spDB.UpdateJobState(objectID, servicetypes.JobState_JOB_STATE_UPLOAD_OBJECT_DONE)
@@ -159,9 +302,25 @@ func (uploader *Uploader) QueryUploadProgress(ctx context.Context, req *types.Qu
 	if err != nil {
 		return nil, merrors.InnerErrorToGRPCError(err)
 	}
-	return &types.QueryUploadProgressResponse{
-		State: job.JobState,
-	}, nil
+	params, err := uploader.spDB.GetStorageParams()
+	if err != nil {
+		return nil, err
+	}
+	checksums, err := uploader.spDB.GetAllPieceChecksums(req.GetObjectId())
+	if err != nil {
+		return nil, err
+	}
+	resp = &types.QueryUploadProgressResponse{
+		State:       job.JobState,
+		SegmentSize: params.VersionedParams.GetMaxSegmentSize(),
+	}
+	for segmentIndex := range checksums {
+		resp.UploadedSegmentIndexes = append(resp.UploadedSegmentIndexes, segmentIndex)
+	}
+	sort.Slice(resp.UploadedSegmentIndexes, func(i, j int) bool {
+		return resp.UploadedSegmentIndexes[i] < resp.UploadedSegmentIndexes[j]
+	})
+	return resp, nil
 }
 
 // QueryPuttingObject query an uploading object with object id from cache
@@ -0,0 +1,74 @@
+package uploader
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	merrors "github.com/bnb-chain/greenfield-storage-provider/model/errors"
+	"github.com/bnb-chain/greenfield-storage-provider/store/sqldb"
+)
+
+func TestCheckSegmentSize(t *testing.T) {
+	cases := []struct {
+		name         string
+		segmentIndex uint32
+		pieceSize    uint64
+		wantedErr    error
+	}{
+		{
+			name:         "full segment",
+			segmentIndex: 0,
+			pieceSize:    16,
+		},
+		{
+			name:         "last segment",
+			segmentIndex: 2,
+			pieceSize:    8,
+		},
+		{
+			name:         "short middle segment",
+			segmentIndex: 1,
+			pieceSize:    8,
+			wantedErr:    merrors.ErrMismatchSegmentSize,
+		},
+		{
+			name:         "segment out of range",
+			segmentIndex: 3,
+			pieceSize:    16,
+			wantedErr:    merrors.ErrMismatchSegmentSize,
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantedErr, checkSegmentSize(40, 16, tt.segmentIndex, tt.pieceSize))
+		})
+	}
+}
+
+func TestCheckSegmentChecksum(t *testing.T) {
+	uploaded := map[uint32][]byte{0: []byte("a")}
+	assert.Nil(t, checkSegmentChecksum(uploaded, 0, []byte("a")))
+	assert.Nil(t, checkSegmentChecksum(uploaded, 1, []byte("b")))
+	assert.Equal(t, merrors.ErrMismatchSegmentChecksum, checkSegmentChecksum(uploaded, 0, []byte("b")))
+}
+
+func TestGetPieceChecksumList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	spDB := sqldb.NewMockSPDB(ctrl)
+	uploader := &Uploader{spDB: spDB}
+
+	spDB.EXPECT().GetAllPieceChecksums(uint64(1)).Return(map[uint32][]byte{0: []byte("a"), 2: []byte("c")}, nil)
+	checksums, missing, err := uploader.getPieceChecksumList(1, 4)
+	assert.Nil(t, err)
+	assert.Nil(t, checksums)
+	assert.Equal(t, []uint32{1, 3}, missing)
+
+	spDB.EXPECT().GetAllPieceChecksums(uint64(1)).Return(map[uint32][]byte{0: []byte("a"), 1: []byte("b")}, nil)
+	checksums, missing, err = uploader.getPieceChecksumList(1, 2)
+	assert.Nil(t, err)
+	assert.Empty(t, missing)
+	assert.Equal(t, [][]byte{[]byte("a"), []byte("b")}, checksums)
+}
@@ -38,6 +38,8 @@ type Metadata interface {
 	ListExpiredBucketsBySp(createAt int64, primarySpAddress string, limit int64) ([]*Bucket, error)
 	// GetObjectByName get object info by an object name
 	GetObjectByName(objectName string, bucketName string, isFullList bool) (*Object, error)
+	// GetObjectByID get object info by an object id, returns nil if the object is not found
+	GetObjectByID(objectID uint64, isFullList bool) (*Object, error)
 	// GetSwitchDBSignal check if there is a signal to switch the database
 	GetSwitchDBSignal() (*MasterDB, error)
 	// GetBucketMetaByName get bucket info with its related info
@@ -109,6 +109,21 @@ func (mr *MockMetadataMockRecorder) GetLatestBlockNumber() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestBlockNumber", reflect.TypeOf((*MockMetadata)(nil).GetLatestBlockNumber))
 }
 
+// GetObjectByID mocks base method.
+func (m *MockMetadata) GetObjectByID(objectID uint64, isFullList bool) (*Object, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetObjectByID", objectID, isFullList)
+	ret0, _ := ret[0].(*Object)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetObjectByID indicates an expected call of GetObjectByID.
+func (mr *MockMetadataMockRecorder) GetObjectByID(objectID, isFullList interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObjectByID", reflect.TypeOf((*MockMetadata)(nil).GetObjectByID), objectID, isFullList)
+}
+
 // GetObjectByName mocks base method.
 func (m *MockMetadata) GetObjectByName(objectName, bucketName string, isFullList bool) (*Object, error) {
 	m.ctrl.T.Helper()
@@ -402,6 +417,21 @@ func (mr *MockBSDBMockRecorder) GetLatestBlockNumber() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestBlockNumber", reflect.TypeOf((*MockBSDB)(nil).GetLatestBlockNumber))
 }
 
+// GetObjectByID mocks base method.
+func (m *MockBSDB) GetObjectByID(objectID uint64, isFullList bool) (*Object, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetObjectByID", objectID, isFullList)
+	ret0, _ := ret[0].(*Object)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetObjectByID indicates an expected call of GetObjectByID.
+func (mr *MockBSDBMockRecorder) GetObjectByID(objectID, isFullList interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObjectByID", reflect.TypeOf((*MockBSDB)(nil).GetObjectByID), objectID, isFullList)
+}
+
 // GetObjectByName mocks base method.
 func (m *MockBSDB) GetObjectByName(objectName, bucketName string, isFullList bool) (*Object, error) {
 	m.ctrl.T.Helper()
@@ -1,6 +1,12 @@
 package bsdb
 
-import "gorm.io/gorm"
+import (
+	"errors"
+	"math/big"
+
+	"github.com/forbole/juno/v4/common"
+	"gorm.io/gorm"
+)
 
 // ListObjectsByBucketName lists objects information by a bucket name.
 // The function takes the following parameters:
@@ -121,3 +127,36 @@ func (b *BsDBImpl) GetObjectByName(objectName string, bucketName string, isFullL
 		Take(&object).Error
 	return object, err
 }
+
+// GetObjectByID get object info by an object id, returns nil if the object is not found
+func (b *BsDBImpl) GetObjectByID(objectID uint64, isFullList bool) (*Object, error) {
+	var (
+		object       *Object
+		err          error
+		objectIDHash common.Hash
+	)
+
+	objectIDHash = common.BigToHash(new(big.Int).SetUint64(objectID))
+	if isFullList {
+		err = b.db.Table((&Object{}).TableName()).
+			Select("*").
+			Where("object_id = ?", objectIDHash).
+			Take(&object).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return object, err
+	}
+
+	err = b.db.Table((&Bucket{}).TableName()).
+		Select("objects.*").
+		Joins("left join objects on buckets.bucket_id = objects.bucket_id").
+		Where("objects.object_id = ? and "+
+			"((objects.visibility='VISIBILITY_TYPE_PUBLIC_READ') or (objects.visibility='VISIBILITY_TYPE_INHERIT' and buckets.visibility='VISIBILITY_TYPE_PUBLIC_READ'))",
+			objectIDHash).
+		Take(&object).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return object, err
+}
@@ -3,13 +3,21 @@ package client
 import (
 	"bytes"
 	"context"
+	"errors"
 	"io"
+	"strings"
 	"time"
 
+	"github.com/bnb-chain/greenfield-common/go/hash"
+	"gorm.io/gorm"
+
+	merrors "github.com/bnb-chain/greenfield-storage-provider/model/errors"
+	"github.com/bnb-chain/greenfield-storage-provider/model/piecestore"
 	"github.com/bnb-chain/greenfield-storage-provider/pkg/log"
 	"github.com/bnb-chain/greenfield-storage-provider/pkg/metrics"
 	"github.com/bnb-chain/greenfield-storage-provider/store/piecestore/piece"
 	"github.com/bnb-chain/greenfield-storage-provider/store/piecestore/storage"
+	"github.com/bnb-chain/greenfield-storage-provider/store/sqldb"
 )
 
 // PieceStoreAPI provides an interface to enable mocking the
@@ -22,24 +30,44 @@ type PieceStoreAPI interface {
 }
 
 type StoreClient struct {
-	ps *piece.PieceStore
+	ps          *piece.PieceStore
+	integrityDB sqldb.ObjectIntegrity
+}
+
+// StoreClientOption defines the option of StoreClient
+type StoreClientOption func(*StoreClient)
+
+// WithVerifyingRead enables the verifying read mode, the data of full piece reads are verified against
+// the piece checksums of the integrity meta in sp-db
+func WithVerifyingRead(integrityDB sqldb.ObjectIntegrity) StoreClientOption {
+	return func(client *StoreClient) {
+		client.integrityDB = integrityDB
+	}
 }
 
 const (
-	getPieceMethodName    = "getPiece"
-	putPieceMethodName    = "putPiece"
-	deletePieceMethodName = "deletePiece"
+	getPieceMethodName      = "getPiece"
+	putPieceMethodName      = "putPiece"
+	deletePieceMethodName   = "deletePiece"
+	listPiecesMethodName    = "listPieces"
+	listHotPiecesMethodName = "listHotPieces"
+	migratePieceMethodName  = "migratePiece"
 )
 
-func NewStoreClient(pieceConfig *storage.PieceStoreConfig) (*StoreClient, error) {
+func NewStoreClient(pieceConfig *storage.PieceStoreConfig, opts ...StoreClientOption) (*StoreClient, error) {
 	ps, err := piece.NewPieceStore(pieceConfig)
 	if err != nil {
 		return nil, err
 	}
-	return &StoreClient{ps: ps}, nil
+	client := &StoreClient{ps: ps}
+	for _, opt := range opts {
+		opt(client)
+	}
+	return client, nil
 }
 
-// GetPiece gets piece data from piece store.
+// GetPiece gets piece data from piece store, the full piece reads whose offset is 0 and limit <= 0 are
+// verified if the verifying read mode is enabled, and *merrors.PieceCorruptError is returned if mismatched.
 func (client *StoreClient) GetPiece(ctx context.Context, key string, offset, limit int64) ([]byte, error) {
 	startTime := time.Now()
 	defer func() {
@@ -59,9 +87,55 @@ func (client *StoreClient) GetPiece(ctx context.Context, key string, offset, lim
 		log.Errorw("failed to copy data", "error", err)
 		return nil, err
 	}
+	if client.integrityDB != nil && offset == 0 && limit <= 0 {
+		if err = verifyPiece(client.integrityDB, key, buf.Bytes()); err != nil {
+			return nil, err
+		}
+	}
 	return buf.Bytes(), nil
 }
 
+// decodePieceKey returns the object id and the segment index of the segment piece key or the ec piece key.
+func decodePieceKey(key string) (objectID uint64, segmentIndex uint32, err error) {
+	switch strings.Count(key, "_") {
+	case 1:
+		return piecestore.DecodeSegmentPieceKey(key)
+	case 2:
+		objectID, segmentIndex, _, err = piecestore.DecodeECPieceKey(key)
+		return objectID, segmentIndex, err
+	default:
+		return 0, 0, merrors.ErrInvalidObjectKey
+	}
+}
+
+// verifyPiece verifies the piece data against the piece checksum of the integrity meta in sp-db.
+func verifyPiece(integrityDB sqldb.ObjectIntegrity, key string, data []byte) error {
+	objectID, segmentIndex, err := decodePieceKey(key)
+	if err != nil {
+		return err
+	}
+	integrity, err := integrityDB.GetObjectIntegrity(objectID)
+	if err != nil {
+		log.Errorw("failed to get integrity meta to verify piece", "key", key, "error", err)
+		return err
+	}
+	if int(segmentIndex) >= len(integrity.Checksum) {
+		log.Errorw("failed to verify piece due to mismatch checksum number", "key", key,
+			"checksum_number", len(integrity.Checksum))
+		return merrors.ErrMismatchChecksumNum
+	}
+	checksum := hash.GenerateChecksum(data)
+	if !bytes.Equal(checksum, integrity.Checksum[segmentIndex]) {
+		log.Errorw("found corrupt piece", "key", key)
+		return &merrors.PieceCorruptError{
+			Key:              key,
+			ExpectedChecksum: integrity.Checksum[segmentIndex],
+			ActualChecksum:   checksum,
+		}
+	}
+	return nil
+}
+
 // PutPiece puts piece to piece store.
 func (client *StoreClient) PutPiece(key string, value []byte) error {
 	startTime := time.Now()
@@ -85,3 +159,67 @@ func (client *StoreClient) DeletePiece(key string) error {
 
 	return client.ps.Delete(context.Background(), key)
 }
+
+// ListAllPieces lists all the pieces whose key starts with prefix and is greater than marker from piece store,
+// the error of listing halfway is sent to the channel and checked by storage.ListError.
+func (client *StoreClient) ListAllPieces(ctx context.Context, prefix, marker string) (<-chan storage.Object, error) {
+	startTime := time.Now()
+	defer func() {
+		observer := metrics.PieceStoreTimeHistogram.WithLabelValues(listPiecesMethodName)
+		observer.Observe(time.Since(startTime).Seconds())
+		metrics.PieceStoreRequestTotal.WithLabelValues(listPiecesMethodName).Inc()
+	}()
+
+	return client.ps.ListAll(ctx, prefix, marker)
+}
+
+// ListAllHotPieces lists all the pieces in the hot tier whose key starts with prefix and is greater than marker,
+// merrors.ErrUnsupportedMethod is returned if the tiered storage is not configured. The error of listing halfway
+// is sent to the channel and checked by storage.ListError.
+func (client *StoreClient) ListAllHotPieces(ctx context.Context, prefix, marker string) (<-chan storage.Object, error) {
+	startTime := time.Now()
+	defer func() {
+		observer := metrics.PieceStoreTimeHistogram.WithLabelValues(listHotPiecesMethodName)
+		observer.Observe(time.Since(startTime).Seconds())
+		metrics.PieceStoreRequestTotal.WithLabelValues(listHotPiecesMethodName).Inc()
+	}()
+
+	return client.ps.ListAllHot(ctx, prefix, marker)
+}
+
+// MigratePiece moves the piece from the hot tier to the cold tier and returns the size of the moved piece.
+func (client *StoreClient) MigratePiece(ctx context.Context, key string) (int64, error) {
+	startTime := time.Now()
+	defer func() {
+		observer := metrics.PieceStoreTimeHistogram.WithLabelValues(migratePieceMethodName)
+		observer.Observe(time.Since(startTime).Seconds())
+		metrics.PieceStoreRequestTotal.WithLabelValues(migratePieceMethodName).Inc()
+	}()
+
+	return client.ps.Migrate(ctx, key)
+}
+
+// RepairVerifierDB is the sp-db used to verify the pieces while repairing the mirrored piece store
+type RepairVerifierDB interface {
+	sqldb.ObjectIntegrity
+	sqldb.GC
+}
+
+// NewRepairVerifier returns the storage.RepairVerifier which verifies the pieces against the piece checksums of
+// the integrity meta in sp-db. The pieces of the objects which have been processed by gc are regarded as deleted,
+// so that the copies left by a partially failed gc are removed instead of being resynced.
+func NewRepairVerifier(db RepairVerifierDB) storage.RepairVerifier {
+	return func(ctx context.Context, key string, data []byte) error {
+		objectID, _, err := decodePieceKey(key)
+		if err != nil {
+			return err
+		}
+		if _, err = db.GetGCObjectProgress(objectID); err == nil {
+			return storage.ErrObjectDeleted
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			log.CtxErrorw(ctx, "failed to query gc object progress to verify piece", "key", key, "error", err)
+			return err
+		}
+		return verifyPiece(db, key, data)
+	}
+}
@@ -0,0 +1,75 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bnb-chain/greenfield-common/go/hash"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	merrors "github.com/bnb-chain/greenfield-storage-provider/model/errors"
+	"github.com/bnb-chain/greenfield-storage-provider/store/piecestore/storage"
+	"github.com/bnb-chain/greenfield-storage-provider/store/sqldb"
+)
+
+func TestStoreClient_VerifyingRead(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	integrityDB := sqldb.NewMockSPDB(ctrl)
+	integrityDB.EXPECT().GetObjectIntegrity(uint64(1)).Return(&sqldb.IntegrityMeta{
+		ObjectID: 1,
+		Checksum: [][]byte{hash.GenerateChecksum([]byte("piece0")), hash.GenerateChecksum([]byte("piece1"))},
+	}, nil).AnyTimes()
+	client, err := NewStoreClient(&storage.PieceStoreConfig{
+		Store: storage.ObjectStorageConfig{Storage: "file", BucketURL: t.TempDir() + "/"},
+	}, WithVerifyingRead(integrityDB))
+	assert.Nil(t, err)
+	assert.Nil(t, client.PutPiece("1_s0", []byte("piece0")))
+	assert.Nil(t, client.PutPiece("1_s1_p2", []byte("xxxxx1")))
+	assert.Nil(t, client.PutPiece("1_s2", []byte("piece2")))
+
+	data, err := client.GetPiece(context.TODO(), "1_s0", 0, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("piece0"), data)
+
+	_, err = client.GetPiece(context.TODO(), "1_s1_p2", 0, 0)
+	assert.True(t, errors.Is(err, merrors.ErrPieceCorrupt))
+	var corruptErr *merrors.PieceCorruptError
+	assert.True(t, errors.As(err, &corruptErr))
+	assert.Equal(t, "1_s1_p2", corruptErr.Key)
+
+	// the ranged reads are not verified
+	data, err = client.GetPiece(context.TODO(), "1_s1_p2", 1, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("xx"), data)
+
+	_, err = client.GetPiece(context.TODO(), "1_s2", 0, 0)
+	assert.Equal(t, merrors.ErrMismatchChecksumNum, err)
+}
+
+func TestRepairVerifier(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	spDB := sqldb.NewMockSPDB(ctrl)
+	spDB.EXPECT().GetGCObjectProgress(uint64(1)).Return(nil, gorm.ErrRecordNotFound).AnyTimes()
+	spDB.EXPECT().GetObjectIntegrity(uint64(1)).Return(&sqldb.IntegrityMeta{
+		ObjectID: 1,
+		Checksum: [][]byte{hash.GenerateChecksum([]byte("piece0"))},
+	}, nil).AnyTimes()
+	spDB.EXPECT().GetGCObjectProgress(uint64(2)).Return(&sqldb.GCObjectProgress{ObjectID: 2,
+		Status: sqldb.GCObjectStatusFailed}, nil)
+	spDB.EXPECT().GetGCObjectProgress(uint64(3)).Return(nil, errors.New("mock error"))
+
+	verify := NewRepairVerifier(spDB)
+	assert.Nil(t, verify(context.TODO(), "1_s0", []byte("piece0")))
+	var corruptErr *merrors.PieceCorruptError
+	assert.True(t, errors.As(verify(context.TODO(), "1_s0_p1", []byte("xxxxx0")), &corruptErr))
+	assert.Equal(t, storage.ErrObjectDeleted, verify(context.TODO(), "2_s0", []byte("piece0")))
+	err := verify(context.TODO(), "3_s0", []byte("piece0"))
+	assert.NotNil(t, err)
+	assert.False(t, errors.As(err, &corruptErr))
+	assert.Equal(t, merrors.ErrInvalidObjectKey, verify(context.TODO(), "invalid", nil))
+}
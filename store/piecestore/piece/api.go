@@ -4,11 +4,15 @@ import (
 	"context"
 	"io"
 
+	merrors "github.com/bnb-chain/greenfield-storage-provider/model/errors"
 	"github.com/bnb-chain/greenfield-storage-provider/store/piecestore/storage"
 )
 
 type PieceStore struct {
 	storeAPI storage.ObjectStorage
+	tiered   storage.TieredStorage   // nil if the tiered storage is not configured
+	sharded  storage.ShardedStorage  // nil if the sharded storage is not configured
+	mirrored storage.MirroredStorage // nil if the mirrored storage is not configured
 }
 
 // Get one piece from PieceStore
@@ -30,3 +34,43 @@ func (p *PieceStore) Delete(ctx context.Context, key string) error {
 func (p *PieceStore) GetPieceInfo(ctx context.Context, key string) (storage.Object, error) {
 	return p.storeAPI.HeadObject(ctx, key)
 }
+
+// ListAll returns all the pieces whose key starts with prefix and is greater than marker as a channel
+func (p *PieceStore) ListAll(ctx context.Context, prefix, marker string) (<-chan storage.Object, error) {
+	return p.storeAPI.ListAllObjects(ctx, prefix, marker)
+}
+
+// ListAllHot returns all the pieces in the hot tier as a channel, merrors.ErrUnsupportedMethod is returned
+// if the tiered storage is not configured
+func (p *PieceStore) ListAllHot(ctx context.Context, prefix, marker string) (<-chan storage.Object, error) {
+	if p.tiered == nil {
+		return nil, merrors.ErrUnsupportedMethod
+	}
+	return p.tiered.ListAllHotObjects(ctx, prefix, marker)
+}
+
+// Migrate moves one piece from the hot tier to the cold tier, returns the size of the moved piece
+func (p *PieceStore) Migrate(ctx context.Context, key string) (int64, error) {
+	if p.tiered == nil {
+		return 0, merrors.ErrUnsupportedMethod
+	}
+	return p.tiered.MigrateObject(ctx, key)
+}
+
+// Rebalance moves the pieces to the shards picked by the current shard layout, merrors.ErrUnsupportedMethod is
+// returned if the sharded storage is not configured
+func (p *PieceStore) Rebalance(ctx context.Context) (*storage.RebalanceResult, error) {
+	if p.sharded == nil {
+		return nil, merrors.ErrUnsupportedMethod
+	}
+	return p.sharded.Rebalance(ctx)
+}
+
+// Repair resyncs the divergent replicas of the pieces verified by verify, merrors.ErrUnsupportedMethod is returned
+// if the mirrored storage is not configured
+func (p *PieceStore) Repair(ctx context.Context, verify storage.RepairVerifier) (*storage.RepairResult, error) {
+	if p.mirrored == nil {
+		return nil, merrors.ErrUnsupportedMethod
+	}
+	return p.mirrored.Repair(ctx, verify)
+}
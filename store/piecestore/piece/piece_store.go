@@ -18,22 +18,23 @@ import (
 // NewPieceStore returns an instance of PieceStore
 func NewPieceStore(pieceConfig *storage.PieceStoreConfig) (*PieceStore, error) {
 	checkConfig(pieceConfig)
-	blob, err := createStorage(*pieceConfig)
+	ps, err := createStorage(*pieceConfig)
 	if err != nil {
 		log.Errorw("failed to create storage", "error", err)
 		return nil, err
 	}
 	log.Debugw("piece store is running", "storage type", pieceConfig.Store.Storage,
-		"shards", pieceConfig.Shards)
+		"shards", pieceConfig.Shards, "previous_shards", pieceConfig.PreviousShardLayout.Shards,
+		"cache_dir", pieceConfig.Cache.CacheDir, "hot_tier_storage_type", pieceConfig.Tier.HotStore.Storage)
 
-	return &PieceStore{blob}, nil
+	return ps, nil
 }
 
 // checkConfig checks config if right
 func checkConfig(cfg *storage.PieceStoreConfig) {
 	overrideConfigFromEnv(cfg)
-	if cfg.Shards > 256 {
-		log.Panicf("too many shards: %d", cfg.Shards)
+	if cfg.Shards > 256 || cfg.PreviousShardLayout.Shards > 256 {
+		log.Panicf("too many shards: %d, previous shards: %d", cfg.Shards, cfg.PreviousShardLayout.Shards)
 	}
 	if cfg.Store.MaxRetries < 0 {
 		log.Panic("MaxRetries should be equal or greater than zero")
@@ -45,13 +46,34 @@ func checkConfig(cfg *storage.PieceStoreConfig) {
 		if cfg.Store.BucketURL == "" {
 			cfg.Store.BucketURL = setDefaultFileStorePath()
 		}
-		p, err := filepath.Abs(cfg.Store.BucketURL)
-		if err != nil {
-			log.Panicw("failed to get absolute path", "bucket", cfg.Store.BucketURL, "error", err)
+		cfg.Store.BucketURL = absFileStorePath(cfg.Store.BucketURL)
+	}
+	if len(cfg.Mirror.Replicas) > 0 && (cfg.Shards > 1 || cfg.PreviousShardLayout.Shards > 1) {
+		log.Panic("mirrored piece store can not be used with sharding")
+	}
+	for i := range cfg.Mirror.Replicas {
+		if cfg.Mirror.Replicas[i].Storage == mpiecestore.DiskFileStore {
+			if cfg.Mirror.Replicas[i].BucketURL == "" {
+				log.Panic("the bucket url of file replica should not be empty")
+			}
+			cfg.Mirror.Replicas[i].BucketURL = absFileStorePath(cfg.Mirror.Replicas[i].BucketURL)
+		}
+	}
+	if cfg.Tier.HotStore.Storage == mpiecestore.DiskFileStore {
+		if cfg.Tier.HotStore.BucketURL == "" {
+			log.Panic("the bucket url of file hot tier should not be empty")
 		}
-		cfg.Store.BucketURL = p
-		cfg.Store.BucketURL += "/"
+		cfg.Tier.HotStore.BucketURL = absFileStorePath(cfg.Tier.HotStore.BucketURL)
+	}
+}
+
+// absFileStorePath returns the absolute directory path of the file storage
+func absFileStorePath(bucketURL string) string {
+	p, err := filepath.Abs(bucketURL)
+	if err != nil {
+		log.Panicw("failed to get absolute path", "bucket", bucketURL, "error", err)
 	}
+	return p + "/"
 }
 
 func overrideConfigFromEnv(cfg *storage.PieceStoreConfig) {
@@ -60,13 +82,18 @@ func overrideConfigFromEnv(cfg *storage.PieceStoreConfig) {
 	}
 }
 
-func createStorage(cfg storage.PieceStoreConfig) (storage.ObjectStorage, error) {
+func createStorage(cfg storage.PieceStoreConfig) (*PieceStore, error) {
 	var (
+		ps     = &PieceStore{}
 		object storage.ObjectStorage
 		err    error
 	)
-	if cfg.Shards > 1 {
-		object, err = storage.NewSharded(cfg)
+	if cfg.Shards > 1 || cfg.PreviousShardLayout.Shards > 1 {
+		ps.sharded, err = storage.NewSharded(cfg)
+		object = ps.sharded
+	} else if len(cfg.Mirror.Replicas) > 0 {
+		ps.mirrored, err = createMirroredStorage(cfg)
+		object = ps.mirrored
 	} else {
 		object, err = storage.NewObjectStorage(cfg.Store)
 	}
@@ -74,6 +101,21 @@ func createStorage(cfg storage.PieceStoreConfig) (storage.ObjectStorage, error)
 		log.Errorw("failed to create storage", "error", err, "object", object)
 		return nil, err
 	}
+	if cfg.Tier.HotStore.Storage != "" {
+		hot, err := storage.NewObjectStorage(cfg.Tier.HotStore)
+		if err != nil {
+			log.Errorw("failed to create hot tier storage", "error", err)
+			return nil, err
+		}
+		ps.tiered = storage.NewTieredStore(hot, object)
+		object = ps.tiered
+	}
+	if cfg.Cache.CacheDir != "" {
+		if object, err = storage.NewCachedStore(object, cfg.Cache); err != nil {
+			log.Errorw("failed to create piece cache", "error", err, "cache_dir", cfg.Cache.CacheDir)
+			return nil, err
+		}
+	}
 
 	if err = checkBucket(context.Background(), object); err != nil {
 		log.Errorw("failed to check bucket due to storage is not configured rightly ", "error", err,
@@ -81,7 +123,22 @@ func createStorage(cfg storage.PieceStoreConfig) (storage.ObjectStorage, error)
 		return nil, err
 	}
 
-	return object, nil
+	ps.storeAPI = object
+	return ps, nil
+}
+
+// createMirroredStorage creates the mirrored storage whose first replica is the Store
+func createMirroredStorage(cfg storage.PieceStoreConfig) (storage.MirroredStorage, error) {
+	replicas := make([]storage.ObjectStorage, 0, len(cfg.Mirror.Replicas)+1)
+	for _, replicaCfg := range append([]storage.ObjectStorageConfig{cfg.Store}, cfg.Mirror.Replicas...) {
+		replica, err := storage.NewObjectStorage(replicaCfg)
+		if err != nil {
+			log.Errorw("failed to create replica storage", "error", err, "bucket", replicaCfg.BucketURL)
+			return nil, err
+		}
+		replicas = append(replicas, replica)
+	}
+	return storage.NewMirroredStore(replicas, cfg.Mirror.WriteQuorum)
 }
 
 // checkBucket checks bucket if exists
@@ -0,0 +1,248 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
+
+	"github.com/bnb-chain/greenfield-storage-provider/model"
+	merrors "github.com/bnb-chain/greenfield-storage-provider/model/errors"
+	mpiecestore "github.com/bnb-chain/greenfield-storage-provider/model/piecestore"
+	"github.com/bnb-chain/greenfield-storage-provider/pkg/log"
+)
+
+var _ ObjectStorage = &azureBlobStore{}
+
+type azureBlobStore struct {
+	containerName string
+	client        *azblob.Client
+	DefaultObjectStorage
+}
+
+// newAzureBlobStore returns the azure blob storage, the bucket url is in the format of
+// https://<account>.blob.core.windows.net/<container>, or http://<host>/<account>/<container> for azurite.
+func newAzureBlobStore(cfg ObjectStorageConfig) (ObjectStorage, error) {
+	serviceURL, account, container, err := parseAzureBucketURL(cfg.BucketURL)
+	if err != nil {
+		log.Errorw("failed to parse azure bucket url", "error", err)
+		return nil, err
+	}
+	if val, ok := os.LookupEnv(mpiecestore.AzureStorageAccount); ok {
+		account = val
+	}
+	opts := &azblob.ClientOptions{ClientOptions: azcore.ClientOptions{
+		Retry: policy.RetryOptions{
+			MaxRetries: int32(cfg.MaxRetries),
+			RetryDelay: time.Duration(cfg.MinRetryDelay),
+		},
+		Transport: getHTTPClient(cfg.TLSInsecureSkipVerify),
+	}}
+
+	// If IAM type is AKSK, the account key is used to access the container, and the public container can be
+	// accessed by setting the account key to NoSignRequest.
+	// If IAM type is SA, the credential is got from environment, workload identity or managed identity.
+	var client *azblob.Client
+	switch cfg.IAMType {
+	case mpiecestore.AKSKIAMType:
+		key, _ := os.LookupEnv(mpiecestore.AzureStorageKey)
+		if key == "NoSignRequest" {
+			client, err = azblob.NewClientWithNoCredential(serviceURL, opts)
+			break
+		}
+		cred, credErr := azblob.NewSharedKeyCredential(account, key)
+		if credErr != nil {
+			return nil, fmt.Errorf("failed to create azure shared key credential: %s", credErr)
+		}
+		client, err = azblob.NewClientWithSharedKeyCredential(serviceURL, cred, opts)
+	case mpiecestore.SAIAMType:
+		cred, credErr := azidentity.NewDefaultAzureCredential(nil)
+		if credErr != nil {
+			return nil, fmt.Errorf("failed to use sa to access azure blob: %s", credErr)
+		}
+		client, err = azblob.NewClient(serviceURL, cred, opts)
+	default:
+		log.Errorf("unknown IAM type: %s", cfg.IAMType)
+		return nil, fmt.Errorf("unknown IAM type: %s", cfg.IAMType)
+	}
+	if err != nil {
+		log.Errorw("failed to create azure blob client", "error", err)
+		return nil, err
+	}
+	log.Infow("new azure blob store succeeds", "container", container)
+	return &azureBlobStore{containerName: container, client: client}, nil
+}
+
+func (a *azureBlobStore) String() string {
+	return fmt.Sprintf("azblob://%s/", a.containerName)
+}
+
+func (a *azureBlobStore) CreateBucket(ctx context.Context) error {
+	_, err := a.client.CreateContainer(ctx, a.containerName, nil)
+	if err != nil && bloberror.HasCode(err, bloberror.ContainerAlreadyExists) {
+		log.Errorw("azure blob failed to create container", "error", err)
+		err = nil
+	}
+	return err
+}
+
+func (a *azureBlobStore) GetObject(ctx context.Context, key string, offset, limit int64) (io.ReadCloser, error) {
+	opts := &azblob.DownloadStreamOptions{}
+	if offset > 0 || limit > 0 {
+		opts.Range = azblob.HTTPRange{Offset: offset}
+		if limit > 0 {
+			opts.Range.Count = limit
+		}
+	}
+	resp, err := a.client.DownloadStream(ctx, a.containerName, key, opts)
+	if err != nil {
+		log.Errorw("azure blob failed to get object", "error", err)
+		return nil, err
+	}
+	if offset == 0 && limit == -1 {
+		for k, v := range resp.Metadata {
+			if strings.EqualFold(k, mpiecestore.ChecksumAlgo) && v != nil {
+				resp.Body = verifyChecksum(resp.Body, *v)
+			}
+		}
+	}
+	return resp.Body, nil
+}
+
+func (a *azureBlobStore) PutObject(ctx context.Context, key string, reader io.Reader) error {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+	checksum := generateChecksum(bytes.NewReader(data))
+	_, err = a.client.UploadBuffer(ctx, a.containerName, key, data, &azblob.UploadBufferOptions{
+		HTTPHeaders: &blob.HTTPHeaders{BlobContentType: to.Ptr(model.OctetStream)},
+		Metadata:    map[string]*string{mpiecestore.ChecksumAlgo: to.Ptr(checksum)},
+	})
+	return err
+}
+
+func (a *azureBlobStore) DeleteObject(ctx context.Context, key string) error {
+	_, err := a.client.DeleteBlob(ctx, a.containerName, key, nil)
+	if err != nil && bloberror.HasCode(err, bloberror.BlobNotFound) {
+		log.Errorw("azure blob failed to delete object", "error", err)
+		err = nil
+	}
+	return err
+}
+
+func (a *azureBlobStore) HeadBucket(ctx context.Context) error {
+	if _, err := a.client.ServiceClient().NewContainerClient(a.containerName).GetProperties(ctx, nil); err != nil {
+		log.Errorw("azure blob failed to head container", "error", err)
+		if bloberror.HasCode(err, bloberror.ContainerNotFound) {
+			return merrors.ErrNoSuchBucket
+		}
+		return err
+	}
+	return nil
+}
+
+func (a *azureBlobStore) HeadObject(ctx context.Context, key string) (Object, error) {
+	resp, err := a.client.ServiceClient().NewContainerClient(a.containerName).NewBlobClient(key).GetProperties(ctx, nil)
+	if err != nil {
+		if bloberror.HasCode(err, bloberror.BlobNotFound) {
+			err = os.ErrNotExist
+		}
+		log.Errorw("azure blob failed to head object", "error", err)
+		return nil, err
+	}
+	var size int64
+	if resp.ContentLength != nil {
+		size = *resp.ContentLength
+	}
+	var modTime time.Time
+	if resp.LastModified != nil {
+		modTime = *resp.LastModified
+	}
+	return &object{key, size, modTime, strings.HasSuffix(key, "/")}, nil
+}
+
+func (a *azureBlobStore) ListObjects(ctx context.Context, prefix, marker, delimiter string, limit int64) ([]Object, error) {
+	return listObjects(ctx, a.ListAllObjects, prefix, marker, delimiter, limit)
+}
+
+// ListAllObjects lists the blobs page by page in the order of name. Azure blob only continues the listing by the
+// opaque marker returned in the previous page, so the blobs not greater than marker are skipped by the client.
+func (a *azureBlobStore) ListAllObjects(ctx context.Context, prefix, marker string) (<-chan Object, error) {
+	pager := a.client.NewListBlobsFlatPager(a.containerName, &azblob.ListBlobsFlatOptions{
+		Prefix:     to.Ptr(prefix),
+		MaxResults: to.Ptr(int32(listPageSize)),
+	})
+	resp, err := pager.NextPage(ctx)
+	if err != nil {
+		log.Errorw("azure blob failed to list objects", "error", err)
+		return nil, err
+	}
+	objCh := make(chan Object, listPageSize)
+	go func() {
+		defer close(objCh)
+		for {
+			for _, item := range resp.Segment.BlobItems {
+				key := *item.Name
+				if key <= marker {
+					continue
+				}
+				var size int64
+				var modTime time.Time
+				if item.Properties != nil {
+					if item.Properties.ContentLength != nil {
+						size = *item.Properties.ContentLength
+					}
+					if item.Properties.LastModified != nil {
+						modTime = *item.Properties.LastModified
+					}
+				}
+				select {
+				case objCh <- &object{key, size, modTime, strings.HasSuffix(key, "/")}:
+				case <-ctx.Done():
+					return
+				}
+			}
+			if !pager.More() {
+				return
+			}
+			if resp, err = pager.NextPage(ctx); err != nil {
+				sendListError(ctx, objCh, err)
+				return
+			}
+		}
+	}()
+	return objCh, nil
+}
+
+// parseAzureBucketURL returns the service url, account name and container name of the bucket url
+func parseAzureBucketURL(bucketURL string) (string, string, string, error) {
+	uri, err := url.ParseRequestURI(strings.TrimSuffix(bucketURL, "/"))
+	if err != nil {
+		return "", "", "", fmt.Errorf("invalid azure bucket url %s: %s", bucketURL, err)
+	}
+	parts := strings.Split(strings.TrimPrefix(uri.Path, "/"), "/")
+	switch {
+	case len(parts) == 1 && parts[0] != "":
+		// https://<account>.blob.core.windows.net/<container>
+		account := strings.Split(uri.Host, ".")[0]
+		return fmt.Sprintf("%s://%s/", uri.Scheme, uri.Host), account, parts[0], nil
+	case len(parts) == 2:
+		// http://127.0.0.1:10000/<account>/<container>
+		return fmt.Sprintf("%s://%s/%s/", uri.Scheme, uri.Host, parts[0]), parts[0], parts[1], nil
+	default:
+		return "", "", "", fmt.Errorf("no container name provided in %s", bucketURL)
+	}
+}
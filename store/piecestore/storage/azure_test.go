@@ -0,0 +1,51 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAzureBucketURL(t *testing.T) {
+	cases := []struct {
+		name       string
+		bucketURL  string
+		serviceURL string
+		account    string
+		container  string
+		wantedErr  bool
+	}{
+		{
+			name:       "azure blob url",
+			bucketURL:  "https://account.blob.core.windows.net/test/",
+			serviceURL: "https://account.blob.core.windows.net/",
+			account:    "account",
+			container:  "test",
+		},
+		{
+			name:       "azurite url",
+			bucketURL:  "http://127.0.0.1:10000/devstoreaccount1/test",
+			serviceURL: "http://127.0.0.1:10000/devstoreaccount1/",
+			account:    "devstoreaccount1",
+			container:  "test",
+		},
+		{
+			name:      "no container",
+			bucketURL: "https://account.blob.core.windows.net",
+			wantedErr: true,
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			serviceURL, account, container, err := parseAzureBucketURL(tt.bucketURL)
+			if tt.wantedErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.serviceURL, serviceURL)
+			assert.Equal(t, tt.account, account)
+			assert.Equal(t, tt.container, container)
+		})
+	}
+}
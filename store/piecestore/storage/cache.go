@@ -0,0 +1,346 @@
+package storage
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bnb-chain/greenfield-storage-provider/pkg/log"
+	"github.com/bnb-chain/greenfield-storage-provider/pkg/metrics"
+)
+
+const (
+	cacheHit  = "hit"
+	cacheMiss = "miss"
+)
+
+// cacheLockFile is the file locked by the process using the cache directory, which prevents the processes
+// from sharing the directory with separate lru indexes
+const cacheLockFile = ".lock"
+
+var (
+	// pieceCaches are the piece caches opened by this process indexed by the cache directory, the services in
+	// the same process share one piece cache of the directory, so that the objects put or deleted by one
+	// service are invalidated for all of them
+	pieceCaches    = make(map[string]*pieceCache)
+	pieceCachesMux sync.Mutex
+)
+
+// cachedStore is a read-through cache in front of the remote object storage. The whole objects read from
+// the remote object storage are cached in the local disk directory, and the least recently used ones are
+// evicted if the total size exceeds the max size. The cached objects are invalidated while putting or
+// deleting the objects.
+type cachedStore struct {
+	ObjectStorage
+	*pieceCache
+}
+
+// pieceCache is the lru index of the objects cached in the local disk directory.
+type pieceCache struct {
+	lock     *os.File
+	cache    ObjectStorage
+	maxSize  int64
+	mux      sync.Mutex
+	lru      *list.List
+	entries  map[string]*list.Element
+	usedSize int64
+	// loads are the objects being loaded from the remote object storage by the cache misses
+	loads map[string]*cacheLoad
+}
+
+type cacheEntry struct {
+	key  string
+	size int64
+}
+
+// cacheLoad is marked stale if the object is put or deleted while it is being loaded, so that the loaded
+// data is not cached.
+type cacheLoad struct {
+	refs  int
+	stale bool
+}
+
+// NewCachedStore returns an ObjectStorage which caches the objects of store in the local disk directory,
+// the cached objects left in the directory are reloaded in the order of modified time. The stores of the
+// same directory share one piece cache in the process, and the directory can not be used by other processes.
+func NewCachedStore(store ObjectStorage, cfg PieceCacheConfig) (ObjectStorage, error) {
+	if cfg.MaxSize <= 0 {
+		return nil, fmt.Errorf("invalid piece cache max size: %d", cfg.MaxSize)
+	}
+	root, err := filepath.Abs(cfg.CacheDir)
+	if err != nil {
+		return nil, err
+	}
+	pieceCachesMux.Lock()
+	defer pieceCachesMux.Unlock()
+	c, ok := pieceCaches[root]
+	if !ok {
+		if c, err = openPieceCache(root, cfg.MaxSize); err != nil {
+			return nil, err
+		}
+		pieceCaches[root] = c
+	} else if c.maxSize != cfg.MaxSize {
+		log.Warnw("piece cache has been opened with another max size", "cache_dir", root,
+			"max_size", c.maxSize, "ignored_max_size", cfg.MaxSize)
+	}
+	return &cachedStore{ObjectStorage: store, pieceCache: c}, nil
+}
+
+// openPieceCache locks the directory for this process and reloads the cached objects left in it.
+func openPieceCache(root string, maxSize int64) (*pieceCache, error) {
+	cache, err := newDiskFileStore(ObjectStorageConfig{BucketURL: root + dirSuffix})
+	if err != nil {
+		return nil, err
+	}
+	if err = cache.CreateBucket(context.Background()); err != nil {
+		return nil, err
+	}
+	lock, err := lockFile(filepath.Join(root, cacheLockFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock piece cache dir %s, it may be used by another process: %s", root, err)
+	}
+	c := &pieceCache{
+		lock:    lock,
+		cache:   cache,
+		maxSize: maxSize,
+		lru:     list.New(),
+		entries: make(map[string]*list.Element),
+		loads:   make(map[string]*cacheLoad),
+	}
+	if err = c.reload(root); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *cachedStore) String() string {
+	return fmt.Sprintf("cache(%s)://%s", c.cache, c.ObjectStorage)
+}
+
+// reload adds the cached objects left in the directory to the lru list, the earlier modified ones are
+// treated as less recently used.
+func (c *pieceCache) reload(root string) error {
+	type cachedFile struct {
+		key     string
+		size    int64
+		modTime time.Time
+	}
+	var files []cachedFile
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		key, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		if key == cacheLockFile {
+			return nil
+		}
+		// the temporary files are left by the interrupted writes
+		if strings.HasPrefix(filepath.Base(key), ".") {
+			return os.Remove(path)
+		}
+		files = append(files, cachedFile{key: filepath.ToSlash(key), size: info.Size(), modTime: info.ModTime()})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].modTime.Before(files[j].modTime) })
+	for _, f := range files {
+		c.add(f.key, f.size)
+	}
+	log.Infow("reload piece cache", "object_number", c.lru.Len(), "used_size", c.usedSize, "max_size", c.maxSize)
+	return nil
+}
+
+func (c *cachedStore) GetObject(ctx context.Context, key string, offset, limit int64) (io.ReadCloser, error) {
+	if c.touch(key) {
+		rc, err := c.cache.GetObject(ctx, key, offset, limit)
+		if err == nil {
+			metrics.PieceStoreCacheCounter.WithLabelValues(cacheHit).Inc()
+			return rc, nil
+		}
+		log.Warnw("failed to get object from piece cache", "key", key, "error", err)
+		c.remove(ctx, key)
+	}
+	metrics.PieceStoreCacheCounter.WithLabelValues(cacheMiss).Inc()
+
+	load := c.beginLoad(key)
+	data, err := c.load(ctx, key)
+	if err != nil {
+		c.endLoad(key, load, nil)
+		return nil, err
+	}
+	c.endLoad(key, load, data)
+
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	data = data[offset:]
+	if limit > 0 && limit < int64(len(data)) {
+		data = data[:limit]
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+// load reads the whole object from the remote object storage.
+func (c *cachedStore) load(ctx context.Context, key string) ([]byte, error) {
+	rc, err := c.ObjectStorage.GetObject(ctx, key, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+// PutObject invalidates the cached object before and after putting it, so that the old data loaded by the
+// concurrent cache misses is not cached.
+func (c *cachedStore) PutObject(ctx context.Context, key string, reader io.Reader) error {
+	c.remove(ctx, key)
+	defer c.remove(ctx, key)
+	return c.ObjectStorage.PutObject(ctx, key, reader)
+}
+
+// DeleteObject invalidates the cached object before and after deleting it, so that the old data loaded by the
+// concurrent cache misses is not cached.
+func (c *cachedStore) DeleteObject(ctx context.Context, key string) error {
+	c.remove(ctx, key)
+	defer c.remove(ctx, key)
+	return c.ObjectStorage.DeleteObject(ctx, key)
+}
+
+// beginLoad registers the object being loaded by a cache miss.
+func (c *pieceCache) beginLoad(key string) *cacheLoad {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	load, ok := c.loads[key]
+	if !ok {
+		load = &cacheLoad{}
+		c.loads[key] = load
+	}
+	load.refs++
+	return load
+}
+
+// endLoad unregisters the loaded object, and caches the loaded data if the object has not been put or
+// deleted while loading. The load is kept registered until the cache file is indexed, so that the
+// invalidation while writing the cache file is not missed.
+func (c *pieceCache) endLoad(key string, load *cacheLoad, data []byte) {
+	cacheable := data != nil && int64(len(data)) <= c.maxSize
+	if cacheable {
+		c.mux.Lock()
+		cacheable = !load.stale
+		c.mux.Unlock()
+	}
+	if cacheable {
+		if err := c.cache.PutObject(context.Background(), key, bytes.NewReader(data)); err != nil {
+			log.Warnw("failed to put object to piece cache", "key", key, "error", err)
+			cacheable = false
+		}
+	}
+
+	var evicted []string
+	c.mux.Lock()
+	if load.refs--; load.refs == 0 {
+		delete(c.loads, key)
+	}
+	stale := cacheable && load.stale
+	if cacheable && !stale {
+		evicted = c.addLocked(key, int64(len(data)))
+	}
+	c.mux.Unlock()
+
+	if stale {
+		// the object is invalidated while writing the cache file
+		evicted = append(evicted, key)
+	}
+	c.deleteCacheFiles(evicted)
+}
+
+// touch marks the cached object as the most recently used one, returns false if it is not cached.
+func (c *pieceCache) touch(key string) bool {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	elem, ok := c.entries[key]
+	if ok {
+		c.lru.MoveToFront(elem)
+	}
+	return ok
+}
+
+// add adds the cached object to the lru list, and evicts the least recently used ones if the total
+// size exceeds the max size.
+func (c *pieceCache) add(key string, size int64) {
+	c.mux.Lock()
+	evicted := c.addLocked(key, size)
+	c.mux.Unlock()
+	c.deleteCacheFiles(evicted)
+}
+
+// addLocked is the same as add except that it returns the evicted keys instead of deleting their cache
+// files, the caller must hold the mutex.
+func (c *pieceCache) addLocked(key string, size int64) []string {
+	var evicted []string
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*cacheEntry)
+		c.usedSize += size - entry.size
+		entry.size = size
+		c.lru.MoveToFront(elem)
+	} else {
+		c.entries[key] = c.lru.PushFront(&cacheEntry{key: key, size: size})
+		c.usedSize += size
+	}
+	for c.usedSize > c.maxSize && c.lru.Len() > 1 {
+		entry := c.lru.Remove(c.lru.Back()).(*cacheEntry)
+		delete(c.entries, entry.key)
+		c.usedSize -= entry.size
+		evicted = append(evicted, entry.key)
+	}
+	return evicted
+}
+
+func (c *pieceCache) deleteCacheFiles(keys []string) {
+	for _, key := range keys {
+		if err := c.cache.DeleteObject(context.Background(), key); err != nil {
+			log.Warnw("failed to evict object from piece cache", "key", key, "error", err)
+		}
+	}
+}
+
+// remove removes the cached object from the lru list and the local disk directory, and marks the object
+// being loaded as stale.
+func (c *pieceCache) remove(ctx context.Context, key string) {
+	c.mux.Lock()
+	if load, ok := c.loads[key]; ok {
+		load.stale = true
+	}
+	elem, ok := c.entries[key]
+	if ok {
+		c.lru.Remove(elem)
+		delete(c.entries, key)
+		c.usedSize -= elem.Value.(*cacheEntry).size
+	}
+	c.mux.Unlock()
+
+	if !ok {
+		return
+	}
+	if err := c.cache.DeleteObject(ctx, key); err != nil {
+		log.Warnw("failed to remove object from piece cache", "key", key, "error", err)
+	}
+}
@@ -0,0 +1,145 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func setupCacheTest(t *testing.T, maxSize int64) (*cachedStore, *memoryStore) {
+	backend := &memoryStore{name: mockBucket, objects: map[string]*memoryObject{}}
+	store, err := NewCachedStore(backend, PieceCacheConfig{CacheDir: t.TempDir(), MaxSize: maxSize})
+	assert.Nil(t, err)
+	return store.(*cachedStore), backend
+}
+
+func readCachedObject(t *testing.T, store ObjectStorage, key string, offset, limit int64) string {
+	rc, err := store.GetObject(context.TODO(), key, offset, limit)
+	assert.Nil(t, err)
+	data, err := io.ReadAll(rc)
+	assert.Nil(t, err)
+	_ = rc.Close()
+	return string(data)
+}
+
+func TestCache_GetObject(t *testing.T) {
+	store, backend := setupCacheTest(t, 1024)
+	assert.Nil(t, backend.PutObject(context.TODO(), mockKey, bytes.NewReader([]byte("hello world"))))
+
+	// the first read misses the cache and loads the whole object
+	assert.Equal(t, "world", readCachedObject(t, store, mockKey, 6, 0))
+	assert.Equal(t, 1, store.lru.Len())
+	assert.Equal(t, int64(11), store.usedSize)
+
+	// the later reads hit the cache even if the object is deleted in backend
+	assert.Nil(t, backend.DeleteObject(context.TODO(), mockKey))
+	assert.Equal(t, "hello", readCachedObject(t, store, mockKey, 0, 5))
+	assert.Equal(t, "hello world", readCachedObject(t, store, mockKey, 0, 0))
+}
+
+func TestCache_Invalidate(t *testing.T) {
+	store, backend := setupCacheTest(t, 1024)
+	assert.Nil(t, store.PutObject(context.TODO(), mockKey, bytes.NewReader([]byte("old"))))
+	assert.Equal(t, "old", readCachedObject(t, store, mockKey, 0, 0))
+
+	assert.Nil(t, store.PutObject(context.TODO(), mockKey, bytes.NewReader([]byte("new"))))
+	assert.Equal(t, 0, store.lru.Len())
+	assert.Equal(t, "new", readCachedObject(t, store, mockKey, 0, 0))
+
+	assert.Nil(t, store.DeleteObject(context.TODO(), mockKey))
+	assert.Equal(t, 0, store.lru.Len())
+	assert.Equal(t, int64(0), store.usedSize)
+	_, err := backend.GetObject(context.TODO(), mockKey, 0, 0)
+	assert.NotNil(t, err)
+}
+
+func TestCache_Evict(t *testing.T) {
+	store, backend := setupCacheTest(t, 10)
+	for _, key := range []string{"a", "b", "c"} {
+		assert.Nil(t, backend.PutObject(context.TODO(), key, bytes.NewReader([]byte("12345"))))
+	}
+	readCachedObject(t, store, "a", 0, 0)
+	readCachedObject(t, store, "b", 0, 0)
+	// touch a, so that b is the least recently used one
+	readCachedObject(t, store, "a", 0, 0)
+	readCachedObject(t, store, "c", 0, 0)
+
+	assert.Equal(t, int64(10), store.usedSize)
+	_, ok := store.entries["b"]
+	assert.False(t, ok)
+	_, err := os.Stat(filepath.Join(store.cache.(*diskFileStore).root, "b"))
+	assert.True(t, os.IsNotExist(err))
+
+	// the object larger than max size is not cached
+	assert.Nil(t, backend.PutObject(context.TODO(), "d", bytes.NewReader([]byte("12345678901"))))
+	assert.Equal(t, "12345678901", readCachedObject(t, store, "d", 0, 0))
+	_, ok = store.entries["d"]
+	assert.False(t, ok)
+}
+
+// closePieceCache simulates the exit of the process using the piece cache.
+func closePieceCache(t *testing.T, store *cachedStore) {
+	pieceCachesMux.Lock()
+	defer pieceCachesMux.Unlock()
+	delete(pieceCaches, filepath.Clean(store.cache.(*diskFileStore).root))
+	assert.Nil(t, store.lock.Close())
+}
+
+func TestCache_Reload(t *testing.T) {
+	store, backend := setupCacheTest(t, 1024)
+	assert.Nil(t, backend.PutObject(context.TODO(), mockKey, bytes.NewReader([]byte("hello world"))))
+	readCachedObject(t, store, mockKey, 0, 0)
+	closePieceCache(t, store)
+
+	reloaded, err := NewCachedStore(backend, PieceCacheConfig{
+		CacheDir: store.cache.(*diskFileStore).root,
+		MaxSize:  1024,
+	})
+	assert.Nil(t, err)
+	assert.NotSame(t, store.pieceCache, reloaded.(*cachedStore).pieceCache)
+	assert.Equal(t, int64(11), reloaded.(*cachedStore).usedSize)
+	_, ok := reloaded.(*cachedStore).entries[mockKey]
+	assert.True(t, ok)
+}
+
+func TestCache_SharedIndex(t *testing.T) {
+	store, backend := setupCacheTest(t, 1024)
+	other, err := NewCachedStore(backend, PieceCacheConfig{
+		CacheDir: store.cache.(*diskFileStore).root,
+		MaxSize:  1024,
+	})
+	assert.Nil(t, err)
+	assert.Same(t, store.pieceCache, other.(*cachedStore).pieceCache)
+
+	assert.Nil(t, backend.PutObject(context.TODO(), mockKey, bytes.NewReader([]byte("old"))))
+	assert.Equal(t, "old", readCachedObject(t, store, mockKey, 0, 0))
+	// the object put by another service is not read stale from the cache
+	assert.Nil(t, other.PutObject(context.TODO(), mockKey, bytes.NewReader([]byte("new"))))
+	assert.Equal(t, "new", readCachedObject(t, store, mockKey, 0, 0))
+	assert.Nil(t, other.DeleteObject(context.TODO(), mockKey))
+	_, err = store.GetObject(context.TODO(), mockKey, 0, 0)
+	assert.NotNil(t, err)
+}
+
+func TestCache_StaleLoad(t *testing.T) {
+	store, backend := setupCacheTest(t, 1024)
+	assert.Nil(t, backend.PutObject(context.TODO(), mockKey, bytes.NewReader([]byte("old"))))
+
+	// the object is put while the miss is loading the old data
+	load := store.beginLoad(mockKey)
+	data, err := store.load(context.TODO(), mockKey)
+	assert.Nil(t, err)
+	assert.Nil(t, store.PutObject(context.TODO(), mockKey, bytes.NewReader([]byte("new"))))
+	store.endLoad(mockKey, load, data)
+
+	assert.Equal(t, 0, store.lru.Len())
+	assert.Empty(t, store.loads)
+	_, err = os.Stat(filepath.Join(store.cache.(*diskFileStore).root, mockKey))
+	assert.True(t, os.IsNotExist(err))
+	assert.Equal(t, "new", readCachedObject(t, store, mockKey, 0, 0))
+}
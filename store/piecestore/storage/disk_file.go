@@ -5,10 +5,12 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"io/fs"
 	"math/rand"
 	"os"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -179,6 +181,84 @@ func (d *diskFileStore) HeadObject(ctx context.Context, key string) (Object, err
 	}, nil
 }
 
+func (d *diskFileStore) ListObjects(ctx context.Context, prefix, marker, delimiter string, limit int64) ([]Object, error) {
+	return listObjects(ctx, d.ListAllObjects, prefix, marker, delimiter, limit)
+}
+
+// ListAllObjects walks the root directory and returns the objects whose key starts with prefix and is greater
+// than marker as a sorted channel, the temporary files left by the interrupted writes are skipped.
+func (d *diskFileStore) ListAllObjects(ctx context.Context, prefix, marker string) (<-chan Object, error) {
+	root := filepath.Clean(d.root)
+	if _, err := os.Stat(root); err != nil {
+		log.Errorw("failed to list objects due to stat root", "error", err)
+		return nil, err
+	}
+	objCh := make(chan Object, listPageSize)
+	go func() {
+		defer close(objCh)
+		if err := d.walk(ctx, root, "", prefix, marker, objCh); err != nil && ctx.Err() == nil {
+			sendListError(ctx, objCh, err)
+		}
+	}()
+	return objCh, nil
+}
+
+// walk sends the files under dir in the order of key, the entries are sorted with the directory name suffixed
+// by "/", so that the keys under a directory are sorted right with the keys of its siblings. The directories
+// out of prefix or not greater than marker are skipped.
+func (d *diskFileStore) walk(ctx context.Context, dir, dirKey, prefix, marker string, objCh chan<- Object) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			// the directory is deleted while walking
+			return nil
+		}
+		return err
+	}
+	names := make([]string, 0, len(entries))
+	entryMap := make(map[string]fs.DirEntry, len(entries))
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		name := entry.Name()
+		if entry.IsDir() {
+			name += dirSuffix
+		}
+		names = append(names, name)
+		entryMap[name] = entry
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		key := dirKey + name
+		entry := entryMap[name]
+		if entry.IsDir() {
+			if !strings.HasPrefix(key, prefix) && !strings.HasPrefix(prefix, key) ||
+				key < marker && !strings.HasPrefix(marker, key) {
+				continue
+			}
+			if err = d.walk(ctx, filepath.Join(dir, entry.Name()), key, prefix, marker, objCh); err != nil {
+				return err
+			}
+			continue
+		}
+		if !strings.HasPrefix(key, prefix) || key <= marker {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			// the file is deleted while walking
+			continue
+		}
+		select {
+		case objCh <- &object{key, info.Size(), info.ModTime(), false}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
 func (d *diskFileStore) path(key string) string {
 	return filepath.Join(d.root, key)
 }
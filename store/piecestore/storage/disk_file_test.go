@@ -12,8 +12,6 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
-
-	merrors "github.com/bnb-chain/greenfield-storage-provider/model/errors"
 )
 
 func setupDiskFileTest(t *testing.T) *diskFileStore {
@@ -287,15 +285,67 @@ func TestDiskFile_HeadDirSuccess(t *testing.T) {
 }
 
 func TestDiskFile_List(t *testing.T) {
-	store := setupDiskFileTest(t)
-	_, err := store.ListObjects(context.TODO(), emptyString, emptyString, emptyString, 0)
-	assert.Equal(t, merrors.ErrUnsupportedMethod, err)
+	store := &diskFileStore{root: t.TempDir() + "/"}
+	for _, key := range []string{"a/1", "a/b/2", "a.c", "b"} {
+		assert.Nil(t, store.PutObject(context.TODO(), key, strings.NewReader("a")))
+	}
+	cases := []struct {
+		name         string
+		prefix       string
+		marker       string
+		delimiter    string
+		wantedResult []string
+	}{
+		{
+			name:         "disk_file_list_test1",
+			wantedResult: []string{"a.c", "a/1", "a/b/2", "b"},
+		},
+		{
+			name:         "disk_file_list_test2",
+			marker:       "a/1",
+			wantedResult: []string{"a/b/2", "b"},
+		},
+		{
+			name:         "disk_file_list_test3",
+			delimiter:    "/",
+			wantedResult: []string{"a.c", "a/", "b"},
+		},
+		{
+			name:         "disk_file_list_test4",
+			prefix:       "a/",
+			delimiter:    "/",
+			wantedResult: []string{"a/1", "a/b/"},
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			objs, err := store.ListObjects(context.TODO(), tt.prefix, tt.marker, tt.delimiter, 0)
+			assert.Nil(t, err)
+			keys := make([]string, 0, len(objs))
+			for _, obj := range objs {
+				keys = append(keys, obj.Key())
+			}
+			assert.Equal(t, tt.wantedResult, keys)
+		})
+	}
 }
 
 func TestDiskFile_ListAll(t *testing.T) {
-	store := setupDiskFileTest(t)
-	_, err := store.ListAllObjects(context.TODO(), emptyString, emptyString)
-	assert.Equal(t, merrors.ErrUnsupportedMethod, err)
+	store := &diskFileStore{root: t.TempDir() + "/"}
+	for _, key := range []string{"1_s0", "1_s1", "2_s0", ".tmp"} {
+		assert.Nil(t, store.PutObject(context.TODO(), key, strings.NewReader("a")))
+	}
+	ch, err := store.ListAllObjects(context.TODO(), "1_", "1_s0")
+	assert.Nil(t, err)
+	var keys []string
+	for obj := range ch {
+		keys = append(keys, obj.Key())
+	}
+	assert.Equal(t, []string{"1_s1"}, keys)
+
+	store = &diskFileStore{root: "not_exist_dir/"}
+	_, err = store.ListAllObjects(context.TODO(), emptyString, emptyString)
+	assert.NotNil(t, err)
 }
 
 func TestPath(t *testing.T) {
@@ -65,3 +65,16 @@ func groupName(gid int) string {
 	}
 	return name
 }
+
+// lockFile opens the file and locks it exclusively, the lock is released if the file is closed or the process exits.
+func lockFile(path string) (*os.File, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, err
+	}
+	if err = syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return f, nil
+}
@@ -0,0 +1,206 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+
+	"github.com/bnb-chain/greenfield-storage-provider/model"
+	merrors "github.com/bnb-chain/greenfield-storage-provider/model/errors"
+	mpiecestore "github.com/bnb-chain/greenfield-storage-provider/model/piecestore"
+	"github.com/bnb-chain/greenfield-storage-provider/pkg/log"
+)
+
+var _ ObjectStorage = &gcsStore{}
+
+type gcsStore struct {
+	bucketName string
+	client     *storage.Client
+	DefaultObjectStorage
+}
+
+// newGCSStore returns the google cloud storage, the bucket url is in the format of gs://<bucket> or
+// https://storage.googleapis.com/<bucket>. The gcs emulator such as fake-gcs-server is used by setting
+// the STORAGE_EMULATOR_HOST env.
+func newGCSStore(cfg ObjectStorageConfig) (ObjectStorage, error) {
+	bucketName, err := parseGCSBucketURL(cfg.BucketURL)
+	if err != nil {
+		log.Errorw("failed to parse gcs bucket url", "error", err)
+		return nil, err
+	}
+
+	// If IAM type is AKSK, the json key file of service account is used to access the bucket, and only the
+	// emulator is accessed without the key file.
+	// If IAM type is SA, the application default credentials are used, e.g. workload identity.
+	var opts []option.ClientOption
+	switch cfg.IAMType {
+	case mpiecestore.AKSKIAMType:
+		if keyFile := os.Getenv(mpiecestore.GCSCredentialsFile); keyFile != "" {
+			opts = append(opts, option.WithCredentialsFile(keyFile))
+		} else if os.Getenv(mpiecestore.GCSEmulatorHost) != "" {
+			opts = append(opts, option.WithoutAuthentication(), option.WithHTTPClient(getHTTPClient(cfg.TLSInsecureSkipVerify)))
+		} else {
+			log.Errorf("failed to use aksk to access gcs due to missing %s", mpiecestore.GCSCredentialsFile)
+			return nil, fmt.Errorf("failed to use aksk to access gcs due to missing %s", mpiecestore.GCSCredentialsFile)
+		}
+	case mpiecestore.SAIAMType:
+	default:
+		log.Errorf("unknown IAM type: %s", cfg.IAMType)
+		return nil, fmt.Errorf("unknown IAM type: %s", cfg.IAMType)
+	}
+	// the client retries the idempotent operations with exponential backoff by default
+	client, err := storage.NewClient(context.Background(), opts...)
+	if err != nil {
+		log.Errorw("failed to create gcs client", "error", err)
+		return nil, err
+	}
+	log.Infow("new gcs store succeeds", "bucket", bucketName)
+	return &gcsStore{bucketName: bucketName, client: client}, nil
+}
+
+func (g *gcsStore) String() string {
+	return fmt.Sprintf("gs://%s/", g.bucketName)
+}
+
+func (g *gcsStore) CreateBucket(ctx context.Context) error {
+	projectID, _ := os.LookupEnv(mpiecestore.GCSProjectID)
+	err := g.client.Bucket(g.bucketName).Create(ctx, projectID, nil)
+	var apiErr *googleapi.Error
+	if err != nil && errors.As(err, &apiErr) && apiErr.Code == http.StatusConflict {
+		log.Errorw("gcs failed to create bucket", "error", err)
+		err = nil
+	}
+	return err
+}
+
+// GetObject gets the object from gcs, the crc32c checksum of the full object read is verified by gcs client.
+func (g *gcsStore) GetObject(ctx context.Context, key string, offset, limit int64) (io.ReadCloser, error) {
+	length := int64(-1)
+	if limit > 0 {
+		length = limit
+	}
+	r, err := g.client.Bucket(g.bucketName).Object(key).NewRangeReader(ctx, offset, length)
+	if err != nil {
+		log.Errorw("gcs failed to get object", "error", err)
+		return nil, err
+	}
+	return r, nil
+}
+
+func (g *gcsStore) PutObject(ctx context.Context, key string, reader io.Reader) error {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+	w := g.client.Bucket(g.bucketName).Object(key).NewWriter(ctx)
+	w.ContentType = model.OctetStream
+	w.CRC32C = crc32.Checksum(data, crc32c)
+	w.SendCRC32C = true
+	if _, err = io.Copy(w, bytes.NewReader(data)); err != nil {
+		_ = w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+func (g *gcsStore) DeleteObject(ctx context.Context, key string) error {
+	err := g.client.Bucket(g.bucketName).Object(key).Delete(ctx)
+	if err != nil && errors.Is(err, storage.ErrObjectNotExist) {
+		log.Errorw("gcs failed to delete object", "error", err)
+		err = nil
+	}
+	return err
+}
+
+func (g *gcsStore) HeadBucket(ctx context.Context) error {
+	if _, err := g.client.Bucket(g.bucketName).Attrs(ctx); err != nil {
+		log.Errorw("gcs failed to head bucket", "error", err)
+		if errors.Is(err, storage.ErrBucketNotExist) {
+			return merrors.ErrNoSuchBucket
+		}
+		return err
+	}
+	return nil
+}
+
+func (g *gcsStore) HeadObject(ctx context.Context, key string) (Object, error) {
+	attrs, err := g.client.Bucket(g.bucketName).Object(key).Attrs(ctx)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			err = os.ErrNotExist
+		}
+		log.Errorw("gcs failed to head object", "error", err)
+		return nil, err
+	}
+	return &object{key, attrs.Size, attrs.Updated, strings.HasSuffix(key, "/")}, nil
+}
+
+func (g *gcsStore) ListObjects(ctx context.Context, prefix, marker, delimiter string, limit int64) ([]Object, error) {
+	return listObjects(ctx, g.ListAllObjects, prefix, marker, delimiter, limit)
+}
+
+// ListAllObjects lists the objects in the order of name, the iterator fetches the next page when the objects of
+// the current page are consumed.
+func (g *gcsStore) ListAllObjects(ctx context.Context, prefix, marker string) (<-chan Object, error) {
+	query := &storage.Query{Prefix: prefix, StartOffset: marker}
+	if err := query.SetAttrSelection([]string{"Name", "Size", "Updated"}); err != nil {
+		return nil, err
+	}
+	it := g.client.Bucket(g.bucketName).Objects(ctx, query)
+	// the start offset is inclusive, so the object of marker is skipped
+	attrs, err := it.Next()
+	for err == nil && attrs.Name == marker {
+		attrs, err = it.Next()
+	}
+	if err != nil && !errors.Is(err, iterator.Done) {
+		log.Errorw("gcs failed to list objects", "error", err)
+		return nil, err
+	}
+	objCh := make(chan Object, listPageSize)
+	go func() {
+		defer close(objCh)
+		for ; err == nil; attrs, err = it.Next() {
+			select {
+			case objCh <- &object{attrs.Name, attrs.Size, attrs.Updated, strings.HasSuffix(attrs.Name, "/")}:
+			case <-ctx.Done():
+				return
+			}
+		}
+		if !errors.Is(err, iterator.Done) {
+			sendListError(ctx, objCh, err)
+		}
+	}()
+	return objCh, nil
+}
+
+// parseGCSBucketURL returns the bucket name of the bucket url
+func parseGCSBucketURL(bucketURL string) (string, error) {
+	uri, err := url.ParseRequestURI(strings.TrimSuffix(bucketURL, "/"))
+	if err != nil {
+		return "", fmt.Errorf("invalid gcs bucket url %s: %s", bucketURL, err)
+	}
+	var bucketName string
+	if strings.ToLower(uri.Scheme) == "gs" {
+		// gs://<bucket>
+		bucketName = uri.Host
+	} else {
+		// https://storage.googleapis.com/<bucket>
+		bucketName = strings.Split(strings.TrimPrefix(uri.Path, "/"), "/")[0]
+	}
+	if bucketName == "" {
+		return "", fmt.Errorf("no bucket name provided in %s", bucketURL)
+	}
+	return bucketName, nil
+}
@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	mpiecestore "github.com/bnb-chain/greenfield-storage-provider/model/piecestore"
+)
+
+func TestParseGCSBucketURL(t *testing.T) {
+	cases := []struct {
+		name       string
+		bucketURL  string
+		bucketName string
+		wantedErr  bool
+	}{
+		{
+			name:       "gs url",
+			bucketURL:  "gs://test/",
+			bucketName: "test",
+		},
+		{
+			name:       "https url",
+			bucketURL:  "https://storage.googleapis.com/test",
+			bucketName: "test",
+		},
+		{
+			name:      "no bucket",
+			bucketURL: "https://storage.googleapis.com",
+			wantedErr: true,
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			bucketName, err := parseGCSBucketURL(tt.bucketURL)
+			if tt.wantedErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.bucketName, bucketName)
+		})
+	}
+}
+
+func TestNewGCSStore_AKSK(t *testing.T) {
+	t.Setenv(mpiecestore.GCSCredentialsFile, "")
+	t.Setenv(mpiecestore.GCSEmulatorHost, "")
+	cfg := ObjectStorageConfig{BucketURL: "gs://test/", IAMType: mpiecestore.AKSKIAMType}
+	// the credentials file is required to access gcs
+	_, err := newGCSStore(cfg)
+	assert.NotNil(t, err)
+
+	// the emulator is accessed without the credentials file
+	t.Setenv(mpiecestore.GCSEmulatorHost, "127.0.0.1:4443")
+	store, err := newGCSStore(cfg)
+	assert.Nil(t, err)
+	assert.Equal(t, "gs://test/", store.(*gcsStore).String())
+}
@@ -26,9 +26,12 @@ type ObjectStorage interface {
 	HeadBucket(ctx context.Context) error
 	// HeadObject returns some information about the object or an error if not found
 	HeadObject(ctx context.Context, key string) (Object, error)
-	// ListObjects lists returns a list of objects
+	// ListObjects returns at most limit(1000 if not positive) sorted objects whose key starts with prefix and is
+	// greater than marker, the keys containing delimiter after prefix are rolled up into one directory object
 	ListObjects(ctx context.Context, prefix, marker, delimiter string, limit int64) ([]Object, error)
-	// ListAllObjects returns all the objects as a channel
+	// ListAllObjects returns all the objects whose key starts with prefix and is greater than marker as a sorted
+	// channel, the objects are listed page by page while the channel is consumed. If listing fails halfway, the
+	// error is sent as the last object of the channel, which is checked by ListError
 	ListAllObjects(ctx context.Context, prefix, marker string) (<-chan Object, error)
 }
 
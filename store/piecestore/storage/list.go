@@ -0,0 +1,171 @@
+package storage
+
+import (
+	"container/heap"
+	"context"
+	"strings"
+	"time"
+
+	"github.com/bnb-chain/greenfield-storage-provider/pkg/log"
+)
+
+// listPageSize defines the max number of objects listed in one page, it is also the default limit of ListObjects
+const listPageSize = 1000
+
+// listErrorObject is sent as the last object of the channel returned by ListAllObjects if listing fails halfway
+type listErrorObject struct {
+	object
+	err error
+}
+
+// ListError returns the error of listing if obj is the last object sent by ListAllObjects which fails halfway.
+// The objects received before it are valid, but the listing is truncated and must not be regarded as complete.
+func ListError(obj Object) error {
+	if errObj, ok := obj.(*listErrorObject); ok {
+		return errObj.err
+	}
+	return nil
+}
+
+// sendListError logs the error of listing halfway and sends it to the object channel as the last object
+func sendListError(ctx context.Context, objCh chan<- Object, err error) {
+	log.Errorw("failed to list objects halfway", "error", err)
+	select {
+	case objCh <- &listErrorObject{err: err}:
+	case <-ctx.Done():
+	}
+}
+
+type listAllFunc func(ctx context.Context, prefix, marker string) (<-chan Object, error)
+
+type listFunc func(ctx context.Context, prefix, marker, delimiter string, limit int64) ([]Object, error)
+
+// listObjects returns at most limit objects whose key starts with prefix and is greater than marker from the
+// sorted object channel of listAll. If delimiter is not empty, the keys which contain the delimiter after the
+// prefix are rolled up into one directory object whose key ends with the delimiter.
+func listObjects(ctx context.Context, listAll listAllFunc, prefix, marker, delimiter string, limit int64) ([]Object, error) {
+	if limit <= 0 {
+		limit = listPageSize
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	ch, err := listAll(ctx, prefix, marker)
+	if err != nil {
+		return nil, err
+	}
+	objs := make([]Object, 0)
+	var lastDir string
+	for obj := range ch {
+		if int64(len(objs)) >= limit {
+			break
+		}
+		if err = ListError(obj); err != nil {
+			return nil, err
+		}
+		if delimiter != "" {
+			if i := strings.Index(obj.Key()[len(prefix):], delimiter); i >= 0 {
+				dir := obj.Key()[:len(prefix)+i+len(delimiter)]
+				// the directory is skipped if it has been listed in this page or the previous pages
+				if dir == lastDir || dir <= marker {
+					continue
+				}
+				lastDir = dir
+				objs = append(objs, &object{dir, 0, time.Unix(0, 0), true})
+				continue
+			}
+		}
+		objs = append(objs, obj)
+	}
+	return objs, ctx.Err()
+}
+
+// listAllByPages returns all the objects whose key starts with prefix and is greater than marker as a channel by
+// listing the objects page by page, the error of the first page is returned directly, and the error of the later
+// pages is sent to the channel.
+func listAllByPages(ctx context.Context, list listFunc, prefix, marker string) (<-chan Object, error) {
+	objs, err := list(ctx, prefix, marker, "", listPageSize)
+	if err != nil {
+		return nil, err
+	}
+	objCh := make(chan Object, listPageSize)
+	go func() {
+		defer close(objCh)
+		for {
+			for _, obj := range objs {
+				select {
+				case objCh <- obj:
+				case <-ctx.Done():
+					return
+				}
+			}
+			if len(objs) < listPageSize {
+				return
+			}
+			if objs, err = list(ctx, prefix, objs[len(objs)-1].Key(), "", listPageSize); err != nil {
+				sendListError(ctx, objCh, err)
+				return
+			}
+		}
+	}()
+	return objCh, nil
+}
+
+// mergeObjectChannels merges the sorted object channels into one sorted channel, the object listed in several
+// channels is only returned once, and the object of the former channel is preferred. The merging stops at the
+// first list error of any channel, which is sent to the merged channel.
+func mergeObjectChannels(ctx context.Context, chs ...<-chan Object) <-chan Object {
+	objCh := make(chan Object, listPageSize)
+	go func() {
+		defer close(objCh)
+		h := &objectHeap{}
+		for i, ch := range chs {
+			if obj, ok := <-ch; ok {
+				heap.Push(h, objectHeapItem{obj: obj, index: i})
+			}
+		}
+		var lastKey string
+		for h.Len() > 0 {
+			item := heap.Pop(h).(objectHeapItem)
+			if err := ListError(item.obj); err != nil {
+				sendListError(ctx, objCh, err)
+				return
+			}
+			if obj, ok := <-chs[item.index]; ok {
+				heap.Push(h, objectHeapItem{obj: obj, index: item.index})
+			}
+			if item.obj.Key() == lastKey && lastKey != "" {
+				continue
+			}
+			lastKey = item.obj.Key()
+			select {
+			case objCh <- item.obj:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return objCh
+}
+
+type objectHeapItem struct {
+	obj   Object
+	index int
+}
+
+type objectHeap []objectHeapItem
+
+func (h objectHeap) Len() int { return len(h) }
+func (h objectHeap) Less(i, j int) bool {
+	if h[i].obj.Key() != h[j].obj.Key() {
+		return h[i].obj.Key() < h[j].obj.Key()
+	}
+	return h[i].index < h[j].index
+}
+func (h objectHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+func (h *objectHeap) Push(x any)   { *h = append(*h, x.(objectHeapItem)) }
+func (h *objectHeap) Pop() any {
+	old := *h
+	item := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return item
+}
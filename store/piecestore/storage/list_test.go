@@ -0,0 +1,168 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newObjectChannel(keys ...string) <-chan Object {
+	ch := make(chan Object, len(keys))
+	for _, key := range keys {
+		ch <- &object{key: key}
+	}
+	close(ch)
+	return ch
+}
+
+func TestMergeObjectChannels(t *testing.T) {
+	var keys []string
+	for obj := range mergeObjectChannels(context.TODO(),
+		newObjectChannel("a", "c", "e"), newObjectChannel("b", "c", "f"), newObjectChannel(), newObjectChannel("d")) {
+		keys = append(keys, obj.Key())
+	}
+	assert.Equal(t, []string{"a", "b", "c", "d", "e", "f"}, keys)
+}
+
+func TestListObjects_Paging(t *testing.T) {
+	listAll := func(ctx context.Context, prefix, marker string) (<-chan Object, error) {
+		var keys []string
+		for _, key := range []string{"a/1", "a/2", "b", "c/1", "c/2", "d"} {
+			if strings.HasPrefix(key, prefix) && key > marker {
+				keys = append(keys, key)
+			}
+		}
+		return newObjectChannel(keys...), nil
+	}
+	var keys []string
+	marker := ""
+	for {
+		objs, err := listObjects(context.TODO(), listAll, "", marker, "/", 2)
+		assert.Nil(t, err)
+		for _, obj := range objs {
+			keys = append(keys, obj.Key())
+		}
+		if len(objs) < 2 {
+			break
+		}
+		marker = objs[len(objs)-1].Key()
+	}
+	assert.Equal(t, []string{"a/", "b", "c/", "d"}, keys)
+}
+
+func TestSharding_ListObjects(t *testing.T) {
+	store, err := NewSharded(PieceStoreConfig{
+		Shards: 4,
+		Store:  ObjectStorageConfig{Storage: "file", BucketURL: t.TempDir() + "/%d/"},
+	})
+	assert.Nil(t, err)
+	var keys []string
+	for i := 0; i < 50; i++ {
+		key := fmt.Sprintf("%d_s%d", i, i%3)
+		keys = append(keys, key)
+		assert.Nil(t, store.PutObject(context.TODO(), key, strings.NewReader(key)))
+	}
+	sort.Strings(keys)
+
+	var listed []string
+	marker := ""
+	for {
+		objs, err := store.ListObjects(context.TODO(), "", marker, "", 7)
+		assert.Nil(t, err)
+		for _, obj := range objs {
+			listed = append(listed, obj.Key())
+		}
+		if len(objs) < 7 {
+			break
+		}
+		marker = objs[len(objs)-1].Key()
+	}
+	assert.Equal(t, keys, listed)
+}
+
+// truncatedListStore is the ObjectStorage whose listing fails after the objects of the wrapped store are listed
+type truncatedListStore struct {
+	ObjectStorage
+}
+
+func (s *truncatedListStore) ListAllObjects(ctx context.Context, prefix, marker string) (<-chan Object, error) {
+	ch, err := s.ObjectStorage.ListAllObjects(ctx, prefix, marker)
+	if err != nil {
+		return nil, err
+	}
+	objCh := make(chan Object)
+	go func() {
+		defer close(objCh)
+		for obj := range ch {
+			objCh <- obj
+		}
+		sendListError(ctx, objCh, errors.New("mock list error"))
+	}()
+	return objCh, nil
+}
+
+func TestListAllByPages_Error(t *testing.T) {
+	listErr := errors.New("mock list error")
+	list := func(ctx context.Context, prefix, marker, delimiter string, limit int64) ([]Object, error) {
+		if marker != "" {
+			return nil, listErr
+		}
+		objs := make([]Object, limit)
+		for i := range objs {
+			objs[i] = &object{key: fmt.Sprintf("%08d", i)}
+		}
+		return objs, nil
+	}
+	ch, err := listAllByPages(context.TODO(), list, "", "")
+	assert.Nil(t, err)
+	var number int
+	var lastErr error
+	for obj := range ch {
+		if lastErr = ListError(obj); lastErr == nil {
+			number++
+		}
+	}
+	assert.Equal(t, listPageSize, number)
+	assert.Equal(t, listErr, lastErr)
+}
+
+func TestListError_Propagation(t *testing.T) {
+	disk := &diskFileStore{root: t.TempDir() + "/"}
+	for _, key := range []string{"1_s0", "2_s0"} {
+		assert.Nil(t, disk.PutObject(context.TODO(), key, bytes.NewReader([]byte(key))))
+	}
+	truncated := &truncatedListStore{disk}
+
+	// the list error stops merging and is sent as the last object
+	ch, err := truncated.ListAllObjects(context.TODO(), "", "")
+	assert.Nil(t, err)
+	var keys []string
+	var lastErr error
+	for obj := range mergeObjectChannels(context.TODO(), ch, newObjectChannel("3_s0")) {
+		if lastErr = ListError(obj); lastErr == nil {
+			keys = append(keys, obj.Key())
+		}
+	}
+	assert.Equal(t, []string{"1_s0", "2_s0"}, keys)
+	assert.NotNil(t, lastErr)
+
+	// the truncated listing is not regarded as complete
+	_, err = listObjects(context.TODO(), truncated.ListAllObjects, "", "", "", 0)
+	assert.NotNil(t, err)
+	mirrored, err := NewMirroredStore([]ObjectStorage{truncated, &diskFileStore{root: t.TempDir() + "/"}}, 0)
+	assert.Nil(t, err)
+	result, err := mirrored.(MirroredStorage).Repair(context.TODO(),
+		func(ctx context.Context, key string, data []byte) error { return nil })
+	assert.NotNil(t, err)
+	assert.Equal(t, int64(2), result.Scanned)
+	picker, err := newShardPicker(ShardLayout{Shards: 1, Hashing: ConsistentShardHashing})
+	assert.Nil(t, err)
+	_, err = (&sharded{stores: []ObjectStorage{truncated}, current: picker}).Rebalance(context.TODO())
+	assert.NotNil(t, err)
+}
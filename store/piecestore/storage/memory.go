@@ -110,30 +110,39 @@ func (m *memoryStore) HeadObject(ctx context.Context, key string) (Object, error
 }
 
 func (m *memoryStore) ListObjects(ctx context.Context, prefix, marker, delimiter string, limit int64) ([]Object, error) {
-	if delimiter != "" {
-		return nil, errors.ErrUnsupportedDelimiter
-	}
-	m.Lock()
-	defer m.Unlock()
+	return listObjects(ctx, m.ListAllObjects, prefix, marker, delimiter, limit)
+}
 
+// ListAllObjects returns the sorted snapshot of the objects whose key starts with prefix and is greater than
+// marker as a channel.
+func (m *memoryStore) ListAllObjects(ctx context.Context, prefix, marker string) (<-chan Object, error) {
+	m.Lock()
 	objs := make([]Object, 0)
-	for k := range m.objects {
+	for k, o := range m.objects {
 		if strings.HasPrefix(k, prefix) && k > marker {
-			o := m.objects[k]
-			f := &object{
+			objs = append(objs, &object{
 				k,
 				int64(len(o.data)),
 				o.modTime,
 				false,
-			}
-			objs = append(objs, f)
+			})
 		}
 	}
+	m.Unlock()
 	sort.Slice(objs, func(i, j int) bool {
 		return objs[i].Key() < objs[j].Key()
 	})
-	if int64(len(objs)) > limit {
-		objs = objs[:limit]
-	}
-	return objs, nil
+
+	objCh := make(chan Object, listPageSize)
+	go func() {
+		defer close(objCh)
+		for _, obj := range objs {
+			select {
+			case objCh <- obj:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return objCh, nil
 }
@@ -221,30 +221,59 @@ func TestMemory_ListSuccess(t *testing.T) {
 	}
 }
 
-func TestMemory_ListError(t *testing.T) {
+func TestMemory_ListDelimiter(t *testing.T) {
+	store := setupMemoryTest(t)
+	store.objects = make(map[string]*memoryObject)
+	for _, key := range []string{"a/1", "a/2", "a.b", "b/c/1", "c"} {
+		assert.Nil(t, store.PutObject(context.TODO(), key, strings.NewReader(mockSecretKey)))
+	}
 	cases := []struct {
-		name      string
-		delimiter string
-		wantedErr error
+		name         string
+		prefix       string
+		marker       string
+		limit        int64
+		wantedResult []string
 	}{
 		{
-			name:      "memory_list_error_test1",
-			delimiter: mockKey,
-			wantedErr: errors.ErrUnsupportedDelimiter,
+			name:         "memory_list_delimiter_test1",
+			wantedResult: []string{"a.b", "a/", "b/", "c"},
+		},
+		{
+			name:         "memory_list_delimiter_test2",
+			marker:       "a/1",
+			limit:        2,
+			wantedResult: []string{"b/", "c"},
+		},
+		{
+			name:         "memory_list_delimiter_test3",
+			prefix:       "b/",
+			wantedResult: []string{"b/c/"},
 		},
 	}
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
-			store := setupMemoryTest(t)
-			objs, err := store.ListObjects(context.TODO(), emptyString, emptyString, tt.delimiter, 0)
-			assert.Equal(t, tt.wantedErr, err)
-			assert.Equal(t, 0, len(objs))
+			objs, err := store.ListObjects(context.TODO(), tt.prefix, tt.marker, "/", tt.limit)
+			assert.Nil(t, err)
+			keys := make([]string, 0, len(objs))
+			for _, obj := range objs {
+				keys = append(keys, obj.Key())
+			}
+			assert.Equal(t, tt.wantedResult, keys)
 		})
 	}
 }
 
 func TestMemory_ListAll(t *testing.T) {
 	store := setupMemoryTest(t)
-	_, err := store.ListAllObjects(context.TODO(), emptyString, emptyString)
-	assert.Equal(t, errors.ErrUnsupportedMethod, err)
+	store.objects = make(map[string]*memoryObject)
+	for _, key := range []string{"b", "a", "c"} {
+		assert.Nil(t, store.PutObject(context.TODO(), key, strings.NewReader(mockSecretKey)))
+	}
+	ch, err := store.ListAllObjects(context.TODO(), emptyString, "a")
+	assert.Nil(t, err)
+	var keys []string
+	for obj := range ch {
+		keys = append(keys, obj.Key())
+	}
+	assert.Equal(t, []string{"b", "c"}, keys)
 }
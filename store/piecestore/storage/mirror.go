@@ -0,0 +1,306 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	merrors "github.com/bnb-chain/greenfield-storage-provider/model/errors"
+	"github.com/bnb-chain/greenfield-storage-provider/pkg/log"
+)
+
+// ErrObjectDeleted is returned by RepairVerifier if the object has been deleted, e.g. by gc, the remaining
+// copies of the object are removed by repairing instead of being resynced to the other replicas
+var ErrObjectDeleted = errors.New("object has been deleted")
+
+// RepairVerifier verifies the data of the object read from a replica while repairing. It returns nil if the
+// data is right, ErrObjectDeleted if the object has been deleted, *merrors.PieceCorruptError if the data is
+// corrupt, and the object is not repaired on other errors.
+type RepairVerifier func(ctx context.Context, key string, data []byte) error
+
+// MirroredStorage is the ObjectStorage which writes every object to several replicas
+type MirroredStorage interface {
+	ObjectStorage
+	// Repair resyncs the replicas which miss the object or whose object fails to be verified
+	Repair(ctx context.Context, verify RepairVerifier) (*RepairResult, error)
+}
+
+// RepairResult is the statistics of repairing the mirrored storage
+type RepairResult struct {
+	Scanned  int64 // the number of scanned objects
+	Repaired int64 // the number of repaired replicas of objects
+	Removed  int64 // the number of replicas whose copies of the deleted objects are removed
+	Failed   int64 // the number of replicas of objects failed to be repaired
+}
+
+// mirroredStore writes the object to all the replicas, and the write succeeds if at least write quorum replicas
+// succeed. The reads fail over to the next replica in order, so a single disk or bucket failure is tolerated.
+type mirroredStore struct {
+	replicas    []ObjectStorage
+	writeQuorum int
+	DefaultObjectStorage
+}
+
+// NewMirroredStore returns a MirroredStorage of the replicas, writeQuorum is the min number of replicas succeeded
+// to put an object, all the replicas are required if it is zero.
+func NewMirroredStore(replicas []ObjectStorage, writeQuorum int) (MirroredStorage, error) {
+	if len(replicas) == 0 {
+		return nil, errors.New("no replica of mirrored storage")
+	}
+	if writeQuorum == 0 {
+		writeQuorum = len(replicas)
+	}
+	if writeQuorum < 0 || writeQuorum > len(replicas) {
+		return nil, fmt.Errorf("invalid write quorum %d of %d replicas", writeQuorum, len(replicas))
+	}
+	return &mirroredStore{replicas: replicas, writeQuorum: writeQuorum}, nil
+}
+
+func (m *mirroredStore) String() string {
+	names := make([]string, len(m.replicas))
+	for i, replica := range m.replicas {
+		names[i] = replica.String()
+	}
+	return fmt.Sprintf("mirror%d://%s", len(m.replicas), strings.Join(names, ","))
+}
+
+// forEach calls fn on all the replicas concurrently, and returns the errors of the replicas in order.
+func (m *mirroredStore) forEach(fn func(replica ObjectStorage) error) []error {
+	errs := make([]error, len(m.replicas))
+	var wg sync.WaitGroup
+	for i, replica := range m.replicas {
+		wg.Add(1)
+		go func(i int, replica ObjectStorage) {
+			defer wg.Done()
+			errs[i] = fn(replica)
+		}(i, replica)
+	}
+	wg.Wait()
+	return errs
+}
+
+// checkQuorum returns nil if at least quorum replicas succeed, otherwise returns the first error.
+func checkQuorum(errs []error, quorum int) error {
+	var firstErr error
+	succeeded := 0
+	for _, err := range errs {
+		if err == nil {
+			succeeded++
+		} else if firstErr == nil {
+			firstErr = err
+		}
+	}
+	if succeeded >= quorum {
+		return nil
+	}
+	return fmt.Errorf("only %d replicas succeeded, less than quorum %d: %w", succeeded, quorum, firstErr)
+}
+
+func (m *mirroredStore) CreateBucket(ctx context.Context) error {
+	errs := m.forEach(func(replica ObjectStorage) error {
+		return replica.CreateBucket(ctx)
+	})
+	return checkQuorum(errs, m.writeQuorum)
+}
+
+// HeadBucket returns merrors.ErrNoSuchBucket if any replica has no bucket, so that the bucket is created on
+// all the replicas; the unavailable replicas are tolerated if the write quorum is still satisfied.
+func (m *mirroredStore) HeadBucket(ctx context.Context) error {
+	errs := m.forEach(func(replica ObjectStorage) error {
+		return replica.HeadBucket(ctx)
+	})
+	for i, err := range errs {
+		if errors.Is(err, merrors.ErrNoSuchBucket) {
+			return err
+		}
+		if err != nil {
+			log.Errorw("failed to head bucket of replica", "replica", m.replicas[i], "error", err)
+		}
+	}
+	return checkQuorum(errs, m.writeQuorum)
+}
+
+func (m *mirroredStore) GetObject(ctx context.Context, key string, offset, limit int64) (io.ReadCloser, error) {
+	var (
+		rc  io.ReadCloser
+		err error
+	)
+	for _, replica := range m.replicas {
+		if rc, err = replica.GetObject(ctx, key, offset, limit); err == nil {
+			return rc, nil
+		}
+		log.Debugw("failed to get object from replica, fail over to next replica", "key", key,
+			"replica", replica, "error", err)
+	}
+	return nil, err
+}
+
+func (m *mirroredStore) PutObject(ctx context.Context, key string, reader io.Reader) error {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+	errs := m.forEach(func(replica ObjectStorage) error {
+		return replica.PutObject(ctx, key, bytes.NewReader(data))
+	})
+	for i, err := range errs {
+		if err != nil {
+			log.Errorw("failed to put object to replica", "key", key, "replica", m.replicas[i], "error", err)
+		}
+	}
+	return checkQuorum(errs, m.writeQuorum)
+}
+
+// DeleteObject deletes the object from all the replicas, it fails if any replica fails, otherwise the object
+// would be resynced to the other replicas by repairing.
+func (m *mirroredStore) DeleteObject(ctx context.Context, key string) error {
+	errs := m.forEach(func(replica ObjectStorage) error {
+		return replica.DeleteObject(ctx, key)
+	})
+	return checkQuorum(errs, len(m.replicas))
+}
+
+func (m *mirroredStore) HeadObject(ctx context.Context, key string) (Object, error) {
+	var (
+		obj Object
+		err error
+	)
+	for _, replica := range m.replicas {
+		if obj, err = replica.HeadObject(ctx, key); err == nil {
+			return obj, nil
+		}
+	}
+	return nil, err
+}
+
+func (m *mirroredStore) ListObjects(ctx context.Context, prefix, marker, delimiter string, limit int64) ([]Object, error) {
+	return listObjects(ctx, m.ListAllObjects, prefix, marker, delimiter, limit)
+}
+
+// ListAllObjects merges the sorted objects of all the replicas, the object in several replicas is only returned
+// once.
+func (m *mirroredStore) ListAllObjects(ctx context.Context, prefix, marker string) (<-chan Object, error) {
+	chs := make([]<-chan Object, len(m.replicas))
+	for i, replica := range m.replicas {
+		ch, err := replica.ListAllObjects(ctx, prefix, marker)
+		if err != nil {
+			return nil, err
+		}
+		chs[i] = ch
+	}
+	return mergeObjectChannels(ctx, chs...), nil
+}
+
+// Repair scans the objects of all the replicas, and copies the verified object to the replicas which miss it or
+// whose object fails to be verified. The remaining copies of the deleted objects are removed, e.g. the ones left
+// by a partially failed DeleteObject, so that the deleted objects are never resurrected by repairing.
+func (m *mirroredStore) Repair(ctx context.Context, verify RepairVerifier) (*RepairResult, error) {
+	result := &RepairResult{}
+	objects, err := m.ListAllObjects(ctx, "", "")
+	if err != nil {
+		log.Errorw("failed to list objects of replicas", "error", err)
+		return result, err
+	}
+	for obj := range objects {
+		if err = ListError(obj); err != nil {
+			log.Errorw("failed to list objects of replicas halfway", "error", err)
+			return result, err
+		}
+		result.Scanned++
+		m.repairObject(ctx, obj.Key(), verify, result)
+	}
+	return result, ctx.Err()
+}
+
+// repairObject resyncs the divergent replicas of the object and adds the outcome to result. The replica which
+// fails to be accessed by a transient error is neither regarded as missing the object nor overwritten.
+func (m *mirroredStore) repairObject(ctx context.Context, key string, verify RepairVerifier, result *RepairResult) {
+	var (
+		source    []byte
+		divergent []int // the replicas which miss the object or whose object is corrupt
+		present   []int // the replicas which have the object
+	)
+	for i, replica := range m.replicas {
+		if _, err := replica.HeadObject(ctx, key); errors.Is(err, os.ErrNotExist) {
+			divergent = append(divergent, i)
+			continue
+		} else if err != nil {
+			log.Errorw("failed to head object of replica", "key", key, "replica", replica, "error", err)
+			result.Failed++
+			continue
+		}
+		present = append(present, i)
+		data, err := readAllObject(ctx, replica, key)
+		if err != nil {
+			log.Errorw("failed to read object from replica", "key", key, "replica", replica, "error", err)
+			result.Failed++
+			continue
+		}
+		var corruptErr *merrors.PieceCorruptError
+		switch err = verify(ctx, key, data); {
+		case err == nil:
+			if source == nil {
+				source = data
+			}
+		case errors.As(err, &corruptErr):
+			log.Errorw("found corrupt object of replica", "key", key, "replica", replica)
+			divergent = append(divergent, i)
+		case errors.Is(err, ErrObjectDeleted):
+			m.removeObject(ctx, key, result)
+			return
+		default:
+			log.Errorw("failed to verify object, skip repairing it", "key", key, "error", err)
+			result.Failed++
+			return
+		}
+	}
+	if len(present) == 0 || len(divergent) == 0 {
+		// the object is deleted while repairing or all the replicas are healthy
+		return
+	}
+	if source == nil {
+		log.Errorw("failed to repair object due to no healthy replica", "key", key)
+		result.Failed += int64(len(divergent))
+		return
+	}
+	for _, i := range divergent {
+		if err := m.replicas[i].PutObject(ctx, key, bytes.NewReader(source)); err != nil {
+			log.Errorw("failed to repair object of replica", "key", key, "replica", m.replicas[i], "error", err)
+			result.Failed++
+			continue
+		}
+		log.Infow("succeed to repair object of replica", "key", key, "replica", m.replicas[i])
+		result.Repaired++
+	}
+}
+
+// removeObject removes the remaining copies of the deleted object from all the replicas.
+func (m *mirroredStore) removeObject(ctx context.Context, key string, result *RepairResult) {
+	for _, replica := range m.replicas {
+		if _, err := replica.HeadObject(ctx, key); errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err := replica.DeleteObject(ctx, key); err != nil {
+			log.Errorw("failed to remove deleted object of replica", "key", key, "replica", replica, "error", err)
+			result.Failed++
+			continue
+		}
+		log.Infow("succeed to remove deleted object of replica", "key", key, "replica", replica)
+		result.Removed++
+	}
+}
+
+// readAllObject reads the whole object from the store
+func readAllObject(ctx context.Context, store ObjectStorage, key string) ([]byte, error) {
+	rc, err := store.GetObject(ctx, key, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
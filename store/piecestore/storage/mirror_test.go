@@ -0,0 +1,130 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	merrors "github.com/bnb-chain/greenfield-storage-provider/model/errors"
+)
+
+func setupMirrorTest(t *testing.T, writeQuorum int) (*mirroredStore, []*diskFileStore) {
+	replicas := []*diskFileStore{{root: t.TempDir() + "/"}, {root: t.TempDir() + "/"}, {root: t.TempDir() + "/"}}
+	store, err := NewMirroredStore([]ObjectStorage{replicas[0], replicas[1], replicas[2]}, writeQuorum)
+	assert.Nil(t, err)
+	return store.(*mirroredStore), replicas
+}
+
+func TestMirror_InvalidQuorum(t *testing.T) {
+	_, err := NewMirroredStore(nil, 0)
+	assert.NotNil(t, err)
+	_, err = NewMirroredStore([]ObjectStorage{&diskFileStore{}}, 2)
+	assert.NotNil(t, err)
+}
+
+func TestMirror_PutAndFailover(t *testing.T) {
+	store, replicas := setupMirrorTest(t, 2)
+	assert.Nil(t, store.PutObject(context.TODO(), mockKey, bytes.NewReader([]byte("hello world"))))
+	for _, replica := range replicas {
+		assert.Equal(t, "hello world", readCachedObject(t, replica, mockKey, 0, 0))
+	}
+
+	// the reads fail over to the next replica
+	assert.Nil(t, replicas[0].DeleteObject(context.TODO(), mockKey))
+	assert.Nil(t, replicas[1].DeleteObject(context.TODO(), mockKey))
+	assert.Equal(t, "world", readCachedObject(t, store, mockKey, 6, 0))
+	obj, err := store.HeadObject(context.TODO(), mockKey)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(11), obj.Size())
+
+	assert.Nil(t, store.DeleteObject(context.TODO(), mockKey))
+	_, err = store.GetObject(context.TODO(), mockKey, 0, 0)
+	assert.NotNil(t, err)
+}
+
+func TestMirror_WriteQuorum(t *testing.T) {
+	store, replicas := setupMirrorTest(t, 2)
+	// the replica whose root is a file can not be written
+	replicas[2].root = createTempFile(t).Name() + "/"
+	assert.Nil(t, store.PutObject(context.TODO(), mockKey, bytes.NewReader([]byte("hello"))))
+
+	replicas[1].root = createTempFile(t).Name() + "/"
+	assert.NotNil(t, store.PutObject(context.TODO(), mockKey, bytes.NewReader([]byte("hello"))))
+}
+
+// mockRepairVerifier verifies the objects against the expected data, the objects without expected data are
+// regarded as deleted
+func mockRepairVerifier(expected map[string]string) RepairVerifier {
+	return func(ctx context.Context, key string, data []byte) error {
+		want, ok := expected[key]
+		if !ok {
+			return ErrObjectDeleted
+		}
+		if want != string(data) {
+			return &merrors.PieceCorruptError{Key: key}
+		}
+		return nil
+	}
+}
+
+// unavailableStore is the ObjectStorage which fails to access the objects with a transient error
+type unavailableStore struct {
+	ObjectStorage
+}
+
+func (s *unavailableStore) HeadObject(ctx context.Context, key string) (Object, error) {
+	return nil, errors.New("mock transient error")
+}
+
+func TestMirror_Repair(t *testing.T) {
+	store, replicas := setupMirrorTest(t, 0)
+	assert.Nil(t, store.PutObject(context.TODO(), "1_s0", bytes.NewReader([]byte("hello"))))
+	assert.Nil(t, store.PutObject(context.TODO(), "2_s0", bytes.NewReader([]byte("world"))))
+	// replica 0 misses a piece and replica 1 and 2 have a corrupt piece of the same size
+	assert.Nil(t, replicas[0].DeleteObject(context.TODO(), "1_s0"))
+	assert.Nil(t, replicas[1].PutObject(context.TODO(), "2_s0", bytes.NewReader([]byte("wxxld"))))
+	assert.Nil(t, replicas[2].PutObject(context.TODO(), "2_s0", bytes.NewReader([]byte("wxxld"))))
+	// the piece deleted by gc remains in replica 2 due to a partially failed DeleteObject
+	assert.Nil(t, replicas[2].PutObject(context.TODO(), "3_s0", bytes.NewReader([]byte("gc"))))
+
+	verify := mockRepairVerifier(map[string]string{"1_s0": "hello", "2_s0": "world"})
+	result, err := store.Repair(context.TODO(), verify)
+	assert.Nil(t, err)
+	assert.Equal(t, &RepairResult{Scanned: 3, Repaired: 3, Removed: 1}, result)
+	assert.Equal(t, "hello", readCachedObject(t, replicas[0], "1_s0", 0, 0))
+	for _, replica := range replicas {
+		assert.Equal(t, "world", readCachedObject(t, replica, "2_s0", 0, 0))
+		_, err = replica.HeadObject(context.TODO(), "3_s0")
+		assert.True(t, errors.Is(err, os.ErrNotExist))
+	}
+
+	result, err = store.Repair(context.TODO(), verify)
+	assert.Nil(t, err)
+	assert.Equal(t, &RepairResult{Scanned: 2}, result)
+}
+
+func TestMirror_RepairSkipsUnavailableReplica(t *testing.T) {
+	replicas := []*diskFileStore{{root: t.TempDir() + "/"}, {root: t.TempDir() + "/"}}
+	store, err := NewMirroredStore([]ObjectStorage{replicas[0], &unavailableStore{replicas[1]}}, 0)
+	assert.Nil(t, err)
+	assert.Nil(t, replicas[0].PutObject(context.TODO(), "1_s0", bytes.NewReader([]byte("hello"))))
+	assert.Nil(t, replicas[1].PutObject(context.TODO(), "1_s0", bytes.NewReader([]byte("world"))))
+
+	result, err := store.(MirroredStorage).Repair(context.TODO(), mockRepairVerifier(map[string]string{"1_s0": "hello"}))
+	assert.Nil(t, err)
+	assert.Equal(t, &RepairResult{Scanned: 1, Failed: 1}, result)
+	// the replica failed to be accessed is not overwritten
+	assert.Equal(t, "world", readCachedObject(t, replicas[1], "1_s0", 0, 0))
+}
+
+func TestMirror_RepairWithoutHealthyReplica(t *testing.T) {
+	store, _ := setupMirrorTest(t, 0)
+	assert.Nil(t, store.PutObject(context.TODO(), "1_s0", bytes.NewReader([]byte("hello"))))
+	result, err := store.Repair(context.TODO(), mockRepairVerifier(map[string]string{"1_s0": "world"}))
+	assert.Nil(t, err)
+	assert.Equal(t, &RepairResult{Scanned: 1, Failed: 3}, result)
+}
@@ -23,10 +23,13 @@ func NewObjectStorage(cfg ObjectStorageConfig) (ObjectStorage, error) {
 type StorageFn func(cfg ObjectStorageConfig) (ObjectStorage, error)
 
 var storageMap = map[string]StorageFn{
-	mpiecestore.S3Store:       newS3Store,
-	mpiecestore.MinioStore:    newMinioStore,
-	mpiecestore.DiskFileStore: newDiskFileStore,
-	mpiecestore.MemoryStore:   newMemoryStore,
+	mpiecestore.S3Store:        newS3Store,
+	mpiecestore.MinioStore:     newMinioStore,
+	mpiecestore.DiskFileStore:  newDiskFileStore,
+	mpiecestore.MemoryStore:    newMemoryStore,
+	mpiecestore.AzureBlobStore: newAzureBlobStore,
+	mpiecestore.GCSStore:       newGCSStore,
+	mpiecestore.OSSStore:       newOSSStore,
 }
 
 type DefaultObjectStorage struct{}
@@ -0,0 +1,278 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aliyun/aliyun-oss-go-sdk/oss"
+
+	"github.com/bnb-chain/greenfield-storage-provider/model"
+	merrors "github.com/bnb-chain/greenfield-storage-provider/model/errors"
+	mpiecestore "github.com/bnb-chain/greenfield-storage-provider/model/piecestore"
+	"github.com/bnb-chain/greenfield-storage-provider/pkg/log"
+)
+
+var _ ObjectStorage = &ossStore{}
+
+const (
+	// ecsMetadataCredentialsURL is the url of ecs metadata service to get the credentials of ram role
+	ecsMetadataCredentialsURL = "http://100.100.100.200/latest/meta-data/ram/security-credentials/"
+	// ecsMetadataTimeout defines the timeout of getting the credentials from ecs metadata service
+	ecsMetadataTimeout = 5 * time.Second
+	// ecsCredentialsRefreshWindow defines the time before expiration when the credentials are refreshed
+	ecsCredentialsRefreshWindow = 5 * time.Minute
+)
+
+type ossStore struct {
+	client *oss.Client
+	bucket *oss.Bucket
+	DefaultObjectStorage
+}
+
+// newOSSStore returns the alibaba cloud object storage service, the bucket url is in the format of
+// https://<bucket>.<endpoint> or https://<endpoint>/<bucket>, e.g. https://test.oss-cn-hangzhou.aliyuncs.com.
+func newOSSStore(cfg ObjectStorageConfig) (ObjectStorage, error) {
+	endpoint, bucketName, err := parseOSSBucketURL(cfg.BucketURL)
+	if err != nil {
+		log.Errorw("failed to parse oss bucket url", "error", err)
+		return nil, err
+	}
+
+	// If IAM type is AKSK, you must provide access key, secret key and session token(optional) to access oss bucket.
+	// If IAM type is SA, the credentials of the ram role of ecs instance are used.
+	opts := []oss.ClientOption{oss.HTTPClient(getHTTPClient(cfg.TLSInsecureSkipVerify))}
+	var accessKey, secretKey string
+	switch cfg.IAMType {
+	case mpiecestore.AKSKIAMType:
+		key := getSecretKeyFromEnv(mpiecestore.OSSAccessKey, mpiecestore.OSSSecretKey, mpiecestore.OSSSessionToken)
+		accessKey, secretKey = key.accessKey, key.secretKey
+		if key.sessionToken != "" {
+			opts = append(opts, oss.SecurityToken(key.sessionToken))
+		}
+	case mpiecestore.SAIAMType:
+		roleName, ok := os.LookupEnv(mpiecestore.OSSECSRoleName)
+		if !ok {
+			return nil, fmt.Errorf("failed to use sa to access oss due to missing %s", mpiecestore.OSSECSRoleName)
+		}
+		opts = append(opts, oss.SetCredentialsProvider(newECSRAMRoleCredentialsProvider(ecsMetadataCredentialsURL, roleName)))
+	default:
+		log.Errorf("unknown IAM type: %s", cfg.IAMType)
+		return nil, fmt.Errorf("unknown IAM type: %s", cfg.IAMType)
+	}
+	client, err := oss.New(endpoint, accessKey, secretKey, opts...)
+	if err != nil {
+		log.Errorw("failed to create oss client", "error", err)
+		return nil, err
+	}
+	client.Config.RetryTimes = uint(cfg.MaxRetries)
+	bucket, err := client.Bucket(bucketName)
+	if err != nil {
+		log.Errorw("failed to create oss bucket client", "error", err)
+		return nil, err
+	}
+	log.Infow("new oss store succeeds", "bucket", bucketName)
+	return &ossStore{client: client, bucket: bucket}, nil
+}
+
+func (o *ossStore) String() string {
+	return fmt.Sprintf("oss://%s/", o.bucket.BucketName)
+}
+
+func (o *ossStore) CreateBucket(ctx context.Context) error {
+	err := o.client.CreateBucket(o.bucket.BucketName, oss.WithContext(ctx))
+	if err != nil && ossErrorCode(err) == "BucketAlreadyExists" {
+		log.Errorw("oss failed to create bucket", "error", err)
+		err = nil
+	}
+	return err
+}
+
+// GetObject gets the object from oss, the crc64 checksum of the full object read is verified by oss client.
+func (o *ossStore) GetObject(ctx context.Context, key string, offset, limit int64) (io.ReadCloser, error) {
+	opts := []oss.Option{oss.WithContext(ctx)}
+	if offset > 0 || limit > 0 {
+		if limit > 0 {
+			opts = append(opts, oss.Range(offset, offset+limit-1))
+		} else {
+			opts = append(opts, oss.NormalizedRange(fmt.Sprintf("%d-", offset)))
+		}
+	}
+	rc, err := o.bucket.GetObject(key, opts...)
+	if err != nil {
+		log.Errorw("oss failed to get object", "error", err)
+		return nil, err
+	}
+	return rc, nil
+}
+
+// PutObject puts the object to oss, the crc64 checksum is verified by oss client.
+func (o *ossStore) PutObject(ctx context.Context, key string, reader io.Reader) error {
+	return o.bucket.PutObject(key, reader, oss.ContentType(model.OctetStream), oss.WithContext(ctx))
+}
+
+// DeleteObject deletes the object from oss, deleting the not existed object succeeds.
+func (o *ossStore) DeleteObject(ctx context.Context, key string) error {
+	return o.bucket.DeleteObject(key, oss.WithContext(ctx))
+}
+
+func (o *ossStore) HeadBucket(ctx context.Context) error {
+	if _, err := o.client.GetBucketInfo(o.bucket.BucketName, oss.WithContext(ctx)); err != nil {
+		log.Errorw("oss failed to head bucket", "error", err)
+		if ossErrorCode(err) == "NoSuchBucket" {
+			return merrors.ErrNoSuchBucket
+		}
+		return err
+	}
+	return nil
+}
+
+func (o *ossStore) HeadObject(ctx context.Context, key string) (Object, error) {
+	header, err := o.bucket.GetObjectMeta(key, oss.WithContext(ctx))
+	if err != nil {
+		var srvErr oss.ServiceError
+		if errors.As(err, &srvErr) && srvErr.StatusCode == http.StatusNotFound {
+			err = os.ErrNotExist
+		}
+		log.Errorw("oss failed to head object", "error", err)
+		return nil, err
+	}
+	size, _ := strconv.ParseInt(header.Get(oss.HTTPHeaderContentLength), 10, 64)
+	modTime, _ := http.ParseTime(header.Get(oss.HTTPHeaderLastModified))
+	return &object{key, size, modTime, strings.HasSuffix(key, "/")}, nil
+}
+
+func (o *ossStore) ListObjects(ctx context.Context, prefix, marker, delimiter string, limit int64) ([]Object, error) {
+	if limit <= 0 {
+		limit = listPageSize
+	}
+	resp, err := o.bucket.ListObjects(oss.Prefix(prefix), oss.Marker(marker), oss.Delimiter(delimiter),
+		oss.MaxKeys(int(limit)), oss.WithContext(ctx))
+	if err != nil {
+		log.Errorw("oss failed to list objects", "error", err)
+		return nil, err
+	}
+	objs := make([]Object, 0, len(resp.Objects)+len(resp.CommonPrefixes))
+	for _, prop := range resp.Objects {
+		objs = append(objs, &object{prop.Key, prop.Size, prop.LastModified, strings.HasSuffix(prop.Key, "/")})
+	}
+	if delimiter != "" {
+		for _, p := range resp.CommonPrefixes {
+			objs = append(objs, &object{p, 0, time.Unix(0, 0), true})
+		}
+		sort.Slice(objs, func(i, j int) bool { return objs[i].Key() < objs[j].Key() })
+	}
+	return objs, nil
+}
+
+func (o *ossStore) ListAllObjects(ctx context.Context, prefix, marker string) (<-chan Object, error) {
+	return listAllByPages(ctx, o.ListObjects, prefix, marker)
+}
+
+func ossErrorCode(err error) string {
+	var srvErr oss.ServiceError
+	if errors.As(err, &srvErr) {
+		return srvErr.Code
+	}
+	return ""
+}
+
+// parseOSSBucketURL returns the endpoint and bucket name of the bucket url
+func parseOSSBucketURL(bucketURL string) (string, string, error) {
+	uri, err := url.ParseRequestURI(strings.TrimSuffix(bucketURL, "/"))
+	if err != nil {
+		return "", "", fmt.Errorf("invalid oss bucket url %s: %s", bucketURL, err)
+	}
+	if uri.Path != "" {
+		// Path style: https://oss-<region>.aliyuncs.com/<bucket>
+		bucketName := strings.Split(strings.TrimPrefix(uri.Path, "/"), "/")[0]
+		return fmt.Sprintf("%s://%s", uri.Scheme, uri.Host), bucketName, nil
+	}
+	// Virtual hosted style: https://<bucket>.oss-<region>.aliyuncs.com
+	hostParts := strings.SplitN(uri.Host, ".", 2)
+	if len(hostParts) != 2 {
+		return "", "", fmt.Errorf("no bucket name provided in %s", bucketURL)
+	}
+	return fmt.Sprintf("%s://%s", uri.Scheme, hostParts[1]), hostParts[0], nil
+}
+
+// ecsRAMRoleCredentialsProvider gets the temporary credentials of the ram role attached to the ecs instance
+// from the ecs metadata service, the credentials are refreshed before expiration.
+type ecsRAMRoleCredentialsProvider struct {
+	url        string
+	client     *http.Client
+	mu         sync.Mutex
+	credential ecsRAMRoleCredentials
+}
+
+type ecsRAMRoleCredentials struct {
+	Code            string
+	AccessKeyID     string `json:"AccessKeyId"`
+	AccessKeySecret string
+	SecurityToken   string
+	Expiration      time.Time
+}
+
+func (c ecsRAMRoleCredentials) GetAccessKeyID() string     { return c.AccessKeyID }
+func (c ecsRAMRoleCredentials) GetAccessKeySecret() string { return c.AccessKeySecret }
+func (c ecsRAMRoleCredentials) GetSecurityToken() string   { return c.SecurityToken }
+
+// newECSRAMRoleCredentialsProvider returns the credentials provider of the ram role from the metadata service url
+func newECSRAMRoleCredentialsProvider(metadataURL, roleName string) *ecsRAMRoleCredentialsProvider {
+	return &ecsRAMRoleCredentialsProvider{
+		url:    metadataURL + roleName,
+		client: &http.Client{Timeout: ecsMetadataTimeout},
+	}
+}
+
+// GetCredentials returns the cached credentials if they are not about to expire, otherwise refreshes them from
+// the metadata service without holding the lock, and the cached credentials are returned if refreshing fails.
+func (p *ecsRAMRoleCredentialsProvider) GetCredentials() oss.Credentials {
+	p.mu.Lock()
+	credential := p.credential
+	p.mu.Unlock()
+	if time.Until(credential.Expiration) > ecsCredentialsRefreshWindow {
+		return credential
+	}
+	refreshed, err := p.fetchCredentials()
+	if err != nil {
+		log.Errorw("failed to get ecs ram role credentials", "url", p.url, "error", err)
+		return credential
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if refreshed.Expiration.After(p.credential.Expiration) {
+		p.credential = refreshed
+	}
+	return p.credential
+}
+
+// fetchCredentials gets the credentials of the ram role from the metadata service
+func (p *ecsRAMRoleCredentialsProvider) fetchCredentials() (ecsRAMRoleCredentials, error) {
+	var credential ecsRAMRoleCredentials
+	resp, err := p.client.Get(p.url)
+	if err != nil {
+		return credential, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return credential, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&credential); err != nil {
+		return credential, err
+	}
+	if credential.Code != "Success" {
+		return credential, fmt.Errorf("unexpected code %s", credential.Code)
+	}
+	return credential, nil
+}
@@ -0,0 +1,163 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	mpiecestore "github.com/bnb-chain/greenfield-storage-provider/model/piecestore"
+)
+
+func TestParseOSSBucketURL(t *testing.T) {
+	cases := []struct {
+		name       string
+		bucketURL  string
+		endpoint   string
+		bucketName string
+		wantedErr  bool
+	}{
+		{
+			name:       "virtual hosted style",
+			bucketURL:  "https://test.oss-cn-hangzhou.aliyuncs.com",
+			endpoint:   "https://oss-cn-hangzhou.aliyuncs.com",
+			bucketName: "test",
+		},
+		{
+			name:       "path style",
+			bucketURL:  "http://oss-cn-hangzhou.aliyuncs.com/test/",
+			endpoint:   "http://oss-cn-hangzhou.aliyuncs.com",
+			bucketName: "test",
+		},
+		{
+			name:      "no bucket",
+			bucketURL: "https://localhost",
+			wantedErr: true,
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			endpoint, bucketName, err := parseOSSBucketURL(tt.bucketURL)
+			if tt.wantedErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.endpoint, endpoint)
+			assert.Equal(t, tt.bucketName, bucketName)
+		})
+	}
+}
+
+// newFakeOSSServer returns the fake oss server which keeps the objects of path style requests in memory
+func newFakeOSSServer(t *testing.T) *httptest.Server {
+	var (
+		mu      sync.Mutex
+		objects = make(map[string][]byte)
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.Method {
+		case http.MethodPut:
+			data, _ := io.ReadAll(r.Body)
+			objects[r.URL.Path] = data
+		case http.MethodDelete:
+			delete(objects, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			data, ok := objects[r.URL.Path]
+			if !ok {
+				w.Header().Set("Content-Type", "application/xml")
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte("<Error><Code>NoSuchKey</Code></Error>"))
+				return
+			}
+			http.ServeContent(w, r, "", time.Unix(0, 0), bytes.NewReader(data))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestOSSStore_Object(t *testing.T) {
+	t.Setenv(mpiecestore.OSSAccessKey, "ak")
+	t.Setenv(mpiecestore.OSSSecretKey, "sk")
+	server := newFakeOSSServer(t)
+	store, err := newOSSStore(ObjectStorageConfig{BucketURL: server.URL + "/test", IAMType: mpiecestore.AKSKIAMType})
+	assert.Nil(t, err)
+
+	assert.Nil(t, store.PutObject(context.TODO(), mockKey, strings.NewReader("hello world")))
+	assert.Equal(t, "hello world", readCachedObject(t, store, mockKey, 0, 0))
+	assert.Equal(t, "world", readCachedObject(t, store, mockKey, 6, 0))
+	assert.Equal(t, "lo", readCachedObject(t, store, mockKey, 3, 2))
+	obj, err := store.HeadObject(context.TODO(), mockKey)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(11), obj.Size())
+
+	assert.Nil(t, store.DeleteObject(context.TODO(), mockKey))
+	_, err = store.HeadObject(context.TODO(), mockKey)
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+}
+
+func TestNewOSSStore_SAWithoutRoleName(t *testing.T) {
+	t.Setenv(mpiecestore.OSSECSRoleName, "")
+	assert.Nil(t, os.Unsetenv(mpiecestore.OSSECSRoleName))
+	_, err := newOSSStore(ObjectStorageConfig{BucketURL: "https://test.oss-cn-hangzhou.aliyuncs.com",
+		IAMType: mpiecestore.SAIAMType})
+	assert.NotNil(t, err)
+}
+
+func TestECSRAMRoleCredentialsProvider(t *testing.T) {
+	var (
+		requests   int32
+		expiration = time.Now().Add(time.Hour)
+		delay      atomic.Value
+	)
+	delay.Store(time.Duration(0))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		time.Sleep(delay.Load().(time.Duration))
+		assert.True(t, strings.HasSuffix(r.URL.Path, "/role"))
+		_ = json.NewEncoder(w).Encode(ecsRAMRoleCredentials{Code: "Success", AccessKeyID: "ak",
+			AccessKeySecret: "sk", SecurityToken: "token", Expiration: expiration})
+	}))
+	defer server.Close()
+
+	provider := newECSRAMRoleCredentialsProvider(server.URL+"/", "role")
+	provider.client.Timeout = 100 * time.Millisecond
+	credential := provider.GetCredentials()
+	assert.Equal(t, "ak", credential.GetAccessKeyID())
+	assert.Equal(t, "sk", credential.GetAccessKeySecret())
+	assert.Equal(t, "token", credential.GetSecurityToken())
+	// the credentials are cached until they are about to expire
+	provider.GetCredentials()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+
+	// the cached credentials are returned if refreshing times out
+	provider.mu.Lock()
+	provider.credential.Expiration = time.Now().Add(time.Minute)
+	provider.mu.Unlock()
+	delay.Store(time.Second)
+	start := time.Now()
+	assert.Equal(t, "ak", provider.GetCredentials().GetAccessKeyID())
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+
+	delay.Store(time.Duration(0))
+	provider.GetCredentials()
+	provider.mu.Lock()
+	assert.True(t, provider.credential.Expiration.Equal(expiration))
+	provider.mu.Unlock()
+}
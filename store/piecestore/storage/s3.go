@@ -180,6 +180,9 @@ func (s *s3Store) HeadObject(ctx context.Context, key string) (Object, error) {
 }
 
 func (s *s3Store) ListObjects(ctx context.Context, prefix, marker, delimiter string, limit int64) ([]Object, error) {
+	if limit <= 0 {
+		limit = listPageSize
+	}
 	param := &s3.ListObjectsInput{
 		Bucket:    aws.String(s.bucketName),
 		Prefix:    aws.String(prefix),
@@ -218,7 +221,7 @@ func (s *s3Store) ListObjects(ctx context.Context, prefix, marker, delimiter str
 }
 
 func (s *s3Store) ListAllObjects(ctx context.Context, prefix, marker string) (<-chan Object, error) {
-	return nil, merrors.ErrUnsupportedMethod
+	return listAllByPages(ctx, s.ListObjects, prefix, marker)
 }
 
 // SessionCache holds session.Session according to ObjectStorageConfig and it synchronizes access/modification
@@ -16,7 +16,6 @@ import (
 	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 	"github.com/stretchr/testify/assert"
 
-	merrors "github.com/bnb-chain/greenfield-storage-provider/model/errors"
 	mpiecestore "github.com/bnb-chain/greenfield-storage-provider/model/piecestore"
 )
 
@@ -285,8 +284,18 @@ func TestS3_ListSuccess(t *testing.T) {
 
 func TestS3_ListAll(t *testing.T) {
 	store := setupS3Test(t)
-	_, err := store.ListAllObjects(context.TODO(), emptyString, emptyString)
-	assert.Equal(t, merrors.ErrUnsupportedMethod, err)
+	store.api = mockS3Client{listObjectsResp: s3.ListObjectsOutput{Contents: []*s3.Object{{
+		Key:          aws.String(mockKey),
+		LastModified: aws.Time(mockModifiedTime),
+		Size:         aws.Int64(mockSize),
+	}}}}
+	ch, err := store.ListAllObjects(context.TODO(), emptyString, emptyString)
+	assert.Nil(t, err)
+	var keys []string
+	for obj := range ch {
+		keys = append(keys, obj.Key())
+	}
+	assert.Equal(t, []string{mockKey}, keys)
 }
 
 type mockS3ClientError struct {
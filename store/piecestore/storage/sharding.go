@@ -1,34 +1,160 @@
 package storage
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"hash/fnv"
 	"io"
+	"sort"
 	"strings"
+
+	"github.com/bnb-chain/greenfield-storage-provider/pkg/log"
+)
+
+// define the shard hashing algorithms
+const (
+	// ModuloShardHashing picks the shard by fnv32a(key) % shards, almost all the keys are moved if the
+	// number of shards changes, it is the default algorithm so the sharded pieces keep their placement
+	ModuloShardHashing = "modulo"
+	// ConsistentShardHashing picks the shard by the consistent hash ring, only about 1/shards of the keys
+	// are moved if one shard is added or removed
+	ConsistentShardHashing = "consistent"
 )
 
+// consistentHashVirtualNodes defines the number of virtual nodes of every shard in the consistent hash ring
+const consistentHashVirtualNodes = 128
+
+// ShardedStorage is the ObjectStorage which spreads the objects into several shards
+type ShardedStorage interface {
+	ObjectStorage
+	// Rebalance moves the objects which are not in the shard picked by the current shard layout
+	// to the right shard
+	Rebalance(ctx context.Context) (*RebalanceResult, error)
+}
+
+// RebalanceResult is the statistics of rebalancing the sharded storage
+type RebalanceResult struct {
+	Scanned int64 // the number of scanned objects
+	Moved   int64 // the number of objects moved to the right shard
+	Failed  int64 // the number of objects failed to be moved
+}
+
+// shardPicker picks the shard index of the key
+type shardPicker interface {
+	pick(key string) int
+}
+
+func newShardPicker(layout ShardLayout) (shardPicker, error) {
+	if layout.Shards <= 0 {
+		return nil, fmt.Errorf("invalid number of shards: %d", layout.Shards)
+	}
+	switch strings.ToLower(layout.Hashing) {
+	case "", ModuloShardHashing:
+		return moduloPicker(layout.Shards), nil
+	case ConsistentShardHashing:
+		return newConsistentPicker(layout.Shards), nil
+	default:
+		return nil, fmt.Errorf("invalid shard hashing: %s", layout.Hashing)
+	}
+}
+
+type moduloPicker uint32
+
+func (m moduloPicker) pick(key string) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return int(h.Sum32() % uint32(m))
+}
+
+// consistentPicker is the consistent hash ring, the virtual nodes of a shard only depend on the shard index,
+// so the shards keep most of their keys when the number of shards changes.
+type consistentPicker struct {
+	hashes []uint64
+	shards map[uint64]int
+}
+
+func newConsistentPicker(shards int) *consistentPicker {
+	c := &consistentPicker{shards: make(map[uint64]int, shards*consistentHashVirtualNodes)}
+	for i := 0; i < shards; i++ {
+		for v := 0; v < consistentHashVirtualNodes; v++ {
+			h := hashKey64(fmt.Sprintf("shard-%d-%d", i, v))
+			if _, ok := c.shards[h]; ok {
+				continue
+			}
+			c.shards[h] = i
+			c.hashes = append(c.hashes, h)
+		}
+	}
+	sort.Slice(c.hashes, func(i, j int) bool { return c.hashes[i] < c.hashes[j] })
+	return c
+}
+
+func (c *consistentPicker) pick(key string) int {
+	h := hashKey64(key)
+	i := sort.Search(len(c.hashes), func(i int) bool { return c.hashes[i] >= h })
+	if i == len(c.hashes) {
+		i = 0
+	}
+	return c.shards[c.hashes[i]]
+}
+
+func hashKey64(key string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key))
+	return h.Sum64()
+}
+
+// sharded places the objects by the current shard layout, and reads the objects from the shard picked by the
+// previous shard layout if they are not found, so the objects keep readable until they are rebalanced.
 type sharded struct {
-	stores []ObjectStorage
+	stores   []ObjectStorage
+	current  shardPicker
+	previous shardPicker // nil if there is no resharding in progress
 	DefaultObjectStorage
 }
 
-func NewSharded(cfg PieceStoreConfig) (ObjectStorage, error) {
-	stores := make([]ObjectStorage, cfg.Shards)
-	var err error
+func NewSharded(cfg PieceStoreConfig) (ShardedStorage, error) {
+	current, err := newShardPicker(ShardLayout{Shards: cfg.Shards, Hashing: cfg.ShardHashing})
+	if err != nil {
+		return nil, err
+	}
+	s := &sharded{current: current}
+	number := cfg.Shards
+	// the unsharded piece store is not in the shard endpoint generated from the bucket url, so its pieces can
+	// not be found by the previous shard layout
+	if cfg.PreviousShardLayout.Shards == 1 {
+		return nil, errors.New("resharding from an unsharded piece store is not supported")
+	}
+	// and the unsharded piece store can not be the target of resharding for the same reason
+	if cfg.PreviousShardLayout.Shards > 0 && cfg.Shards <= 1 {
+		return nil, errors.New("resharding to an unsharded piece store is not supported")
+	}
+	if cfg.PreviousShardLayout.Shards > 0 {
+		if s.previous, err = newShardPicker(cfg.PreviousShardLayout); err != nil {
+			return nil, err
+		}
+		if cfg.PreviousShardLayout.Shards > number {
+			number = cfg.PreviousShardLayout.Shards
+		}
+	}
+	// the endpoint of shard only depends on its index, the shards removed from the current layout are
+	// still accessed while resharding
+	s.stores = make([]ObjectStorage, number)
 	shardingURL := cfg.Store.BucketURL
-	for i := range stores {
+	for i := range s.stores {
 		ep := fmt.Sprintf(shardingURL, i)
 		if strings.HasSuffix(ep, "%!(EXTRA int=0)") {
 			return nil, fmt.Errorf("can not generate different endpoint using %s", shardingURL)
 		}
 		cfg.Store.BucketURL = ep
-		stores[i], err = NewObjectStorage(cfg.Store)
+		s.stores[i], err = NewObjectStorage(cfg.Store)
 		if err != nil {
 			return nil, err
 		}
 	}
-	return &sharded{stores: stores}, nil
+	return s, nil
 }
 
 func (s *sharded) String() string {
@@ -45,22 +171,43 @@ func (s *sharded) CreateBucket(ctx context.Context) error {
 }
 
 func (s *sharded) pick(key string) ObjectStorage {
-	h := fnv.New32a()
-	_, _ = h.Write([]byte(key))
-	i := h.Sum32() % uint32(len(s.stores))
-	return s.stores[i]
+	return s.stores[s.current.pick(key)]
+}
+
+// pickPrevious returns the shard picked by the previous shard layout, nil if it is the same as the current one.
+func (s *sharded) pickPrevious(key string) ObjectStorage {
+	if s.previous == nil {
+		return nil
+	}
+	if i := s.previous.pick(key); i != s.current.pick(key) {
+		return s.stores[i]
+	}
+	return nil
 }
 
 func (s *sharded) GetObject(ctx context.Context, key string, off, limit int64) (io.ReadCloser, error) {
-	return s.pick(key).GetObject(ctx, key, off, limit)
+	rc, err := s.pick(key).GetObject(ctx, key, off, limit)
+	if err != nil {
+		if previous := s.pickPrevious(key); previous != nil {
+			return previous.GetObject(ctx, key, off, limit)
+		}
+	}
+	return rc, err
 }
 
 func (s *sharded) PutObject(ctx context.Context, key string, body io.Reader) error {
 	return s.pick(key).PutObject(ctx, key, body)
 }
 
+// DeleteObject deletes the object from the shards picked by both the current and the previous shard layout.
 func (s *sharded) DeleteObject(ctx context.Context, key string) error {
-	return s.pick(key).DeleteObject(ctx, key)
+	if err := s.pick(key).DeleteObject(ctx, key); err != nil {
+		return err
+	}
+	if previous := s.pickPrevious(key); previous != nil {
+		return previous.DeleteObject(ctx, key)
+	}
+	return nil
 }
 
 func (s *sharded) HeadBucket(ctx context.Context) error {
@@ -73,5 +220,94 @@ func (s *sharded) HeadBucket(ctx context.Context) error {
 }
 
 func (s *sharded) HeadObject(ctx context.Context, key string) (Object, error) {
-	return s.pick(key).HeadObject(ctx, key)
+	obj, err := s.pick(key).HeadObject(ctx, key)
+	if err != nil {
+		if previous := s.pickPrevious(key); previous != nil {
+			return previous.HeadObject(ctx, key)
+		}
+	}
+	return obj, err
+}
+
+func (s *sharded) ListObjects(ctx context.Context, prefix, marker, delimiter string, limit int64) ([]Object, error) {
+	return listObjects(ctx, s.ListAllObjects, prefix, marker, delimiter, limit)
+}
+
+// ListAllObjects lists all the shards concurrently and merges their sorted objects, the object being rebalanced
+// is only returned once.
+func (s *sharded) ListAllObjects(ctx context.Context, prefix, marker string) (<-chan Object, error) {
+	chs := make([]<-chan Object, len(s.stores))
+	for i, o := range s.stores {
+		ch, err := o.ListAllObjects(ctx, prefix, marker)
+		if err != nil {
+			return nil, err
+		}
+		chs[i] = ch
+	}
+	return mergeObjectChannels(ctx, chs...), nil
+}
+
+// Rebalance scans all the shards, and moves the objects to the shard picked by the current shard layout.
+// The object is copied to the new shard before it is deleted from the old shard, so it keeps readable during
+// rebalancing. The previous shard layout can be removed from the config after rebalancing is finished.
+func (s *sharded) Rebalance(ctx context.Context) (*RebalanceResult, error) {
+	result := &RebalanceResult{}
+	for i, store := range s.stores {
+		ch, err := store.ListAllObjects(ctx, "", "")
+		if err != nil {
+			log.Errorw("failed to list objects of shard", "shard", i, "error", err)
+			return result, err
+		}
+		for obj := range ch {
+			if err = ListError(obj); err != nil {
+				log.Errorw("failed to list objects of shard halfway", "shard", i, "error", err)
+				return result, err
+			}
+			result.Scanned++
+			target := s.current.pick(obj.Key())
+			if target == i {
+				continue
+			}
+			if _, err = moveObject(ctx, store, s.stores[target], obj.Key()); err != nil {
+				log.Errorw("failed to move object to the right shard", "key", obj.Key(), "from", i,
+					"to", target, "error", err)
+				result.Failed++
+				continue
+			}
+			result.Moved++
+		}
+		if err = ctx.Err(); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// moveObject copies the object from the source store to the destination store, and then deletes it from the
+// source store, returns the size of the moved object. The object may be deleted concurrently, e.g. by gc, which
+// deletes the destination before the source, so the source is checked again after copying and the copy is
+// removed if the source is gone, otherwise the deleted object would be resurrected in the destination store.
+func moveObject(ctx context.Context, src, dst ObjectStorage, key string) (int64, error) {
+	rc, err := src.GetObject(ctx, key, 0, 0)
+	if err != nil {
+		return 0, err
+	}
+	data, err := io.ReadAll(rc)
+	_ = rc.Close()
+	if err != nil {
+		return 0, err
+	}
+	if err = dst.PutObject(ctx, key, bytes.NewReader(data)); err != nil {
+		return 0, err
+	}
+	if _, err = src.HeadObject(ctx, key); err != nil {
+		if deleteErr := dst.DeleteObject(ctx, key); deleteErr != nil {
+			log.Errorw("failed to remove the copy of object deleted while moving", "key", key, "error", deleteErr)
+		}
+		return 0, fmt.Errorf("object is deleted while moving: %w", err)
+	}
+	if err = src.DeleteObject(ctx, key); err != nil {
+		return 0, err
+	}
+	return int64(len(data)), nil
 }
@@ -0,0 +1,99 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSharding_InvalidLayout(t *testing.T) {
+	_, err := NewSharded(PieceStoreConfig{Shards: 2, ShardHashing: "unknown"})
+	assert.NotNil(t, err)
+	_, err = NewSharded(PieceStoreConfig{Shards: 2, Store: ObjectStorageConfig{Storage: "file", BucketURL: "/data/"}})
+	assert.NotNil(t, err)
+	// resharding from an unsharded piece store is rejected
+	_, err = NewSharded(PieceStoreConfig{Shards: 2, PreviousShardLayout: ShardLayout{Shards: 1, Hashing: ModuloShardHashing},
+		Store: ObjectStorageConfig{Storage: "file", BucketURL: t.TempDir() + "/%d/"}})
+	assert.NotNil(t, err)
+	// resharding to an unsharded piece store is rejected
+	for _, shards := range []int{0, 1} {
+		_, err = NewSharded(PieceStoreConfig{Shards: shards, PreviousShardLayout: ShardLayout{Shards: 2},
+			Store: ObjectStorageConfig{Storage: "file", BucketURL: t.TempDir() + "/%d/"}})
+		assert.NotNil(t, err)
+	}
+}
+
+func TestSharding_DefaultHashing(t *testing.T) {
+	// the pieces sharded without hashing configured keep their placement
+	picker, err := newShardPicker(ShardLayout{Shards: 4})
+	assert.Nil(t, err)
+	assert.IsType(t, moduloPicker(0), picker)
+	picker, err = newShardPicker(ShardLayout{Shards: 4, Hashing: ConsistentShardHashing})
+	assert.Nil(t, err)
+	assert.IsType(t, &consistentPicker{}, picker)
+}
+
+func TestSharding_ConsistentPicker(t *testing.T) {
+	before, after := newConsistentPicker(4), newConsistentPicker(5)
+	moved := 0
+	for i := 0; i < 10000; i++ {
+		key := fmt.Sprintf("%d_s%d", i, i%7)
+		if b, a := before.pick(key), after.pick(key); b != a {
+			// the keys are only moved to the new shard
+			assert.Equal(t, 4, a)
+			moved++
+		}
+	}
+	assert.Greater(t, moved, 1000)
+	assert.Less(t, moved, 3000)
+}
+
+func TestSharding_ReshardAndRebalance(t *testing.T) {
+	root := t.TempDir()
+	newStore := func(shards int, hashing string, previous ShardLayout) *sharded {
+		store, err := NewSharded(PieceStoreConfig{
+			Shards:              shards,
+			ShardHashing:        hashing,
+			PreviousShardLayout: previous,
+			Store:               ObjectStorageConfig{Storage: "file", BucketURL: root + "/%d/"},
+		})
+		assert.Nil(t, err)
+		return store.(*sharded)
+	}
+
+	old := newStore(2, ModuloShardHashing, ShardLayout{})
+	keys := make([]string, 100)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("%d_s0", i)
+		assert.Nil(t, old.PutObject(context.TODO(), keys[i], bytes.NewReader([]byte(keys[i]))))
+	}
+
+	// the pieces are readable by falling back to the previous shard layout while resharding
+	store := newStore(3, ConsistentShardHashing, ShardLayout{Shards: 2, Hashing: ModuloShardHashing})
+	for _, key := range keys {
+		assert.Equal(t, key, readCachedObject(t, store, key, 0, 0))
+	}
+
+	result, err := store.Rebalance(context.TODO())
+	assert.Nil(t, err)
+	// the pieces moved to the shards scanned later are scanned again
+	assert.GreaterOrEqual(t, result.Scanned, int64(100))
+	assert.Equal(t, int64(0), result.Failed)
+	assert.Greater(t, result.Moved, int64(0))
+
+	// the pieces are readable by the current shard layout only after rebalancing
+	store = newStore(3, ConsistentShardHashing, ShardLayout{})
+	for _, key := range keys {
+		assert.Equal(t, key, readCachedObject(t, store, key, 0, 0))
+	}
+	result, err = store.Rebalance(context.TODO())
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), result.Moved)
+
+	assert.Nil(t, store.DeleteObject(context.TODO(), keys[0]))
+	_, err = store.HeadObject(context.TODO(), keys[0])
+	assert.NotNil(t, err)
+}
@@ -2,8 +2,43 @@ package storage
 
 // PieceStoreConfig contains some parameters which are used to run PieceStore
 type PieceStoreConfig struct {
-	Shards int                 // store the blocks into N buckets by hash of key
-	Store  ObjectStorageConfig // config of object storage
+	Shards              int                 // store the blocks into N buckets by hash of key
+	ShardHashing        string              // the algorithm to pick the shard by key, modulo(default) or consistent
+	PreviousShardLayout ShardLayout         // the shard layout before resharding, reads fall back to it until rebalanced
+	Store               ObjectStorageConfig // config of object storage
+	VerifyRead          bool                // whether verify the full piece reads against the piece checksums in sp-db
+	Cache               PieceCacheConfig    // config of local read-through piece cache
+	Tier                TieredStoreConfig   // config of hot tier, the Store is the cold tier if the hot tier is configured
+	Mirror              MirrorStoreConfig   // config of mirrored replicas, the Store is the first replica if mirroring
+}
+
+// MirrorStoreConfig mirrored piece storage config, every piece is written to the Store and all the replicas,
+// the reads fail over to the next replica if the piece can not be read. It can not be used with sharding.
+type MirrorStoreConfig struct {
+	Replicas    []ObjectStorageConfig // config of the other replicas besides the Store, the mirroring is disabled if it is empty
+	WriteQuorum int                   // the min number of replicas succeeded to put a piece, all the replicas if it is zero
+}
+
+// ShardLayout defines how the pieces are spread into the shards. To change the shard layout, set the previous
+// shard layout to the old one, then run the piece.rebalance command to move the pieces to their new shards,
+// and clear the previous shard layout after rebalancing is finished. Resharding from or to an unsharded piece
+// store is not supported, since its pieces are not in the shard endpoints generated from the bucket url.
+type ShardLayout struct {
+	Shards  int    // the number of shards, there is no previous shard layout if it is zero
+	Hashing string // the algorithm to pick the shard by key, modulo(default) or consistent
+}
+
+// TieredStoreConfig tiered piece storage config, the new pieces are written to the hot tier, and migrated
+// to the cold tier by the manager's piece tier migrator. The hot tier must be accessible by all the services
+// sharing the piece store.
+type TieredStoreConfig struct {
+	HotStore ObjectStorageConfig // config of hot tier object storage, the tiering is disabled if the storage is empty
+}
+
+// PieceCacheConfig local read-through piece cache config
+type PieceCacheConfig struct {
+	CacheDir string // the local directory to cache the pieces, the cache is disabled if it is empty, it is shared by the services of one process and can not be used by other processes
+	MaxSize  int64  // the max total size of the cached pieces in bytes, the least recently used ones are evicted
 }
 
 // ObjectStorageConfig object storage config
@@ -0,0 +1,105 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// TieredStorage is the ObjectStorage which places the objects in the hot tier and the cold tier
+type TieredStorage interface {
+	ObjectStorage
+	// ListAllHotObjects returns all the objects in the hot tier as a channel
+	ListAllHotObjects(ctx context.Context, prefix, marker string) (<-chan Object, error)
+	// MigrateObject moves the object from the hot tier to the cold tier, returns the size of the moved object
+	MigrateObject(ctx context.Context, key string) (int64, error)
+}
+
+// tieredStore writes the new objects to the hot tier, and reads the objects from the hot tier firstly, then
+// falls back to the cold tier, so the objects can be migrated between the tiers transparently.
+type tieredStore struct {
+	hot  ObjectStorage
+	cold ObjectStorage
+	DefaultObjectStorage
+}
+
+// NewTieredStore returns a TieredStorage which uses the hot store as the hot tier and the cold store as the
+// cold tier.
+func NewTieredStore(hot, cold ObjectStorage) TieredStorage {
+	return &tieredStore{hot: hot, cold: cold}
+}
+
+func (t *tieredStore) String() string {
+	return fmt.Sprintf("tiered(%s)://%s", t.hot, t.cold)
+}
+
+func (t *tieredStore) CreateBucket(ctx context.Context) error {
+	if err := t.hot.CreateBucket(ctx); err != nil {
+		return err
+	}
+	return t.cold.CreateBucket(ctx)
+}
+
+func (t *tieredStore) GetObject(ctx context.Context, key string, offset, limit int64) (io.ReadCloser, error) {
+	rc, err := t.hot.GetObject(ctx, key, offset, limit)
+	if err == nil {
+		return rc, nil
+	}
+	return t.cold.GetObject(ctx, key, offset, limit)
+}
+
+func (t *tieredStore) PutObject(ctx context.Context, key string, reader io.Reader) error {
+	return t.hot.PutObject(ctx, key, reader)
+}
+
+// DeleteObject deletes the object from both tiers, since the object may be in the middle of migration.
+func (t *tieredStore) DeleteObject(ctx context.Context, key string) error {
+	if err := t.hot.DeleteObject(ctx, key); err != nil {
+		return err
+	}
+	return t.cold.DeleteObject(ctx, key)
+}
+
+func (t *tieredStore) HeadBucket(ctx context.Context) error {
+	if err := t.hot.HeadBucket(ctx); err != nil {
+		return err
+	}
+	return t.cold.HeadBucket(ctx)
+}
+
+func (t *tieredStore) HeadObject(ctx context.Context, key string) (Object, error) {
+	obj, err := t.hot.HeadObject(ctx, key)
+	if err == nil {
+		return obj, nil
+	}
+	return t.cold.HeadObject(ctx, key)
+}
+
+func (t *tieredStore) ListObjects(ctx context.Context, prefix, marker, delimiter string, limit int64) ([]Object, error) {
+	return listObjects(ctx, t.ListAllObjects, prefix, marker, delimiter, limit)
+}
+
+// ListAllObjects merges the sorted objects of the hot tier and the cold tier, the object being migrated is only
+// returned once from the hot tier.
+func (t *tieredStore) ListAllObjects(ctx context.Context, prefix, marker string) (<-chan Object, error) {
+	hotCh, err := t.hot.ListAllObjects(ctx, prefix, marker)
+	if err != nil {
+		return nil, err
+	}
+	coldCh, err := t.cold.ListAllObjects(ctx, prefix, marker)
+	if err != nil {
+		return nil, err
+	}
+	return mergeObjectChannels(ctx, hotCh, coldCh), nil
+}
+
+func (t *tieredStore) ListAllHotObjects(ctx context.Context, prefix, marker string) (<-chan Object, error) {
+	return t.hot.ListAllObjects(ctx, prefix, marker)
+}
+
+// MigrateObject copies the object to the cold tier before deleting it from the hot tier, so the object is
+// always readable during the migration. DeleteObject deletes the hot tier before the cold tier, so the object
+// deleted while migrating is detected by moveObject and not left in the cold tier.
+func (t *tieredStore) MigrateObject(ctx context.Context, key string) (int64, error) {
+	return moveObject(ctx, t.hot, t.cold, key)
+}
@@ -0,0 +1,87 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func setupTieredTest(t *testing.T) (TieredStorage, *diskFileStore, *memoryStore) {
+	hot := &diskFileStore{root: t.TempDir() + "/"}
+	cold := &memoryStore{name: mockBucket, objects: map[string]*memoryObject{}}
+	return NewTieredStore(hot, cold), hot, cold
+}
+
+func TestTiered_PutAndMigrate(t *testing.T) {
+	store, hot, cold := setupTieredTest(t)
+	assert.Nil(t, store.PutObject(context.TODO(), mockKey, bytes.NewReader([]byte("hello world"))))
+
+	// the new object is placed in the hot tier
+	_, err := hot.HeadObject(context.TODO(), mockKey)
+	assert.Nil(t, err)
+	_, err = cold.HeadObject(context.TODO(), mockKey)
+	assert.NotNil(t, err)
+
+	size, err := store.MigrateObject(context.TODO(), mockKey)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(11), size)
+	_, err = hot.HeadObject(context.TODO(), mockKey)
+	assert.NotNil(t, err)
+
+	// the migrated object is read from the cold tier
+	assert.Equal(t, "world", readCachedObject(t, store, mockKey, 6, 0))
+	obj, err := store.HeadObject(context.TODO(), mockKey)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(11), obj.Size())
+}
+
+func TestTiered_ListAndDelete(t *testing.T) {
+	store, _, cold := setupTieredTest(t)
+	assert.Nil(t, store.PutObject(context.TODO(), "1_s0", bytes.NewReader([]byte("hot"))))
+	assert.Nil(t, cold.PutObject(context.TODO(), "2_s0", bytes.NewReader([]byte("cold"))))
+
+	ch, err := store.ListAllHotObjects(context.TODO(), "", "")
+	assert.Nil(t, err)
+	var keys []string
+	for obj := range ch {
+		keys = append(keys, obj.Key())
+	}
+	assert.Equal(t, []string{"1_s0"}, keys)
+
+	// the object is deleted from both tiers
+	assert.Nil(t, cold.PutObject(context.TODO(), "1_s0", bytes.NewReader([]byte("hot"))))
+	assert.Nil(t, store.DeleteObject(context.TODO(), "1_s0"))
+	_, err = store.HeadObject(context.TODO(), "1_s0")
+	assert.NotNil(t, err)
+	assert.Equal(t, "cold", readCachedObject(t, store, "2_s0", 0, 0))
+}
+
+// putHookStore calls beforePut before putting the object
+type putHookStore struct {
+	ObjectStorage
+	beforePut func()
+}
+
+func (s *putHookStore) PutObject(ctx context.Context, key string, reader io.Reader) error {
+	s.beforePut()
+	return s.ObjectStorage.PutObject(ctx, key, reader)
+}
+
+func TestTiered_MigrateDeletedObject(t *testing.T) {
+	hot := &diskFileStore{root: t.TempDir() + "/"}
+	cold := &putHookStore{ObjectStorage: &memoryStore{name: mockBucket, objects: map[string]*memoryObject{}}}
+	store := NewTieredStore(hot, cold)
+	assert.Nil(t, store.PutObject(context.TODO(), mockKey, bytes.NewReader([]byte("hello world"))))
+
+	// the object is deleted by gc after it is read from the hot tier
+	cold.beforePut = func() { assert.Nil(t, store.DeleteObject(context.TODO(), mockKey)) }
+	_, err := store.MigrateObject(context.TODO(), mockKey)
+	assert.NotNil(t, err)
+	_, err = cold.HeadObject(context.TODO(), mockKey)
+	assert.NotNil(t, err)
+	_, err = store.HeadObject(context.TODO(), mockKey)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,63 @@
+package sqldb
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// GetBucketCORS get the cors configuration of bucket from BucketCORSTable
+func (s *SpDBImpl) GetBucketCORS(bucketName string) (*BucketCORSTable, error) {
+	queryReturn := &BucketCORSTable{}
+	result := s.db.First(queryReturn, "bucket_name = ?", bucketName)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, result.Error
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to query bucket cors table: %s", result.Error)
+	}
+	return queryReturn, nil
+}
+
+// UpdateBucketCORS insert or update(overwrite) the cors configuration of bucket
+func (s *SpDBImpl) UpdateBucketCORS(record *BucketCORSTable) error {
+	queryReturn := &BucketCORSTable{}
+	result := s.db.First(queryReturn, "bucket_name = ?", record.BucketName)
+	recordNotFound := errors.Is(result.Error, gorm.ErrRecordNotFound)
+	if result.Error != nil && !recordNotFound {
+		return fmt.Errorf("failed to query bucket cors table: %s", result.Error)
+	}
+
+	if recordNotFound {
+		insertRecord := &BucketCORSTable{
+			BucketName:   record.BucketName,
+			Rules:        record.Rules,
+			CreatedTime:  time.Now(),
+			ModifiedTime: time.Now(),
+		}
+		result = s.db.Create(insertRecord)
+		if result.Error != nil || result.RowsAffected != 1 {
+			return fmt.Errorf("failed to insert bucket cors table: %s", result.Error)
+		}
+		return nil
+	}
+	result = s.db.Model(&BucketCORSTable{BucketName: record.BucketName}).Updates(&BucketCORSTable{
+		Rules:        record.Rules,
+		ModifiedTime: time.Now(),
+	})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update bucket cors table: %s", result.Error)
+	}
+	return nil
+}
+
+// DeleteBucketCORS delete the cors configuration of bucket from BucketCORSTable
+func (s *SpDBImpl) DeleteBucketCORS(bucketName string) error {
+	result := s.db.Where("bucket_name = ?", bucketName).Delete(&BucketCORSTable{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete bucket cors table: %s", result.Error)
+	}
+	return nil
+}
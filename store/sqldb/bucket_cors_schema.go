@@ -0,0 +1,20 @@
+package sqldb
+
+import (
+	"time"
+)
+
+// BucketCORSTable table schema
+type BucketCORSTable struct {
+	BucketName string `gorm:"primary_key"`
+	// Rules is the json encoded cors rules of bucket
+	Rules string `gorm:"type:text"`
+
+	CreatedTime  time.Time
+	ModifiedTime time.Time
+}
+
+// TableName is used to set BucketCORSTable Schema's table name in database
+func (BucketCORSTable) TableName() string {
+	return BucketCORSTableName
+}
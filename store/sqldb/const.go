@@ -8,6 +8,8 @@ const (
 	ObjectTableName = "object"
 	// IntegrityMetaTableName defines the integrity meta table name
 	IntegrityMetaTableName = "integrity_meta"
+	// PieceChecksumTableName defines the piece checksum table name, which is used for recording the uploaded segments
+	PieceChecksumTableName = "piece_checksum"
 	// SpInfoTableName defines the SP info table name
 	SpInfoTableName = "sp_info"
 	// StorageParamsTableName defines the storage params info table name
@@ -20,4 +22,18 @@ const (
 	ServiceConfigTableName = "service_config"
 	// OffChainAuthKeyTableName defines the off chain auth key table name
 	OffChainAuthKeyTableName = "off_chain_auth_key"
+	// S3CredentialTableName defines the s3 credential table name, which maps the s3 access keys to the off chain auth keys
+	S3CredentialTableName = "s3_credential"
+	// BucketCORSTableName defines the bucket cors table name, which stores the cors rules of buckets
+	BucketCORSTableName = "bucket_cors"
+	// GCBlockProgressTableName defines the gc block progress table name, which is used for recording gc checkpoint
+	GCBlockProgressTableName = "gc_block_progress"
+	// GCObjectProgressTableName defines the gc object progress table name, which is used for recording gc outcome of object
+	GCObjectProgressTableName = "gc_object_progress"
+	// GCTaskTableName defines the gc task table name, which is used for recording the block range of gc job
+	GCTaskTableName = "gc_task"
+	// ScrubResultTableName defines the scrub result table name, which is used for recording scrub outcome of object
+	ScrubResultTableName = "scrub_result"
+	// SPReputationTableName defines the sp reputation table name, which is used for recording replication statistics of secondary sp
+	SPReputationTableName = "sp_reputation"
 )
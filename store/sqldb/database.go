@@ -22,10 +22,11 @@ type Job interface {
 	GetJobByID(jobID uint64) (*servicetypes.JobContext, error)
 	// GetJobByObjectID get job context by object id
 	GetJobByObjectID(objectID uint64) (*servicetypes.JobContext, error)
-
-	// TODO:: supports Iterator and Batch interface for gc the job and retry the job
-	// Iterator
-	// Batch
+	// ListUploadJobsByState list the upload jobs in the states which are modified before the time and
+	// whose retry count is less than maxRetry, order by modified time
+	ListUploadJobsByState(states []servicetypes.JobState, modifiedBefore time.Time, maxRetry uint32, limit int) ([]*UploadJobMeta, error)
+	// UpdateJobRetryCount update the retry count of a job by job id
+	UpdateJobRetryCount(jobID uint64, retryCount uint32) error
 }
 
 // Object interface which contains get and set object info interface
@@ -34,6 +35,10 @@ type Object interface {
 	GetObjectInfo(objectID uint64) (*storagetypes.ObjectInfo, error)
 	// SetObjectInfo set(maybe overwrite) object info by object id
 	SetObjectInfo(objectID uint64, objectInfo *storagetypes.ObjectInfo) error
+	// GetSecondarySpSignatures get the secondary sp signatures which are used to seal object by object id
+	GetSecondarySpSignatures(objectID uint64) ([][]byte, error)
+	// SetSecondarySpSignatures set(maybe overwrite) the secondary sp signatures by object id
+	SetSecondarySpSignatures(objectID uint64, signatures [][]byte) error
 }
 
 // ObjectIntegrity abstract object integrity interface
@@ -42,6 +47,12 @@ type ObjectIntegrity interface {
 	GetObjectIntegrity(objectID uint64) (*IntegrityMeta, error)
 	// SetObjectIntegrity set(maybe overwrite) integrity hash info to db
 	SetObjectIntegrity(integrity *IntegrityMeta) error
+	// SetPieceChecksum set(maybe overwrite) the checksum of an uploaded segment piece
+	SetPieceChecksum(objectID uint64, segmentIndex uint32, checksum []byte) error
+	// GetAllPieceChecksums return the checksums of the uploaded segment pieces, the key is segment index
+	GetAllPieceChecksums(objectID uint64) (map[uint32][]byte, error)
+	// DeleteAllPieceChecksums delete the checksums of the uploaded segment pieces by object id
+	DeleteAllPieceChecksums(objectID uint64) error
 }
 
 // SPInfo interface
@@ -94,6 +105,10 @@ type Traffic interface {
 
 	// GetUserReadRecord return user record list by time range
 	GetUserReadRecord(userAddress string, timeRange *TrafficTimeRange) ([]*ReadRecord, error)
+
+	// GetObjectsReadCount return the read count of every object since startTimestampUs,
+	// the objects without read record are absent in the returned map
+	GetObjectsReadCount(objectIDs []uint64, startTimestampUs int64) (map[uint64]int64, error)
 }
 
 // ServiceConfig defines a series of reading and setting service config interfaces
@@ -108,6 +123,82 @@ type OffChainAuthKey interface {
 	InsertAuthKey(newRecord *OffChainAuthKeyTable) error
 }
 
+// S3Credential defines a series of s3 credential interfaces, the s3 credential is bound to the off chain
+// auth key of (user address, domain) with the nonce at issuing time
+type S3Credential interface {
+	// GetS3Credential return the s3 credential by access key id,
+	// notice maybe return (nil, gorm.ErrRecordNotFound) while the access key id does not exist
+	GetS3Credential(accessKeyID string) (*S3CredentialTable, error)
+	// InsertS3Credential insert a new s3 credential
+	InsertS3Credential(newRecord *S3CredentialTable) error
+}
+
+// BucketCORS defines a series of bucket cors interfaces
+type BucketCORS interface {
+	// GetBucketCORS return the cors configuration of bucket,
+	// gorm.ErrRecordNotFound is returned if the bucket has no cors configuration
+	GetBucketCORS(bucketName string) (*BucketCORSTable, error)
+	// UpdateBucketCORS insert or update the cors configuration of bucket
+	UpdateBucketCORS(record *BucketCORSTable) error
+	// DeleteBucketCORS delete the cors configuration of bucket
+	DeleteBucketCORS(bucketName string) error
+}
+
+// GC defines a series of garbage collection progress interfaces
+type GC interface {
+	// GetGCBlockProgress return the last block range which has been fully processed by gc,
+	// notice maybe return (nil, gorm.ErrRecordNotFound) while gc has never finished a loop
+	GetGCBlockProgress() (*GCBlockProgress, error)
+	// SetGCBlockProgress set(maybe overwrite) the last block range which has been fully processed by gc
+	SetGCBlockProgress(progress *GCBlockProgress) error
+	// GetGCObjectProgress return the gc outcome of an object,
+	// notice maybe return (nil, gorm.ErrRecordNotFound) while the object has not been processed
+	GetGCObjectProgress(objectID uint64) (*GCObjectProgress, error)
+	// SetGCObjectProgress set(maybe overwrite) the gc outcome of an object
+	SetGCObjectProgress(progress *GCObjectProgress) error
+	// ClaimFailedGCObjects claims the objects which are failed to gc and whose retry count is less than
+	// maxRetry by marking them retrying, every object is only claimed by one caller, the retrying objects
+	// which are not finished within lease can be claimed again, is unlimited if limit <= 0
+	ClaimFailedGCObjects(maxRetry uint32, limit int, lease time.Duration) ([]*GCObjectProgress, error)
+	// CreateGCTask create a gc job with JOB_TYPE_DELETE_OBJECT type and its block range
+	CreateGCTask(startBlock, endBlock uint64) (*GCTask, error)
+	// UpdateGCTask update the state and retry count of a gc job by job id
+	UpdateGCTask(jobID uint64, state servicetypes.JobState, retryCount uint32) error
+	// UpdateGCTaskStartBlock update the start block number of a gc job by job id, the blocks before it have been processed
+	UpdateGCTaskStartBlock(jobID uint64, startBlock uint64) error
+	// ListUnfinishedGCTasks return the gc jobs which are not done, order by start block number
+	ListUnfinishedGCTasks() ([]*GCTask, error)
+}
+
+// Scrub defines a series of piece scrubbing interfaces
+type Scrub interface {
+	// ListObjectIntegrities return the integrity metas whose object id is greater than afterObjectID,
+	// order by object id, is unlimited if limit <= 0
+	ListObjectIntegrities(afterObjectID uint64, limit int) ([]*IntegrityMeta, error)
+	// GetScrubResult return the scrub outcome of an object,
+	// notice maybe return (nil, gorm.ErrRecordNotFound) while the object has not been scrubbed
+	GetScrubResult(objectID uint64) (*ScrubResult, error)
+	// SetScrubResult set(maybe overwrite) the scrub outcome of an object
+	SetScrubResult(result *ScrubResult) error
+	// ListScrubResults return the scrub outcomes by status, order by modified time desc,
+	// is unlimited if limit <= 0
+	ListScrubResults(status ScrubStatus, limit int) ([]*ScrubResult, error)
+}
+
+// Reputation defines a series of secondary sp reputation interfaces
+type Reputation interface {
+	// GetSPReputation return the reputation of a secondary sp,
+	// notice maybe return (nil, gorm.ErrRecordNotFound) while the sp has never been replicated to
+	GetSPReputation(operatorAddress string) (*SPReputation, error)
+	// SetSPReputation set(maybe overwrite) the reputation of a secondary sp
+	SetSPReputation(reputation *SPReputation) error
+	// ListSPReputations return the reputations of the secondary sps, the sps without reputation are skipped
+	ListSPReputations(operatorAddresses []string) ([]*SPReputation, error)
+	// RecordSPReplication atomically update the reputation of a secondary sp by the replication outcome,
+	// the reputation is created if the sp has never been replicated to
+	RecordSPReplication(replication *SPReplication) error
+}
+
 // SPDB contains all the methods required by sql database
 type SPDB interface {
 	Job
@@ -117,6 +208,11 @@ type SPDB interface {
 	SPInfo
 	StorageParam
 	OffChainAuthKey
+	S3Credential
+	BucketCORS
+	GC
+	Scrub
+	Reputation
 }
 
 func errIsNotFound(err error) bool {
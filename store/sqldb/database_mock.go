@@ -82,6 +82,35 @@ func (mr *MockJobMockRecorder) GetJobByObjectID(objectID interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJobByObjectID", reflect.TypeOf((*MockJob)(nil).GetJobByObjectID), objectID)
 }
 
+// ListUploadJobsByState mocks base method.
+func (m *MockJob) ListUploadJobsByState(states []types.JobState, modifiedBefore time.Time, maxRetry uint32, limit int) ([]*UploadJobMeta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUploadJobsByState", states, modifiedBefore, maxRetry, limit)
+	ret0, _ := ret[0].([]*UploadJobMeta)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUploadJobsByState indicates an expected call of ListUploadJobsByState.
+func (mr *MockJobMockRecorder) ListUploadJobsByState(states, modifiedBefore, maxRetry, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUploadJobsByState", reflect.TypeOf((*MockJob)(nil).ListUploadJobsByState), states, modifiedBefore, maxRetry, limit)
+}
+
+// UpdateJobRetryCount mocks base method.
+func (m *MockJob) UpdateJobRetryCount(jobID uint64, retryCount uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateJobRetryCount", jobID, retryCount)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateJobRetryCount indicates an expected call of UpdateJobRetryCount.
+func (mr *MockJobMockRecorder) UpdateJobRetryCount(jobID, retryCount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateJobRetryCount", reflect.TypeOf((*MockJob)(nil).UpdateJobRetryCount), jobID, retryCount)
+}
+
 // UpdateJobState mocks base method.
 func (m *MockJob) UpdateJobState(objectID uint64, state types.JobState) error {
 	m.ctrl.T.Helper()
@@ -134,6 +163,21 @@ func (mr *MockObjectMockRecorder) GetObjectInfo(objectID interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObjectInfo", reflect.TypeOf((*MockObject)(nil).GetObjectInfo), objectID)
 }
 
+// GetSecondarySpSignatures mocks base method.
+func (m *MockObject) GetSecondarySpSignatures(objectID uint64) ([][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecondarySpSignatures", objectID)
+	ret0, _ := ret[0].([][]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecondarySpSignatures indicates an expected call of GetSecondarySpSignatures.
+func (mr *MockObjectMockRecorder) GetSecondarySpSignatures(objectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecondarySpSignatures", reflect.TypeOf((*MockObject)(nil).GetSecondarySpSignatures), objectID)
+}
+
 // SetObjectInfo mocks base method.
 func (m *MockObject) SetObjectInfo(objectID uint64, objectInfo *types1.ObjectInfo) error {
 	m.ctrl.T.Helper()
@@ -148,6 +192,20 @@ func (mr *MockObjectMockRecorder) SetObjectInfo(objectID, objectInfo interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetObjectInfo", reflect.TypeOf((*MockObject)(nil).SetObjectInfo), objectID, objectInfo)
 }
 
+// SetSecondarySpSignatures mocks base method.
+func (m *MockObject) SetSecondarySpSignatures(objectID uint64, signatures [][]byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSecondarySpSignatures", objectID, signatures)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSecondarySpSignatures indicates an expected call of SetSecondarySpSignatures.
+func (mr *MockObjectMockRecorder) SetSecondarySpSignatures(objectID, signatures interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSecondarySpSignatures", reflect.TypeOf((*MockObject)(nil).SetSecondarySpSignatures), objectID, signatures)
+}
+
 // MockObjectIntegrity is a mock of ObjectIntegrity interface.
 type MockObjectIntegrity struct {
 	ctrl     *gomock.Controller
@@ -171,6 +229,35 @@ func (m *MockObjectIntegrity) EXPECT() *MockObjectIntegrityMockRecorder {
 	return m.recorder
 }
 
+// DeleteAllPieceChecksums mocks base method.
+func (m *MockObjectIntegrity) DeleteAllPieceChecksums(objectID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAllPieceChecksums", objectID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAllPieceChecksums indicates an expected call of DeleteAllPieceChecksums.
+func (mr *MockObjectIntegrityMockRecorder) DeleteAllPieceChecksums(objectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAllPieceChecksums", reflect.TypeOf((*MockObjectIntegrity)(nil).DeleteAllPieceChecksums), objectID)
+}
+
+// GetAllPieceChecksums mocks base method.
+func (m *MockObjectIntegrity) GetAllPieceChecksums(objectID uint64) (map[uint32][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllPieceChecksums", objectID)
+	ret0, _ := ret[0].(map[uint32][]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllPieceChecksums indicates an expected call of GetAllPieceChecksums.
+func (mr *MockObjectIntegrityMockRecorder) GetAllPieceChecksums(objectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllPieceChecksums", reflect.TypeOf((*MockObjectIntegrity)(nil).GetAllPieceChecksums), objectID)
+}
+
 // GetObjectIntegrity mocks base method.
 func (m *MockObjectIntegrity) GetObjectIntegrity(objectID uint64) (*IntegrityMeta, error) {
 	m.ctrl.T.Helper()
@@ -200,6 +287,20 @@ func (mr *MockObjectIntegrityMockRecorder) SetObjectIntegrity(integrity interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetObjectIntegrity", reflect.TypeOf((*MockObjectIntegrity)(nil).SetObjectIntegrity), integrity)
 }
 
+// SetPieceChecksum mocks base method.
+func (m *MockObjectIntegrity) SetPieceChecksum(objectID uint64, segmentIndex uint32, checksum []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPieceChecksum", objectID, segmentIndex, checksum)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPieceChecksum indicates an expected call of SetPieceChecksum.
+func (mr *MockObjectIntegrityMockRecorder) SetPieceChecksum(objectID, segmentIndex, checksum interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPieceChecksum", reflect.TypeOf((*MockObjectIntegrity)(nil).SetPieceChecksum), objectID, segmentIndex, checksum)
+}
+
 // MockSPInfo is a mock of SPInfo interface.
 type MockSPInfo struct {
 	ctrl     *gomock.Controller
@@ -468,6 +569,21 @@ func (mr *MockTrafficMockRecorder) GetObjectReadRecord(objectID, timeRange inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObjectReadRecord", reflect.TypeOf((*MockTraffic)(nil).GetObjectReadRecord), objectID, timeRange)
 }
 
+// GetObjectsReadCount mocks base method.
+func (m *MockTraffic) GetObjectsReadCount(objectIDs []uint64, startTimestampUs int64) (map[uint64]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetObjectsReadCount", objectIDs, startTimestampUs)
+	ret0, _ := ret[0].(map[uint64]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetObjectsReadCount indicates an expected call of GetObjectsReadCount.
+func (mr *MockTrafficMockRecorder) GetObjectsReadCount(objectIDs, startTimestampUs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObjectsReadCount", reflect.TypeOf((*MockTraffic)(nil).GetObjectsReadCount), objectIDs, startTimestampUs)
+}
+
 // GetReadRecord mocks base method.
 func (m *MockTraffic) GetReadRecord(timeRange *TrafficTimeRange) ([]*ReadRecord, error) {
 	m.ctrl.T.Helper()
@@ -617,262 +733,890 @@ func (mr *MockOffChainAuthKeyMockRecorder) UpdateAuthKey(userAddress, domain, ol
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAuthKey", reflect.TypeOf((*MockOffChainAuthKey)(nil).UpdateAuthKey), userAddress, domain, oldNonce, newNonce, newPublicKey, newExpiryDate)
 }
 
-// MockSPDB is a mock of SPDB interface.
-type MockSPDB struct {
+// MockS3Credential is a mock of S3Credential interface.
+type MockS3Credential struct {
 	ctrl     *gomock.Controller
-	recorder *MockSPDBMockRecorder
+	recorder *MockS3CredentialMockRecorder
 }
 
-// MockSPDBMockRecorder is the mock recorder for MockSPDB.
-type MockSPDBMockRecorder struct {
-	mock *MockSPDB
+// MockS3CredentialMockRecorder is the mock recorder for MockS3Credential.
+type MockS3CredentialMockRecorder struct {
+	mock *MockS3Credential
 }
 
-// NewMockSPDB creates a new mock instance.
-func NewMockSPDB(ctrl *gomock.Controller) *MockSPDB {
-	mock := &MockSPDB{ctrl: ctrl}
-	mock.recorder = &MockSPDBMockRecorder{mock}
+// NewMockS3Credential creates a new mock instance.
+func NewMockS3Credential(ctrl *gomock.Controller) *MockS3Credential {
+	mock := &MockS3Credential{ctrl: ctrl}
+	mock.recorder = &MockS3CredentialMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockSPDB) EXPECT() *MockSPDBMockRecorder {
+func (m *MockS3Credential) EXPECT() *MockS3CredentialMockRecorder {
 	return m.recorder
 }
 
-// CheckQuotaAndAddReadRecord mocks base method.
-func (m *MockSPDB) CheckQuotaAndAddReadRecord(record *ReadRecord, quota *BucketQuota) error {
+// GetS3Credential mocks base method.
+func (m *MockS3Credential) GetS3Credential(accessKeyID string) (*S3CredentialTable, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CheckQuotaAndAddReadRecord", record, quota)
+	ret := m.ctrl.Call(m, "GetS3Credential", accessKeyID)
+	ret0, _ := ret[0].(*S3CredentialTable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetS3Credential indicates an expected call of GetS3Credential.
+func (mr *MockS3CredentialMockRecorder) GetS3Credential(accessKeyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetS3Credential", reflect.TypeOf((*MockS3Credential)(nil).GetS3Credential), accessKeyID)
+}
+
+// InsertS3Credential mocks base method.
+func (m *MockS3Credential) InsertS3Credential(newRecord *S3CredentialTable) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertS3Credential", newRecord)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// CheckQuotaAndAddReadRecord indicates an expected call of CheckQuotaAndAddReadRecord.
-func (mr *MockSPDBMockRecorder) CheckQuotaAndAddReadRecord(record, quota interface{}) *gomock.Call {
+// InsertS3Credential indicates an expected call of InsertS3Credential.
+func (mr *MockS3CredentialMockRecorder) InsertS3Credential(newRecord interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckQuotaAndAddReadRecord", reflect.TypeOf((*MockSPDB)(nil).CheckQuotaAndAddReadRecord), record, quota)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertS3Credential", reflect.TypeOf((*MockS3Credential)(nil).InsertS3Credential), newRecord)
 }
 
-// CreateUploadJob mocks base method.
-func (m *MockSPDB) CreateUploadJob(objectInfo *types1.ObjectInfo) (*types.JobContext, error) {
+// MockBucketCORS is a mock of BucketCORS interface.
+type MockBucketCORS struct {
+	ctrl     *gomock.Controller
+	recorder *MockBucketCORSMockRecorder
+}
+
+// MockBucketCORSMockRecorder is the mock recorder for MockBucketCORS.
+type MockBucketCORSMockRecorder struct {
+	mock *MockBucketCORS
+}
+
+// NewMockBucketCORS creates a new mock instance.
+func NewMockBucketCORS(ctrl *gomock.Controller) *MockBucketCORS {
+	mock := &MockBucketCORS{ctrl: ctrl}
+	mock.recorder = &MockBucketCORSMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBucketCORS) EXPECT() *MockBucketCORSMockRecorder {
+	return m.recorder
+}
+
+// DeleteBucketCORS mocks base method.
+func (m *MockBucketCORS) DeleteBucketCORS(bucketName string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUploadJob", objectInfo)
-	ret0, _ := ret[0].(*types.JobContext)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "DeleteBucketCORS", bucketName)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// CreateUploadJob indicates an expected call of CreateUploadJob.
-func (mr *MockSPDBMockRecorder) CreateUploadJob(objectInfo interface{}) *gomock.Call {
+// DeleteBucketCORS indicates an expected call of DeleteBucketCORS.
+func (mr *MockBucketCORSMockRecorder) DeleteBucketCORS(bucketName interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUploadJob", reflect.TypeOf((*MockSPDB)(nil).CreateUploadJob), objectInfo)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBucketCORS", reflect.TypeOf((*MockBucketCORS)(nil).DeleteBucketCORS), bucketName)
 }
 
-// FetchAllSp mocks base method.
-func (m *MockSPDB) FetchAllSp(status ...types0.Status) ([]*types0.StorageProvider, error) {
+// GetBucketCORS mocks base method.
+func (m *MockBucketCORS) GetBucketCORS(bucketName string) (*BucketCORSTable, error) {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{}
-	for _, a := range status {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "FetchAllSp", varargs...)
-	ret0, _ := ret[0].([]*types0.StorageProvider)
+	ret := m.ctrl.Call(m, "GetBucketCORS", bucketName)
+	ret0, _ := ret[0].(*BucketCORSTable)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FetchAllSp indicates an expected call of FetchAllSp.
-func (mr *MockSPDBMockRecorder) FetchAllSp(status ...interface{}) *gomock.Call {
+// GetBucketCORS indicates an expected call of GetBucketCORS.
+func (mr *MockBucketCORSMockRecorder) GetBucketCORS(bucketName interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchAllSp", reflect.TypeOf((*MockSPDB)(nil).FetchAllSp), status...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBucketCORS", reflect.TypeOf((*MockBucketCORS)(nil).GetBucketCORS), bucketName)
 }
 
-// FetchAllSpWithoutOwnSp mocks base method.
-func (m *MockSPDB) FetchAllSpWithoutOwnSp(status ...types0.Status) ([]*types0.StorageProvider, error) {
+// UpdateBucketCORS mocks base method.
+func (m *MockBucketCORS) UpdateBucketCORS(record *BucketCORSTable) error {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{}
-	for _, a := range status {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "FetchAllSpWithoutOwnSp", varargs...)
-	ret0, _ := ret[0].([]*types0.StorageProvider)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "UpdateBucketCORS", record)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// FetchAllSpWithoutOwnSp indicates an expected call of FetchAllSpWithoutOwnSp.
-func (mr *MockSPDBMockRecorder) FetchAllSpWithoutOwnSp(status ...interface{}) *gomock.Call {
+// UpdateBucketCORS indicates an expected call of UpdateBucketCORS.
+func (mr *MockBucketCORSMockRecorder) UpdateBucketCORS(record interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchAllSpWithoutOwnSp", reflect.TypeOf((*MockSPDB)(nil).FetchAllSpWithoutOwnSp), status...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBucketCORS", reflect.TypeOf((*MockBucketCORS)(nil).UpdateBucketCORS), record)
 }
 
-// GetAuthKey mocks base method.
-func (m *MockSPDB) GetAuthKey(userAddress, domain string) (*OffChainAuthKeyTable, error) {
+// MockGC is a mock of GC interface.
+type MockGC struct {
+	ctrl     *gomock.Controller
+	recorder *MockGCMockRecorder
+}
+
+// MockGCMockRecorder is the mock recorder for MockGC.
+type MockGCMockRecorder struct {
+	mock *MockGC
+}
+
+// NewMockGC creates a new mock instance.
+func NewMockGC(ctrl *gomock.Controller) *MockGC {
+	mock := &MockGC{ctrl: ctrl}
+	mock.recorder = &MockGCMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGC) EXPECT() *MockGCMockRecorder {
+	return m.recorder
+}
+
+// ClaimFailedGCObjects mocks base method.
+func (m *MockGC) ClaimFailedGCObjects(maxRetry uint32, limit int, lease time.Duration) ([]*GCObjectProgress, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAuthKey", userAddress, domain)
-	ret0, _ := ret[0].(*OffChainAuthKeyTable)
+	ret := m.ctrl.Call(m, "ClaimFailedGCObjects", maxRetry, limit, lease)
+	ret0, _ := ret[0].([]*GCObjectProgress)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAuthKey indicates an expected call of GetAuthKey.
-func (mr *MockSPDBMockRecorder) GetAuthKey(userAddress, domain interface{}) *gomock.Call {
+// ClaimFailedGCObjects indicates an expected call of ClaimFailedGCObjects.
+func (mr *MockGCMockRecorder) ClaimFailedGCObjects(maxRetry, limit, lease interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthKey", reflect.TypeOf((*MockSPDB)(nil).GetAuthKey), userAddress, domain)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimFailedGCObjects", reflect.TypeOf((*MockGC)(nil).ClaimFailedGCObjects), maxRetry, limit, lease)
 }
 
-// GetBucketReadRecord mocks base method.
-func (m *MockSPDB) GetBucketReadRecord(bucketID uint64, timeRange *TrafficTimeRange) ([]*ReadRecord, error) {
+// CreateGCTask mocks base method.
+func (m *MockGC) CreateGCTask(startBlock, endBlock uint64) (*GCTask, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBucketReadRecord", bucketID, timeRange)
-	ret0, _ := ret[0].([]*ReadRecord)
+	ret := m.ctrl.Call(m, "CreateGCTask", startBlock, endBlock)
+	ret0, _ := ret[0].(*GCTask)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetBucketReadRecord indicates an expected call of GetBucketReadRecord.
-func (mr *MockSPDBMockRecorder) GetBucketReadRecord(bucketID, timeRange interface{}) *gomock.Call {
+// CreateGCTask indicates an expected call of CreateGCTask.
+func (mr *MockGCMockRecorder) CreateGCTask(startBlock, endBlock interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBucketReadRecord", reflect.TypeOf((*MockSPDB)(nil).GetBucketReadRecord), bucketID, timeRange)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGCTask", reflect.TypeOf((*MockGC)(nil).CreateGCTask), startBlock, endBlock)
 }
 
-// GetBucketTraffic mocks base method.
-func (m *MockSPDB) GetBucketTraffic(bucketID uint64, yearMonth string) (*BucketTraffic, error) {
+// GetGCBlockProgress mocks base method.
+func (m *MockGC) GetGCBlockProgress() (*GCBlockProgress, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBucketTraffic", bucketID, yearMonth)
-	ret0, _ := ret[0].(*BucketTraffic)
+	ret := m.ctrl.Call(m, "GetGCBlockProgress")
+	ret0, _ := ret[0].(*GCBlockProgress)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetBucketTraffic indicates an expected call of GetBucketTraffic.
-func (mr *MockSPDBMockRecorder) GetBucketTraffic(bucketID, yearMonth interface{}) *gomock.Call {
+// GetGCBlockProgress indicates an expected call of GetGCBlockProgress.
+func (mr *MockGCMockRecorder) GetGCBlockProgress() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBucketTraffic", reflect.TypeOf((*MockSPDB)(nil).GetBucketTraffic), bucketID, yearMonth)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGCBlockProgress", reflect.TypeOf((*MockGC)(nil).GetGCBlockProgress))
 }
 
-// GetJobByID mocks base method.
-func (m *MockSPDB) GetJobByID(jobID uint64) (*types.JobContext, error) {
+// GetGCObjectProgress mocks base method.
+func (m *MockGC) GetGCObjectProgress(objectID uint64) (*GCObjectProgress, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetJobByID", jobID)
-	ret0, _ := ret[0].(*types.JobContext)
+	ret := m.ctrl.Call(m, "GetGCObjectProgress", objectID)
+	ret0, _ := ret[0].(*GCObjectProgress)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetJobByID indicates an expected call of GetJobByID.
-func (mr *MockSPDBMockRecorder) GetJobByID(jobID interface{}) *gomock.Call {
+// GetGCObjectProgress indicates an expected call of GetGCObjectProgress.
+func (mr *MockGCMockRecorder) GetGCObjectProgress(objectID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJobByID", reflect.TypeOf((*MockSPDB)(nil).GetJobByID), jobID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGCObjectProgress", reflect.TypeOf((*MockGC)(nil).GetGCObjectProgress), objectID)
 }
 
-// GetJobByObjectID mocks base method.
-func (m *MockSPDB) GetJobByObjectID(objectID uint64) (*types.JobContext, error) {
+// ListUnfinishedGCTasks mocks base method.
+func (m *MockGC) ListUnfinishedGCTasks() ([]*GCTask, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetJobByObjectID", objectID)
-	ret0, _ := ret[0].(*types.JobContext)
+	ret := m.ctrl.Call(m, "ListUnfinishedGCTasks")
+	ret0, _ := ret[0].([]*GCTask)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetJobByObjectID indicates an expected call of GetJobByObjectID.
-func (mr *MockSPDBMockRecorder) GetJobByObjectID(objectID interface{}) *gomock.Call {
+// ListUnfinishedGCTasks indicates an expected call of ListUnfinishedGCTasks.
+func (mr *MockGCMockRecorder) ListUnfinishedGCTasks() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJobByObjectID", reflect.TypeOf((*MockSPDB)(nil).GetJobByObjectID), objectID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUnfinishedGCTasks", reflect.TypeOf((*MockGC)(nil).ListUnfinishedGCTasks))
 }
 
-// GetObjectInfo mocks base method.
-func (m *MockSPDB) GetObjectInfo(objectID uint64) (*types1.ObjectInfo, error) {
+// SetGCBlockProgress mocks base method.
+func (m *MockGC) SetGCBlockProgress(progress *GCBlockProgress) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetObjectInfo", objectID)
-	ret0, _ := ret[0].(*types1.ObjectInfo)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "SetGCBlockProgress", progress)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetObjectInfo indicates an expected call of GetObjectInfo.
-func (mr *MockSPDBMockRecorder) GetObjectInfo(objectID interface{}) *gomock.Call {
+// SetGCBlockProgress indicates an expected call of SetGCBlockProgress.
+func (mr *MockGCMockRecorder) SetGCBlockProgress(progress interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObjectInfo", reflect.TypeOf((*MockSPDB)(nil).GetObjectInfo), objectID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetGCBlockProgress", reflect.TypeOf((*MockGC)(nil).SetGCBlockProgress), progress)
 }
 
-// GetObjectIntegrity mocks base method.
-func (m *MockSPDB) GetObjectIntegrity(objectID uint64) (*IntegrityMeta, error) {
+// SetGCObjectProgress mocks base method.
+func (m *MockGC) SetGCObjectProgress(progress *GCObjectProgress) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetObjectIntegrity", objectID)
-	ret0, _ := ret[0].(*IntegrityMeta)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "SetGCObjectProgress", progress)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetObjectIntegrity indicates an expected call of GetObjectIntegrity.
-func (mr *MockSPDBMockRecorder) GetObjectIntegrity(objectID interface{}) *gomock.Call {
+// SetGCObjectProgress indicates an expected call of SetGCObjectProgress.
+func (mr *MockGCMockRecorder) SetGCObjectProgress(progress interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObjectIntegrity", reflect.TypeOf((*MockSPDB)(nil).GetObjectIntegrity), objectID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetGCObjectProgress", reflect.TypeOf((*MockGC)(nil).SetGCObjectProgress), progress)
 }
 
-// GetObjectReadRecord mocks base method.
-func (m *MockSPDB) GetObjectReadRecord(objectID uint64, timeRange *TrafficTimeRange) ([]*ReadRecord, error) {
+// UpdateGCTask mocks base method.
+func (m *MockGC) UpdateGCTask(jobID uint64, state types.JobState, retryCount uint32) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetObjectReadRecord", objectID, timeRange)
-	ret0, _ := ret[0].([]*ReadRecord)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "UpdateGCTask", jobID, state, retryCount)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetObjectReadRecord indicates an expected call of GetObjectReadRecord.
-func (mr *MockSPDBMockRecorder) GetObjectReadRecord(objectID, timeRange interface{}) *gomock.Call {
+// UpdateGCTask indicates an expected call of UpdateGCTask.
+func (mr *MockGCMockRecorder) UpdateGCTask(jobID, state, retryCount interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObjectReadRecord", reflect.TypeOf((*MockSPDB)(nil).GetObjectReadRecord), objectID, timeRange)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGCTask", reflect.TypeOf((*MockGC)(nil).UpdateGCTask), jobID, state, retryCount)
 }
 
-// GetOwnSpInfo mocks base method.
-func (m *MockSPDB) GetOwnSpInfo() (*types0.StorageProvider, error) {
+// UpdateGCTaskStartBlock mocks base method.
+func (m *MockGC) UpdateGCTaskStartBlock(jobID, startBlock uint64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetOwnSpInfo")
-	ret0, _ := ret[0].(*types0.StorageProvider)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "UpdateGCTaskStartBlock", jobID, startBlock)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetOwnSpInfo indicates an expected call of GetOwnSpInfo.
-func (mr *MockSPDBMockRecorder) GetOwnSpInfo() *gomock.Call {
+// UpdateGCTaskStartBlock indicates an expected call of UpdateGCTaskStartBlock.
+func (mr *MockGCMockRecorder) UpdateGCTaskStartBlock(jobID, startBlock interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOwnSpInfo", reflect.TypeOf((*MockSPDB)(nil).GetOwnSpInfo))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGCTaskStartBlock", reflect.TypeOf((*MockGC)(nil).UpdateGCTaskStartBlock), jobID, startBlock)
 }
 
-// GetReadRecord mocks base method.
-func (m *MockSPDB) GetReadRecord(timeRange *TrafficTimeRange) ([]*ReadRecord, error) {
+// MockScrub is a mock of Scrub interface.
+type MockScrub struct {
+	ctrl     *gomock.Controller
+	recorder *MockScrubMockRecorder
+}
+
+// MockScrubMockRecorder is the mock recorder for MockScrub.
+type MockScrubMockRecorder struct {
+	mock *MockScrub
+}
+
+// NewMockScrub creates a new mock instance.
+func NewMockScrub(ctrl *gomock.Controller) *MockScrub {
+	mock := &MockScrub{ctrl: ctrl}
+	mock.recorder = &MockScrubMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockScrub) EXPECT() *MockScrubMockRecorder {
+	return m.recorder
+}
+
+// GetScrubResult mocks base method.
+func (m *MockScrub) GetScrubResult(objectID uint64) (*ScrubResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetReadRecord", timeRange)
-	ret0, _ := ret[0].([]*ReadRecord)
+	ret := m.ctrl.Call(m, "GetScrubResult", objectID)
+	ret0, _ := ret[0].(*ScrubResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetReadRecord indicates an expected call of GetReadRecord.
-func (mr *MockSPDBMockRecorder) GetReadRecord(timeRange interface{}) *gomock.Call {
+// GetScrubResult indicates an expected call of GetScrubResult.
+func (mr *MockScrubMockRecorder) GetScrubResult(objectID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReadRecord", reflect.TypeOf((*MockSPDB)(nil).GetReadRecord), timeRange)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScrubResult", reflect.TypeOf((*MockScrub)(nil).GetScrubResult), objectID)
 }
 
-// GetSpByAddress mocks base method.
-func (m *MockSPDB) GetSpByAddress(address string, addressType SpAddressType) (*types0.StorageProvider, error) {
+// ListObjectIntegrities mocks base method.
+func (m *MockScrub) ListObjectIntegrities(afterObjectID uint64, limit int) ([]*IntegrityMeta, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSpByAddress", address, addressType)
-	ret0, _ := ret[0].(*types0.StorageProvider)
+	ret := m.ctrl.Call(m, "ListObjectIntegrities", afterObjectID, limit)
+	ret0, _ := ret[0].([]*IntegrityMeta)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetSpByAddress indicates an expected call of GetSpByAddress.
-func (mr *MockSPDBMockRecorder) GetSpByAddress(address, addressType interface{}) *gomock.Call {
+// ListObjectIntegrities indicates an expected call of ListObjectIntegrities.
+func (mr *MockScrubMockRecorder) ListObjectIntegrities(afterObjectID, limit interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSpByAddress", reflect.TypeOf((*MockSPDB)(nil).GetSpByAddress), address, addressType)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListObjectIntegrities", reflect.TypeOf((*MockScrub)(nil).ListObjectIntegrities), afterObjectID, limit)
 }
 
-// GetSpByEndpoint mocks base method.
+// ListScrubResults mocks base method.
+func (m *MockScrub) ListScrubResults(status ScrubStatus, limit int) ([]*ScrubResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListScrubResults", status, limit)
+	ret0, _ := ret[0].([]*ScrubResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListScrubResults indicates an expected call of ListScrubResults.
+func (mr *MockScrubMockRecorder) ListScrubResults(status, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListScrubResults", reflect.TypeOf((*MockScrub)(nil).ListScrubResults), status, limit)
+}
+
+// SetScrubResult mocks base method.
+func (m *MockScrub) SetScrubResult(result *ScrubResult) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetScrubResult", result)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetScrubResult indicates an expected call of SetScrubResult.
+func (mr *MockScrubMockRecorder) SetScrubResult(result interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetScrubResult", reflect.TypeOf((*MockScrub)(nil).SetScrubResult), result)
+}
+
+// MockReputation is a mock of Reputation interface.
+type MockReputation struct {
+	ctrl     *gomock.Controller
+	recorder *MockReputationMockRecorder
+}
+
+// MockReputationMockRecorder is the mock recorder for MockReputation.
+type MockReputationMockRecorder struct {
+	mock *MockReputation
+}
+
+// NewMockReputation creates a new mock instance.
+func NewMockReputation(ctrl *gomock.Controller) *MockReputation {
+	mock := &MockReputation{ctrl: ctrl}
+	mock.recorder = &MockReputationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReputation) EXPECT() *MockReputationMockRecorder {
+	return m.recorder
+}
+
+// GetSPReputation mocks base method.
+func (m *MockReputation) GetSPReputation(operatorAddress string) (*SPReputation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSPReputation", operatorAddress)
+	ret0, _ := ret[0].(*SPReputation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSPReputation indicates an expected call of GetSPReputation.
+func (mr *MockReputationMockRecorder) GetSPReputation(operatorAddress interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSPReputation", reflect.TypeOf((*MockReputation)(nil).GetSPReputation), operatorAddress)
+}
+
+// ListSPReputations mocks base method.
+func (m *MockReputation) ListSPReputations(operatorAddresses []string) ([]*SPReputation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSPReputations", operatorAddresses)
+	ret0, _ := ret[0].([]*SPReputation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSPReputations indicates an expected call of ListSPReputations.
+func (mr *MockReputationMockRecorder) ListSPReputations(operatorAddresses interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSPReputations", reflect.TypeOf((*MockReputation)(nil).ListSPReputations), operatorAddresses)
+}
+
+// RecordSPReplication mocks base method.
+func (m *MockReputation) RecordSPReplication(replication *SPReplication) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordSPReplication", replication)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordSPReplication indicates an expected call of RecordSPReplication.
+func (mr *MockReputationMockRecorder) RecordSPReplication(replication interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordSPReplication", reflect.TypeOf((*MockReputation)(nil).RecordSPReplication), replication)
+}
+
+// SetSPReputation mocks base method.
+func (m *MockReputation) SetSPReputation(reputation *SPReputation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSPReputation", reputation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSPReputation indicates an expected call of SetSPReputation.
+func (mr *MockReputationMockRecorder) SetSPReputation(reputation interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSPReputation", reflect.TypeOf((*MockReputation)(nil).SetSPReputation), reputation)
+}
+
+// MockSPDB is a mock of SPDB interface.
+type MockSPDB struct {
+	ctrl     *gomock.Controller
+	recorder *MockSPDBMockRecorder
+}
+
+// MockSPDBMockRecorder is the mock recorder for MockSPDB.
+type MockSPDBMockRecorder struct {
+	mock *MockSPDB
+}
+
+// NewMockSPDB creates a new mock instance.
+func NewMockSPDB(ctrl *gomock.Controller) *MockSPDB {
+	mock := &MockSPDB{ctrl: ctrl}
+	mock.recorder = &MockSPDBMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSPDB) EXPECT() *MockSPDBMockRecorder {
+	return m.recorder
+}
+
+// CheckQuotaAndAddReadRecord mocks base method.
+func (m *MockSPDB) CheckQuotaAndAddReadRecord(record *ReadRecord, quota *BucketQuota) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckQuotaAndAddReadRecord", record, quota)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckQuotaAndAddReadRecord indicates an expected call of CheckQuotaAndAddReadRecord.
+func (mr *MockSPDBMockRecorder) CheckQuotaAndAddReadRecord(record, quota interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckQuotaAndAddReadRecord", reflect.TypeOf((*MockSPDB)(nil).CheckQuotaAndAddReadRecord), record, quota)
+}
+
+// ClaimFailedGCObjects mocks base method.
+func (m *MockSPDB) ClaimFailedGCObjects(maxRetry uint32, limit int, lease time.Duration) ([]*GCObjectProgress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimFailedGCObjects", maxRetry, limit, lease)
+	ret0, _ := ret[0].([]*GCObjectProgress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimFailedGCObjects indicates an expected call of ClaimFailedGCObjects.
+func (mr *MockSPDBMockRecorder) ClaimFailedGCObjects(maxRetry, limit, lease interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimFailedGCObjects", reflect.TypeOf((*MockSPDB)(nil).ClaimFailedGCObjects), maxRetry, limit, lease)
+}
+
+// CreateGCTask mocks base method.
+func (m *MockSPDB) CreateGCTask(startBlock, endBlock uint64) (*GCTask, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGCTask", startBlock, endBlock)
+	ret0, _ := ret[0].(*GCTask)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGCTask indicates an expected call of CreateGCTask.
+func (mr *MockSPDBMockRecorder) CreateGCTask(startBlock, endBlock interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGCTask", reflect.TypeOf((*MockSPDB)(nil).CreateGCTask), startBlock, endBlock)
+}
+
+// CreateUploadJob mocks base method.
+func (m *MockSPDB) CreateUploadJob(objectInfo *types1.ObjectInfo) (*types.JobContext, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUploadJob", objectInfo)
+	ret0, _ := ret[0].(*types.JobContext)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUploadJob indicates an expected call of CreateUploadJob.
+func (mr *MockSPDBMockRecorder) CreateUploadJob(objectInfo interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUploadJob", reflect.TypeOf((*MockSPDB)(nil).CreateUploadJob), objectInfo)
+}
+
+// DeleteAllPieceChecksums mocks base method.
+func (m *MockSPDB) DeleteAllPieceChecksums(objectID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAllPieceChecksums", objectID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAllPieceChecksums indicates an expected call of DeleteAllPieceChecksums.
+func (mr *MockSPDBMockRecorder) DeleteAllPieceChecksums(objectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAllPieceChecksums", reflect.TypeOf((*MockSPDB)(nil).DeleteAllPieceChecksums), objectID)
+}
+
+// DeleteBucketCORS mocks base method.
+func (m *MockSPDB) DeleteBucketCORS(bucketName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBucketCORS", bucketName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBucketCORS indicates an expected call of DeleteBucketCORS.
+func (mr *MockSPDBMockRecorder) DeleteBucketCORS(bucketName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBucketCORS", reflect.TypeOf((*MockSPDB)(nil).DeleteBucketCORS), bucketName)
+}
+
+// FetchAllSp mocks base method.
+func (m *MockSPDB) FetchAllSp(status ...types0.Status) ([]*types0.StorageProvider, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range status {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FetchAllSp", varargs...)
+	ret0, _ := ret[0].([]*types0.StorageProvider)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchAllSp indicates an expected call of FetchAllSp.
+func (mr *MockSPDBMockRecorder) FetchAllSp(status ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchAllSp", reflect.TypeOf((*MockSPDB)(nil).FetchAllSp), status...)
+}
+
+// FetchAllSpWithoutOwnSp mocks base method.
+func (m *MockSPDB) FetchAllSpWithoutOwnSp(status ...types0.Status) ([]*types0.StorageProvider, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range status {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FetchAllSpWithoutOwnSp", varargs...)
+	ret0, _ := ret[0].([]*types0.StorageProvider)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchAllSpWithoutOwnSp indicates an expected call of FetchAllSpWithoutOwnSp.
+func (mr *MockSPDBMockRecorder) FetchAllSpWithoutOwnSp(status ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchAllSpWithoutOwnSp", reflect.TypeOf((*MockSPDB)(nil).FetchAllSpWithoutOwnSp), status...)
+}
+
+// GetAllPieceChecksums mocks base method.
+func (m *MockSPDB) GetAllPieceChecksums(objectID uint64) (map[uint32][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllPieceChecksums", objectID)
+	ret0, _ := ret[0].(map[uint32][]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllPieceChecksums indicates an expected call of GetAllPieceChecksums.
+func (mr *MockSPDBMockRecorder) GetAllPieceChecksums(objectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllPieceChecksums", reflect.TypeOf((*MockSPDB)(nil).GetAllPieceChecksums), objectID)
+}
+
+// GetAuthKey mocks base method.
+func (m *MockSPDB) GetAuthKey(userAddress, domain string) (*OffChainAuthKeyTable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuthKey", userAddress, domain)
+	ret0, _ := ret[0].(*OffChainAuthKeyTable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuthKey indicates an expected call of GetAuthKey.
+func (mr *MockSPDBMockRecorder) GetAuthKey(userAddress, domain interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthKey", reflect.TypeOf((*MockSPDB)(nil).GetAuthKey), userAddress, domain)
+}
+
+// GetBucketCORS mocks base method.
+func (m *MockSPDB) GetBucketCORS(bucketName string) (*BucketCORSTable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBucketCORS", bucketName)
+	ret0, _ := ret[0].(*BucketCORSTable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBucketCORS indicates an expected call of GetBucketCORS.
+func (mr *MockSPDBMockRecorder) GetBucketCORS(bucketName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBucketCORS", reflect.TypeOf((*MockSPDB)(nil).GetBucketCORS), bucketName)
+}
+
+// GetBucketReadRecord mocks base method.
+func (m *MockSPDB) GetBucketReadRecord(bucketID uint64, timeRange *TrafficTimeRange) ([]*ReadRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBucketReadRecord", bucketID, timeRange)
+	ret0, _ := ret[0].([]*ReadRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBucketReadRecord indicates an expected call of GetBucketReadRecord.
+func (mr *MockSPDBMockRecorder) GetBucketReadRecord(bucketID, timeRange interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBucketReadRecord", reflect.TypeOf((*MockSPDB)(nil).GetBucketReadRecord), bucketID, timeRange)
+}
+
+// GetBucketTraffic mocks base method.
+func (m *MockSPDB) GetBucketTraffic(bucketID uint64, yearMonth string) (*BucketTraffic, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBucketTraffic", bucketID, yearMonth)
+	ret0, _ := ret[0].(*BucketTraffic)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBucketTraffic indicates an expected call of GetBucketTraffic.
+func (mr *MockSPDBMockRecorder) GetBucketTraffic(bucketID, yearMonth interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBucketTraffic", reflect.TypeOf((*MockSPDB)(nil).GetBucketTraffic), bucketID, yearMonth)
+}
+
+// GetGCBlockProgress mocks base method.
+func (m *MockSPDB) GetGCBlockProgress() (*GCBlockProgress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGCBlockProgress")
+	ret0, _ := ret[0].(*GCBlockProgress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGCBlockProgress indicates an expected call of GetGCBlockProgress.
+func (mr *MockSPDBMockRecorder) GetGCBlockProgress() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGCBlockProgress", reflect.TypeOf((*MockSPDB)(nil).GetGCBlockProgress))
+}
+
+// GetGCObjectProgress mocks base method.
+func (m *MockSPDB) GetGCObjectProgress(objectID uint64) (*GCObjectProgress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGCObjectProgress", objectID)
+	ret0, _ := ret[0].(*GCObjectProgress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGCObjectProgress indicates an expected call of GetGCObjectProgress.
+func (mr *MockSPDBMockRecorder) GetGCObjectProgress(objectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGCObjectProgress", reflect.TypeOf((*MockSPDB)(nil).GetGCObjectProgress), objectID)
+}
+
+// GetJobByID mocks base method.
+func (m *MockSPDB) GetJobByID(jobID uint64) (*types.JobContext, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJobByID", jobID)
+	ret0, _ := ret[0].(*types.JobContext)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJobByID indicates an expected call of GetJobByID.
+func (mr *MockSPDBMockRecorder) GetJobByID(jobID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJobByID", reflect.TypeOf((*MockSPDB)(nil).GetJobByID), jobID)
+}
+
+// GetJobByObjectID mocks base method.
+func (m *MockSPDB) GetJobByObjectID(objectID uint64) (*types.JobContext, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJobByObjectID", objectID)
+	ret0, _ := ret[0].(*types.JobContext)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJobByObjectID indicates an expected call of GetJobByObjectID.
+func (mr *MockSPDBMockRecorder) GetJobByObjectID(objectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJobByObjectID", reflect.TypeOf((*MockSPDB)(nil).GetJobByObjectID), objectID)
+}
+
+// GetObjectInfo mocks base method.
+func (m *MockSPDB) GetObjectInfo(objectID uint64) (*types1.ObjectInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetObjectInfo", objectID)
+	ret0, _ := ret[0].(*types1.ObjectInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetObjectInfo indicates an expected call of GetObjectInfo.
+func (mr *MockSPDBMockRecorder) GetObjectInfo(objectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObjectInfo", reflect.TypeOf((*MockSPDB)(nil).GetObjectInfo), objectID)
+}
+
+// GetObjectIntegrity mocks base method.
+func (m *MockSPDB) GetObjectIntegrity(objectID uint64) (*IntegrityMeta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetObjectIntegrity", objectID)
+	ret0, _ := ret[0].(*IntegrityMeta)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetObjectIntegrity indicates an expected call of GetObjectIntegrity.
+func (mr *MockSPDBMockRecorder) GetObjectIntegrity(objectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObjectIntegrity", reflect.TypeOf((*MockSPDB)(nil).GetObjectIntegrity), objectID)
+}
+
+// GetObjectReadRecord mocks base method.
+func (m *MockSPDB) GetObjectReadRecord(objectID uint64, timeRange *TrafficTimeRange) ([]*ReadRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetObjectReadRecord", objectID, timeRange)
+	ret0, _ := ret[0].([]*ReadRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetObjectReadRecord indicates an expected call of GetObjectReadRecord.
+func (mr *MockSPDBMockRecorder) GetObjectReadRecord(objectID, timeRange interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObjectReadRecord", reflect.TypeOf((*MockSPDB)(nil).GetObjectReadRecord), objectID, timeRange)
+}
+
+// GetObjectsReadCount mocks base method.
+func (m *MockSPDB) GetObjectsReadCount(objectIDs []uint64, startTimestampUs int64) (map[uint64]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetObjectsReadCount", objectIDs, startTimestampUs)
+	ret0, _ := ret[0].(map[uint64]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetObjectsReadCount indicates an expected call of GetObjectsReadCount.
+func (mr *MockSPDBMockRecorder) GetObjectsReadCount(objectIDs, startTimestampUs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObjectsReadCount", reflect.TypeOf((*MockSPDB)(nil).GetObjectsReadCount), objectIDs, startTimestampUs)
+}
+
+// GetOwnSpInfo mocks base method.
+func (m *MockSPDB) GetOwnSpInfo() (*types0.StorageProvider, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOwnSpInfo")
+	ret0, _ := ret[0].(*types0.StorageProvider)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOwnSpInfo indicates an expected call of GetOwnSpInfo.
+func (mr *MockSPDBMockRecorder) GetOwnSpInfo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOwnSpInfo", reflect.TypeOf((*MockSPDB)(nil).GetOwnSpInfo))
+}
+
+// GetReadRecord mocks base method.
+func (m *MockSPDB) GetReadRecord(timeRange *TrafficTimeRange) ([]*ReadRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReadRecord", timeRange)
+	ret0, _ := ret[0].([]*ReadRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReadRecord indicates an expected call of GetReadRecord.
+func (mr *MockSPDBMockRecorder) GetReadRecord(timeRange interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReadRecord", reflect.TypeOf((*MockSPDB)(nil).GetReadRecord), timeRange)
+}
+
+// GetS3Credential mocks base method.
+func (m *MockSPDB) GetS3Credential(accessKeyID string) (*S3CredentialTable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetS3Credential", accessKeyID)
+	ret0, _ := ret[0].(*S3CredentialTable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetS3Credential indicates an expected call of GetS3Credential.
+func (mr *MockSPDBMockRecorder) GetS3Credential(accessKeyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetS3Credential", reflect.TypeOf((*MockSPDB)(nil).GetS3Credential), accessKeyID)
+}
+
+// GetSPReputation mocks base method.
+func (m *MockSPDB) GetSPReputation(operatorAddress string) (*SPReputation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSPReputation", operatorAddress)
+	ret0, _ := ret[0].(*SPReputation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSPReputation indicates an expected call of GetSPReputation.
+func (mr *MockSPDBMockRecorder) GetSPReputation(operatorAddress interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSPReputation", reflect.TypeOf((*MockSPDB)(nil).GetSPReputation), operatorAddress)
+}
+
+// GetScrubResult mocks base method.
+func (m *MockSPDB) GetScrubResult(objectID uint64) (*ScrubResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScrubResult", objectID)
+	ret0, _ := ret[0].(*ScrubResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScrubResult indicates an expected call of GetScrubResult.
+func (mr *MockSPDBMockRecorder) GetScrubResult(objectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScrubResult", reflect.TypeOf((*MockSPDB)(nil).GetScrubResult), objectID)
+}
+
+// GetSecondarySpSignatures mocks base method.
+func (m *MockSPDB) GetSecondarySpSignatures(objectID uint64) ([][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecondarySpSignatures", objectID)
+	ret0, _ := ret[0].([][]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecondarySpSignatures indicates an expected call of GetSecondarySpSignatures.
+func (mr *MockSPDBMockRecorder) GetSecondarySpSignatures(objectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecondarySpSignatures", reflect.TypeOf((*MockSPDB)(nil).GetSecondarySpSignatures), objectID)
+}
+
+// GetSpByAddress mocks base method.
+func (m *MockSPDB) GetSpByAddress(address string, addressType SpAddressType) (*types0.StorageProvider, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSpByAddress", address, addressType)
+	ret0, _ := ret[0].(*types0.StorageProvider)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSpByAddress indicates an expected call of GetSpByAddress.
+func (mr *MockSPDBMockRecorder) GetSpByAddress(address, addressType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSpByAddress", reflect.TypeOf((*MockSPDB)(nil).GetSpByAddress), address, addressType)
+}
+
+// GetSpByEndpoint mocks base method.
 func (m *MockSPDB) GetSpByEndpoint(endpoint string) (*types0.StorageProvider, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetSpByEndpoint", endpoint)
@@ -931,6 +1675,137 @@ func (mr *MockSPDBMockRecorder) InsertAuthKey(newRecord interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertAuthKey", reflect.TypeOf((*MockSPDB)(nil).InsertAuthKey), newRecord)
 }
 
+// InsertS3Credential mocks base method.
+func (m *MockSPDB) InsertS3Credential(newRecord *S3CredentialTable) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertS3Credential", newRecord)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertS3Credential indicates an expected call of InsertS3Credential.
+func (mr *MockSPDBMockRecorder) InsertS3Credential(newRecord interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertS3Credential", reflect.TypeOf((*MockSPDB)(nil).InsertS3Credential), newRecord)
+}
+
+// ListObjectIntegrities mocks base method.
+func (m *MockSPDB) ListObjectIntegrities(afterObjectID uint64, limit int) ([]*IntegrityMeta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListObjectIntegrities", afterObjectID, limit)
+	ret0, _ := ret[0].([]*IntegrityMeta)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListObjectIntegrities indicates an expected call of ListObjectIntegrities.
+func (mr *MockSPDBMockRecorder) ListObjectIntegrities(afterObjectID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListObjectIntegrities", reflect.TypeOf((*MockSPDB)(nil).ListObjectIntegrities), afterObjectID, limit)
+}
+
+// ListSPReputations mocks base method.
+func (m *MockSPDB) ListSPReputations(operatorAddresses []string) ([]*SPReputation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSPReputations", operatorAddresses)
+	ret0, _ := ret[0].([]*SPReputation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSPReputations indicates an expected call of ListSPReputations.
+func (mr *MockSPDBMockRecorder) ListSPReputations(operatorAddresses interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSPReputations", reflect.TypeOf((*MockSPDB)(nil).ListSPReputations), operatorAddresses)
+}
+
+// ListScrubResults mocks base method.
+func (m *MockSPDB) ListScrubResults(status ScrubStatus, limit int) ([]*ScrubResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListScrubResults", status, limit)
+	ret0, _ := ret[0].([]*ScrubResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListScrubResults indicates an expected call of ListScrubResults.
+func (mr *MockSPDBMockRecorder) ListScrubResults(status, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListScrubResults", reflect.TypeOf((*MockSPDB)(nil).ListScrubResults), status, limit)
+}
+
+// ListUnfinishedGCTasks mocks base method.
+func (m *MockSPDB) ListUnfinishedGCTasks() ([]*GCTask, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUnfinishedGCTasks")
+	ret0, _ := ret[0].([]*GCTask)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUnfinishedGCTasks indicates an expected call of ListUnfinishedGCTasks.
+func (mr *MockSPDBMockRecorder) ListUnfinishedGCTasks() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUnfinishedGCTasks", reflect.TypeOf((*MockSPDB)(nil).ListUnfinishedGCTasks))
+}
+
+// ListUploadJobsByState mocks base method.
+func (m *MockSPDB) ListUploadJobsByState(states []types.JobState, modifiedBefore time.Time, maxRetry uint32, limit int) ([]*UploadJobMeta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUploadJobsByState", states, modifiedBefore, maxRetry, limit)
+	ret0, _ := ret[0].([]*UploadJobMeta)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUploadJobsByState indicates an expected call of ListUploadJobsByState.
+func (mr *MockSPDBMockRecorder) ListUploadJobsByState(states, modifiedBefore, maxRetry, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUploadJobsByState", reflect.TypeOf((*MockSPDB)(nil).ListUploadJobsByState), states, modifiedBefore, maxRetry, limit)
+}
+
+// RecordSPReplication mocks base method.
+func (m *MockSPDB) RecordSPReplication(replication *SPReplication) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordSPReplication", replication)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordSPReplication indicates an expected call of RecordSPReplication.
+func (mr *MockSPDBMockRecorder) RecordSPReplication(replication interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordSPReplication", reflect.TypeOf((*MockSPDB)(nil).RecordSPReplication), replication)
+}
+
+// SetGCBlockProgress mocks base method.
+func (m *MockSPDB) SetGCBlockProgress(progress *GCBlockProgress) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetGCBlockProgress", progress)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetGCBlockProgress indicates an expected call of SetGCBlockProgress.
+func (mr *MockSPDBMockRecorder) SetGCBlockProgress(progress interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetGCBlockProgress", reflect.TypeOf((*MockSPDB)(nil).SetGCBlockProgress), progress)
+}
+
+// SetGCObjectProgress mocks base method.
+func (m *MockSPDB) SetGCObjectProgress(progress *GCObjectProgress) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetGCObjectProgress", progress)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetGCObjectProgress indicates an expected call of SetGCObjectProgress.
+func (mr *MockSPDBMockRecorder) SetGCObjectProgress(progress interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetGCObjectProgress", reflect.TypeOf((*MockSPDB)(nil).SetGCObjectProgress), progress)
+}
+
 // SetObjectInfo mocks base method.
 func (m *MockSPDB) SetObjectInfo(objectID uint64, objectInfo *types1.ObjectInfo) error {
 	m.ctrl.T.Helper()
@@ -973,6 +1848,62 @@ func (mr *MockSPDBMockRecorder) SetOwnSpInfo(sp interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOwnSpInfo", reflect.TypeOf((*MockSPDB)(nil).SetOwnSpInfo), sp)
 }
 
+// SetPieceChecksum mocks base method.
+func (m *MockSPDB) SetPieceChecksum(objectID uint64, segmentIndex uint32, checksum []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPieceChecksum", objectID, segmentIndex, checksum)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPieceChecksum indicates an expected call of SetPieceChecksum.
+func (mr *MockSPDBMockRecorder) SetPieceChecksum(objectID, segmentIndex, checksum interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPieceChecksum", reflect.TypeOf((*MockSPDB)(nil).SetPieceChecksum), objectID, segmentIndex, checksum)
+}
+
+// SetSPReputation mocks base method.
+func (m *MockSPDB) SetSPReputation(reputation *SPReputation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSPReputation", reputation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSPReputation indicates an expected call of SetSPReputation.
+func (mr *MockSPDBMockRecorder) SetSPReputation(reputation interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSPReputation", reflect.TypeOf((*MockSPDB)(nil).SetSPReputation), reputation)
+}
+
+// SetScrubResult mocks base method.
+func (m *MockSPDB) SetScrubResult(result *ScrubResult) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetScrubResult", result)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetScrubResult indicates an expected call of SetScrubResult.
+func (mr *MockSPDBMockRecorder) SetScrubResult(result interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetScrubResult", reflect.TypeOf((*MockSPDB)(nil).SetScrubResult), result)
+}
+
+// SetSecondarySpSignatures mocks base method.
+func (m *MockSPDB) SetSecondarySpSignatures(objectID uint64, signatures [][]byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSecondarySpSignatures", objectID, signatures)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSecondarySpSignatures indicates an expected call of SetSecondarySpSignatures.
+func (mr *MockSPDBMockRecorder) SetSecondarySpSignatures(objectID, signatures interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSecondarySpSignatures", reflect.TypeOf((*MockSPDB)(nil).SetSecondarySpSignatures), objectID, signatures)
+}
+
 // SetStorageParams mocks base method.
 func (m *MockSPDB) SetStorageParams(params *types1.Params) error {
 	m.ctrl.T.Helper()
@@ -1015,6 +1946,62 @@ func (mr *MockSPDBMockRecorder) UpdateAuthKey(userAddress, domain, oldNonce, new
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAuthKey", reflect.TypeOf((*MockSPDB)(nil).UpdateAuthKey), userAddress, domain, oldNonce, newNonce, newPublicKey, newExpiryDate)
 }
 
+// UpdateBucketCORS mocks base method.
+func (m *MockSPDB) UpdateBucketCORS(record *BucketCORSTable) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBucketCORS", record)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBucketCORS indicates an expected call of UpdateBucketCORS.
+func (mr *MockSPDBMockRecorder) UpdateBucketCORS(record interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBucketCORS", reflect.TypeOf((*MockSPDB)(nil).UpdateBucketCORS), record)
+}
+
+// UpdateGCTask mocks base method.
+func (m *MockSPDB) UpdateGCTask(jobID uint64, state types.JobState, retryCount uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGCTask", jobID, state, retryCount)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateGCTask indicates an expected call of UpdateGCTask.
+func (mr *MockSPDBMockRecorder) UpdateGCTask(jobID, state, retryCount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGCTask", reflect.TypeOf((*MockSPDB)(nil).UpdateGCTask), jobID, state, retryCount)
+}
+
+// UpdateGCTaskStartBlock mocks base method.
+func (m *MockSPDB) UpdateGCTaskStartBlock(jobID, startBlock uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGCTaskStartBlock", jobID, startBlock)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateGCTaskStartBlock indicates an expected call of UpdateGCTaskStartBlock.
+func (mr *MockSPDBMockRecorder) UpdateGCTaskStartBlock(jobID, startBlock interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGCTaskStartBlock", reflect.TypeOf((*MockSPDB)(nil).UpdateGCTaskStartBlock), jobID, startBlock)
+}
+
+// UpdateJobRetryCount mocks base method.
+func (m *MockSPDB) UpdateJobRetryCount(jobID uint64, retryCount uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateJobRetryCount", jobID, retryCount)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateJobRetryCount indicates an expected call of UpdateJobRetryCount.
+func (mr *MockSPDBMockRecorder) UpdateJobRetryCount(jobID, retryCount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateJobRetryCount", reflect.TypeOf((*MockSPDB)(nil).UpdateJobRetryCount), jobID, retryCount)
+}
+
 // UpdateJobState mocks base method.
 func (m *MockSPDB) UpdateJobState(objectID uint64, state types.JobState) error {
 	m.ctrl.T.Helper()
@@ -1,6 +1,10 @@
 package sqldb
 
-import "time"
+import (
+	"time"
+
+	servicetypes "github.com/bnb-chain/greenfield-storage-provider/service/types"
+)
 
 // IntegrityMeta defines the payload integrity hash and piece checksum with objectID
 type IntegrityMeta struct {
@@ -10,6 +14,12 @@ type IntegrityMeta struct {
 	Signature     []byte
 }
 
+// UploadJobMeta defines the upload job context with objectID
+type UploadJobMeta struct {
+	ObjectID   uint64
+	JobContext *servicetypes.JobContext
+}
+
 // SpAddressType identify address type of SP
 type SpAddressType int32
 
@@ -84,3 +94,101 @@ func TimestampSecToTime(timeUnix int64) time.Time {
 func TimeToYearMonth(t time.Time) string {
 	return t.Format("2006-01-02 15:04:05")[0:7]
 }
+
+// GCBlockProgress defines the last block range which has been fully processed by gc,
+// the range is [StartBlockNumber, EndBlockNumber]
+type GCBlockProgress struct {
+	StartBlockNumber uint64
+	EndBlockNumber   uint64
+	ModifyTime       int64
+}
+
+// GCObjectStatus identify the gc outcome of an object
+type GCObjectStatus int32
+
+const (
+	GCObjectStatusDeleted GCObjectStatus = iota + 1
+	GCObjectStatusFailed
+	// GCObjectStatusRetrying means the failed pieces are being retried by the gc task which claims the object
+	GCObjectStatusRetrying
+)
+
+// GCTask defines a gc job which releases the pieces of the deleted objects in the block range
+// [StartBlockNumber, EndBlockNumber]
+type GCTask struct {
+	JobID            uint64
+	JobState         servicetypes.JobState
+	StartBlockNumber uint64
+	EndBlockNumber   uint64
+	RetryCount       uint32
+	CreateTime       int64
+	ModifyTime       int64
+}
+
+// GCObjectProgress defines the gc outcome of an object, FailedPieceKeys records the piece keys
+// which are failed to delete and should be retried
+type GCObjectProgress struct {
+	ObjectID         uint64
+	BlockNumber      uint64
+	Status           GCObjectStatus
+	FailedPieceKeys  []string
+	RetryCount       uint32
+	ErrorDescription string
+	CreateTime       int64
+	ModifyTime       int64
+}
+
+// ScrubStatus identify the scrub outcome of an object
+type ScrubStatus int32
+
+const (
+	ScrubStatusHealthy ScrubStatus = iota + 1
+	ScrubStatusCorrupt
+	ScrubStatusRepaired
+)
+
+// ScrubResult defines the scrub outcome of an object, CorruptPieceKeys records the piece keys
+// which are lost or mismatch the checksums in integrity meta
+type ScrubResult struct {
+	ObjectID         uint64
+	Status           ScrubStatus
+	ScrubbedPieces   uint32
+	CorruptPieceKeys []string
+	ErrorDescription string
+	CreateTime       int64
+	ModifyTime       int64
+}
+
+// SPReputation defines the replication statistics of a secondary sp, which are used to score the sp while
+// picking secondary sps, AverageLatency(ms) and AverageThroughput(bytes/s) are exponential moving averages,
+// and the sp is not picked until CoolingUntil(unix seconds) if it misbehaves.
+type SPReputation struct {
+	OperatorAddress       string
+	SuccessCount          uint64
+	FailureCount          uint64
+	SignatureFailureCount uint64
+	ConsecutiveFailures   uint32
+	AverageLatency        int64
+	AverageThroughput     int64
+	CoolingUntil          int64
+	ModifyTime            int64
+}
+
+// SPReplication defines the outcome of replicating data to a secondary sp and the rules of updating its
+// reputation, Latency(ms) and Throughput(bytes/s) are the samples of a successful replication.
+type SPReplication struct {
+	OperatorAddress  string
+	Success          bool
+	SignatureFailure bool
+	Latency          int64
+	Throughput       int64
+	// SmoothingFactor is the weight of the latest sample in the moving averages
+	SmoothingFactor float64
+	// MaxConsecutiveFailures is the number of consecutive failures after which the sp enters cooling-off period
+	MaxConsecutiveFailures uint32
+	// CoolingUntil is the end of cooling-off period if the sp enters it by this replication
+	CoolingUntil int64
+	// SignatureFailureDecay is the number of successes after which one signature failure is forgiven,
+	// the signature failures never decay if it is 0
+	SignatureFailureDecay uint64
+}
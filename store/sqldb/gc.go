@@ -0,0 +1,268 @@
+package sqldb
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	servicetypes "github.com/bnb-chain/greenfield-storage-provider/service/types"
+	"github.com/bnb-chain/greenfield-storage-provider/util"
+)
+
+// GetGCBlockProgress return the last block range which has been fully processed by gc
+func (s *SpDBImpl) GetGCBlockProgress() (*GCBlockProgress, error) {
+	queryReturn := &GCBlockProgressTable{}
+	result := s.db.Last(queryReturn)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, result.Error
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to query gc block progress table: %s", result.Error)
+	}
+	return &GCBlockProgress{
+		StartBlockNumber: queryReturn.StartBlockNumber,
+		EndBlockNumber:   queryReturn.EndBlockNumber,
+		ModifyTime:       queryReturn.ModifiedTime.Unix(),
+	}, nil
+}
+
+// SetGCBlockProgress set(maybe overwrite) the last block range which has been fully processed by gc,
+// there is only one record in GCBlockProgressTable
+func (s *SpDBImpl) SetGCBlockProgress(progress *GCBlockProgress) error {
+	queryReturn := &GCBlockProgressTable{}
+	result := s.db.Last(queryReturn)
+	recordNotFound := errors.Is(result.Error, gorm.ErrRecordNotFound)
+	if result.Error != nil && !recordNotFound {
+		return fmt.Errorf("failed to query gc block progress table: %s", result.Error)
+	}
+
+	// if there is no records in GCBlockProgressTable, insert a new record
+	if recordNotFound {
+		insertProgressRecord := &GCBlockProgressTable{
+			StartBlockNumber: progress.StartBlockNumber,
+			EndBlockNumber:   progress.EndBlockNumber,
+			ModifiedTime:     time.Now(),
+		}
+		result = s.db.Create(insertProgressRecord)
+		if result.Error != nil || result.RowsAffected != 1 {
+			return fmt.Errorf("failed to insert gc block progress table: %s", result.Error)
+		}
+		return nil
+	}
+	// use map to update, otherwise the zero value start block number will be ignored by gorm
+	result = s.db.Model(&GCBlockProgressTable{ID: queryReturn.ID}).Updates(map[string]interface{}{
+		"start_block_number": progress.StartBlockNumber,
+		"end_block_number":   progress.EndBlockNumber,
+		"modified_time":      time.Now(),
+	})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update gc block progress table: %s", result.Error)
+	}
+	return nil
+}
+
+// GetGCObjectProgress return the gc outcome of an object
+func (s *SpDBImpl) GetGCObjectProgress(objectID uint64) (*GCObjectProgress, error) {
+	queryReturn := &GCObjectProgressTable{}
+	result := s.db.First(queryReturn, "object_id = ?", objectID)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, result.Error
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to query gc object progress table: %s", result.Error)
+	}
+	return toGCObjectProgress(queryReturn), nil
+}
+
+// SetGCObjectProgress set(maybe overwrite) the gc outcome of an object
+func (s *SpDBImpl) SetGCObjectProgress(progress *GCObjectProgress) error {
+	queryReturn := &GCObjectProgressTable{}
+	result := s.db.First(queryReturn, "object_id = ?", progress.ObjectID)
+	recordNotFound := errors.Is(result.Error, gorm.ErrRecordNotFound)
+	if result.Error != nil && !recordNotFound {
+		return fmt.Errorf("failed to query gc object progress table: %s", result.Error)
+	}
+
+	if recordNotFound {
+		insertProgressRecord := &GCObjectProgressTable{
+			ObjectID:         progress.ObjectID,
+			BlockNumber:      progress.BlockNumber,
+			Status:           int32(progress.Status),
+			FailedPieceKeys:  util.JoinWithComma(progress.FailedPieceKeys),
+			RetryCount:       progress.RetryCount,
+			ErrorDescription: progress.ErrorDescription,
+			CreatedTime:      time.Now(),
+			ModifiedTime:     time.Now(),
+		}
+		result = s.db.Create(insertProgressRecord)
+		if result.Error != nil || result.RowsAffected != 1 {
+			return fmt.Errorf("failed to insert gc object progress table: %s", result.Error)
+		}
+		return nil
+	}
+	// use map to update, otherwise the cleared failed piece keys and error description will be ignored by gorm
+	result = s.db.Model(&GCObjectProgressTable{ObjectID: progress.ObjectID}).Updates(map[string]interface{}{
+		"block_number":      progress.BlockNumber,
+		"status":            int32(progress.Status),
+		"failed_piece_keys": util.JoinWithComma(progress.FailedPieceKeys),
+		"retry_count":       progress.RetryCount,
+		"error_description": progress.ErrorDescription,
+		"modified_time":     time.Now(),
+	})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update gc object progress table: %s", result.Error)
+	}
+	return nil
+}
+
+// ClaimFailedGCObjects claims the objects which are failed to gc and whose retry count is less than maxRetry by
+// marking them retrying. The claim is a conditional update on the observed status and modified time, so every
+// object is only claimed by one caller; the retrying objects which are not finished within lease are regarded
+// as abandoned, e.g. the task node crashed, and can be claimed again.
+func (s *SpDBImpl) ClaimFailedGCObjects(maxRetry uint32, limit int, lease time.Duration) ([]*GCObjectProgress, error) {
+	var (
+		progresses   []*GCObjectProgress
+		queryReturns []GCObjectProgressTable
+	)
+
+	query := s.db.Where("(status = ? or (status = ? and modified_time < ?)) and retry_count < ?",
+		int32(GCObjectStatusFailed), int32(GCObjectStatusRetrying), time.Now().Add(-lease), maxRetry).
+		Order("modified_time asc")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if result := query.Find(&queryReturns); result.Error != nil {
+		return progresses, fmt.Errorf("failed to query gc object progress table: %s", result.Error)
+	}
+	for index := range queryReturns {
+		record := &queryReturns[index]
+		claimTime := time.Now()
+		result := s.db.Model(&GCObjectProgressTable{}).
+			Where("object_id = ? and status = ? and modified_time = ?", record.ObjectID, record.Status, record.ModifiedTime).
+			Updates(map[string]interface{}{
+				"status":        int32(GCObjectStatusRetrying),
+				"modified_time": claimTime,
+			})
+		if result.Error != nil {
+			return progresses, fmt.Errorf("failed to claim gc object progress table: %s", result.Error)
+		}
+		if result.RowsAffected != 1 {
+			// the object has been claimed by another caller
+			continue
+		}
+		record.Status = int32(GCObjectStatusRetrying)
+		record.ModifiedTime = claimTime
+		progresses = append(progresses, toGCObjectProgress(record))
+	}
+	return progresses, nil
+}
+
+// toGCObjectProgress convert GCObjectProgressTable record to GCObjectProgress
+func toGCObjectProgress(record *GCObjectProgressTable) *GCObjectProgress {
+	return &GCObjectProgress{
+		ObjectID:         record.ObjectID,
+		BlockNumber:      record.BlockNumber,
+		Status:           GCObjectStatus(record.Status),
+		FailedPieceKeys:  util.SplitByComma(record.FailedPieceKeys),
+		RetryCount:       record.RetryCount,
+		ErrorDescription: record.ErrorDescription,
+		CreateTime:       record.CreatedTime.Unix(),
+		ModifyTime:       record.ModifiedTime.Unix(),
+	}
+}
+
+// CreateGCTask create JobTable record and GCTaskTable record; use JobID field for association
+func (s *SpDBImpl) CreateGCTask(startBlock, endBlock uint64) (*GCTask, error) {
+	insertJobRecord := &JobTable{
+		JobType:      int32(servicetypes.JobType_JOB_TYPE_DELETE_OBJECT),
+		JobState:     int32(servicetypes.JobState_JOB_STATE_INIT_UNSPECIFIED),
+		CreatedTime:  time.Now(),
+		ModifiedTime: time.Now(),
+	}
+	result := s.db.Create(insertJobRecord)
+	if result.Error != nil || result.RowsAffected != 1 {
+		return nil, fmt.Errorf("failed to insert job table: %s", result.Error)
+	}
+
+	insertTaskRecord := &GCTaskTable{
+		JobID:            insertJobRecord.JobID,
+		StartBlockNumber: startBlock,
+		EndBlockNumber:   endBlock,
+	}
+	result = s.db.Create(insertTaskRecord)
+	if result.Error != nil || result.RowsAffected != 1 {
+		return nil, fmt.Errorf("failed to insert gc task table: %s", result.Error)
+	}
+
+	return &GCTask{
+		JobID:            insertJobRecord.JobID,
+		JobState:         servicetypes.JobState(insertJobRecord.JobState),
+		StartBlockNumber: insertTaskRecord.StartBlockNumber,
+		EndBlockNumber:   insertTaskRecord.EndBlockNumber,
+		CreateTime:       insertJobRecord.CreatedTime.Unix(),
+		ModifyTime:       insertJobRecord.ModifiedTime.Unix(),
+	}, nil
+}
+
+// UpdateGCTask update JobTable record's state and GCTaskTable record's retry count
+func (s *SpDBImpl) UpdateGCTask(jobID uint64, state servicetypes.JobState, retryCount uint32) error {
+	result := s.db.Model(&JobTable{JobID: jobID}).Updates(&JobTable{
+		JobState:     int32(state),
+		ModifiedTime: time.Now(),
+	})
+	if result.Error != nil || result.RowsAffected != 1 {
+		return fmt.Errorf("failed to update job record's state: %s", result.Error)
+	}
+	result = s.db.Model(&GCTaskTable{JobID: jobID}).Update("retry_count", retryCount)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update gc task record's retry count: %s", result.Error)
+	}
+	return nil
+}
+
+// UpdateGCTaskStartBlock update GCTaskTable record's start block number
+func (s *SpDBImpl) UpdateGCTaskStartBlock(jobID uint64, startBlock uint64) error {
+	result := s.db.Model(&GCTaskTable{JobID: jobID}).Update("start_block_number", startBlock)
+	if result.Error != nil || result.RowsAffected != 1 {
+		return fmt.Errorf("failed to update gc task record's start block number: %s", result.Error)
+	}
+	return nil
+}
+
+// ListUnfinishedGCTasks return the gc jobs which are not done, order by start block number
+func (s *SpDBImpl) ListUnfinishedGCTasks() ([]*GCTask, error) {
+	var (
+		tasks        []*GCTask
+		queryReturns []struct {
+			GCTaskTable
+			JobState     int32
+			CreatedTime  time.Time
+			ModifiedTime time.Time
+		}
+	)
+
+	result := s.db.Table(GCTaskTableName).
+		Select(GCTaskTableName+".*, "+JobTableName+".job_state, "+JobTableName+".created_time, "+JobTableName+".modified_time").
+		Joins("inner join "+JobTableName+" on "+JobTableName+".job_id = "+GCTaskTableName+".job_id").
+		Where(JobTableName+".job_type = ? and "+JobTableName+".job_state != ?",
+			int32(servicetypes.JobType_JOB_TYPE_DELETE_OBJECT), int32(servicetypes.JobState_JOB_STATE_GC_OBJECT_DONE)).
+		Order(GCTaskTableName + ".start_block_number asc").
+		Find(&queryReturns)
+	if result.Error != nil {
+		return tasks, fmt.Errorf("failed to query gc task table: %s", result.Error)
+	}
+	for _, record := range queryReturns {
+		tasks = append(tasks, &GCTask{
+			JobID:            record.JobID,
+			JobState:         servicetypes.JobState(record.JobState),
+			StartBlockNumber: record.StartBlockNumber,
+			EndBlockNumber:   record.EndBlockNumber,
+			RetryCount:       record.RetryCount,
+			CreateTime:       record.CreatedTime.Unix(),
+			ModifyTime:       record.ModifiedTime.Unix(),
+		})
+	}
+	return tasks, nil
+}
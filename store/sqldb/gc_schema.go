@@ -0,0 +1,48 @@
+package sqldb
+
+import (
+	"time"
+)
+
+// GCBlockProgressTable table schema
+type GCBlockProgressTable struct {
+	ID               int64 `gorm:"primary_key;autoIncrement"`
+	StartBlockNumber uint64
+	EndBlockNumber   uint64
+	ModifiedTime     time.Time
+}
+
+// TableName is used to set GCBlockProgressTable Schema's table name in database
+func (GCBlockProgressTable) TableName() string {
+	return GCBlockProgressTableName
+}
+
+// GCObjectProgressTable table schema
+type GCObjectProgressTable struct {
+	ObjectID         uint64 `gorm:"primary_key"`
+	BlockNumber      uint64
+	Status           int32 `gorm:"index:status_to_gc_object"`
+	FailedPieceKeys  string
+	RetryCount       uint32
+	ErrorDescription string
+	CreatedTime      time.Time
+	ModifiedTime     time.Time
+}
+
+// TableName is used to set GCObjectProgressTable Schema's table name in database
+func (GCObjectProgressTable) TableName() string {
+	return GCObjectProgressTableName
+}
+
+// GCTaskTable table schema
+type GCTaskTable struct {
+	JobID            uint64 `gorm:"primary_key"` // Job.JobID
+	StartBlockNumber uint64
+	EndBlockNumber   uint64
+	RetryCount       uint32
+}
+
+// TableName is used to set GCTaskTable Schema's table name in database
+func (GCTaskTable) TableName() string {
+	return GCTaskTableName
+}
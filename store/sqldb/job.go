@@ -90,6 +90,7 @@ func (s *SpDBImpl) GetJobByID(jobID uint64) (*servicetypes.JobContext, error) {
 		JobType:      servicetypes.JobType(queryReturn.JobType),
 		JobState:     servicetypes.JobState(queryReturn.JobState),
 		JobErrorCode: queryReturn.JobErrorCode,
+		RetryCount:   queryReturn.RetryCount,
 		CreateTime:   queryReturn.CreatedTime.Unix(),
 		ModifyTime:   queryReturn.ModifiedTime.Unix(),
 	}, nil
@@ -118,11 +119,70 @@ func (s *SpDBImpl) GetJobByObjectID(objectID uint64) (*servicetypes.JobContext,
 		JobType:      servicetypes.JobType(jobQueryReturn.JobType),
 		JobState:     servicetypes.JobState(jobQueryReturn.JobState),
 		JobErrorCode: jobQueryReturn.JobErrorCode,
+		RetryCount:   jobQueryReturn.RetryCount,
 		CreateTime:   jobQueryReturn.CreatedTime.Unix(),
 		ModifyTime:   jobQueryReturn.ModifiedTime.Unix(),
 	}, nil
 }
 
+// ListUploadJobsByState query JobTable joined with ObjectTable by job states, modified time and retry count
+func (s *SpDBImpl) ListUploadJobsByState(states []servicetypes.JobState, modifiedBefore time.Time, maxRetry uint32,
+	limit int) ([]*UploadJobMeta, error) {
+	var (
+		jobs         []*UploadJobMeta
+		stateList    = make([]int32, len(states))
+		queryReturns []struct {
+			JobTable
+			ObjectID uint64
+		}
+	)
+	if len(states) == 0 {
+		return jobs, nil
+	}
+	for index, state := range states {
+		stateList[index] = int32(state)
+	}
+
+	query := s.db.Table(JobTableName).
+		Select(JobTableName+".*, "+ObjectTableName+".object_id").
+		Joins("inner join "+ObjectTableName+" on "+ObjectTableName+".job_id = "+JobTableName+".job_id").
+		Where(JobTableName+".job_type = ? and "+JobTableName+".job_state in ? and "+
+			JobTableName+".modified_time < ? and "+JobTableName+".retry_count < ?",
+			int32(servicetypes.JobType_JOB_TYPE_UPLOAD_OBJECT), stateList, modifiedBefore, maxRetry).
+		Order(JobTableName + ".modified_time asc")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	result := query.Find(&queryReturns)
+	if result.Error != nil {
+		return jobs, fmt.Errorf("failed to query job table: %s", result.Error)
+	}
+	for _, record := range queryReturns {
+		jobs = append(jobs, &UploadJobMeta{
+			ObjectID: record.ObjectID,
+			JobContext: &servicetypes.JobContext{
+				JobId:        record.JobID,
+				JobType:      servicetypes.JobType(record.JobType),
+				JobState:     servicetypes.JobState(record.JobState),
+				JobErrorCode: record.JobErrorCode,
+				RetryCount:   record.RetryCount,
+				CreateTime:   record.CreatedTime.Unix(),
+				ModifyTime:   record.ModifiedTime.Unix(),
+			},
+		})
+	}
+	return jobs, nil
+}
+
+// UpdateJobRetryCount update JobTable record's retry count
+func (s *SpDBImpl) UpdateJobRetryCount(jobID uint64, retryCount uint32) error {
+	result := s.db.Model(&JobTable{JobID: jobID}).Update("retry_count", retryCount)
+	if result.Error != nil || result.RowsAffected != 1 {
+		return fmt.Errorf("failed to update job record's retry count: %s", result.Error)
+	}
+	return nil
+}
+
 // GetObjectInfo query ObjectTable by objectID and convert to storage/types.ObjectInfo.
 func (s *SpDBImpl) GetObjectInfo(objectID uint64) (*storagetypes.ObjectInfo, error) {
 	queryReturn := &ObjectTable{}
@@ -203,3 +263,26 @@ func (s *SpDBImpl) SetObjectInfo(objectID uint64, objectInfo *storagetypes.Objec
 	}
 	return nil
 }
+
+// GetSecondarySpSignatures query ObjectTable by objectID and return the secondary sp signatures
+func (s *SpDBImpl) GetSecondarySpSignatures(objectID uint64) ([][]byte, error) {
+	queryReturn := &ObjectTable{}
+	result := s.db.First(queryReturn, "object_id = ?", objectID)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to query object table: %s", result.Error)
+	}
+	if queryReturn.SecondarySpSignatures == "" {
+		return nil, nil
+	}
+	return util.StringToBytesSlice(queryReturn.SecondarySpSignatures)
+}
+
+// SetSecondarySpSignatures set ObjectTable's secondary sp signatures by objectID
+func (s *SpDBImpl) SetSecondarySpSignatures(objectID uint64, signatures [][]byte) error {
+	result := s.db.Model(&ObjectTable{ObjectID: objectID}).
+		Update("secondary_sp_signatures", util.BytesSliceToString(signatures))
+	if result.Error != nil || result.RowsAffected != 1 {
+		return fmt.Errorf("failed to update object record's secondary sp signatures: %s", result.Error)
+	}
+	return nil
+}
@@ -10,6 +10,7 @@ type JobTable struct {
 	JobType      int32
 	JobState     int32
 	JobErrorCode uint32
+	RetryCount   uint32
 	CreatedTime  time.Time
 	ModifiedTime time.Time
 }
@@ -21,20 +22,21 @@ func (JobTable) TableName() string {
 
 // ObjectTable table schema
 type ObjectTable struct {
-	ObjectID             uint64 `gorm:"primary_key"`
-	JobID                uint64 `gorm:"index:job_to_object"` // Job.JobID
-	Owner                string
-	BucketName           string
-	ObjectName           string
-	PayloadSize          uint64
-	Visibility           int32
-	ContentType          string
-	CreatedAtHeight      int64
-	ObjectStatus         int32
-	RedundancyType       int32
-	SourceType           int32
-	SpIntegrityHash      string
-	SecondarySpAddresses string
+	ObjectID              uint64 `gorm:"primary_key"`
+	JobID                 uint64 `gorm:"index:job_to_object"` // Job.JobID
+	Owner                 string
+	BucketName            string
+	ObjectName            string
+	PayloadSize           uint64
+	Visibility            int32
+	ContentType           string
+	CreatedAtHeight       int64
+	ObjectStatus          int32
+	RedundancyType        int32
+	SourceType            int32
+	SpIntegrityHash       string
+	SecondarySpAddresses  string
+	SecondarySpSignatures string
 }
 
 // TableName is used to set ObjectTable Schema's table name in database
@@ -0,0 +1,48 @@
+package sqldb
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	servicetypes "github.com/bnb-chain/greenfield-storage-provider/service/types"
+)
+
+func TestListUploadJobsByState(t *testing.T) {
+	spDB, recorder := setupDryRunSpDB(t)
+	modifiedBefore := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	jobs, err := spDB.ListUploadJobsByState([]servicetypes.JobState{
+		servicetypes.JobState_JOB_STATE_REPLICATE_OBJECT_ERROR,
+		servicetypes.JobState_JOB_STATE_SEAL_OBJECT_ERROR,
+	}, modifiedBefore, 3, 100)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(jobs))
+	assert.Equal(t, 1, len(recorder.statements))
+	query := recorder.statements[0]
+	assert.True(t, strings.HasPrefix(query, "SELECT job.*, object.object_id FROM `job` inner join object on object.job_id = job.job_id"))
+	assert.Contains(t, query, "job.job_type = 1")
+	assert.Contains(t, query, "job.job_state in (9,15)")
+	assert.Contains(t, query, "job.modified_time < '2023-01-02 03:04:05'")
+	// the jobs whose retry budget is used up are not listed
+	assert.Contains(t, query, "job.retry_count < 3")
+	assert.True(t, strings.HasSuffix(query, "ORDER BY job.modified_time asc LIMIT 100"))
+}
+
+func TestListUploadJobsByState_EmptyStates(t *testing.T) {
+	spDB, recorder := setupDryRunSpDB(t)
+	jobs, err := spDB.ListUploadJobsByState(nil, time.Now(), 3, 100)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(jobs))
+	assert.Equal(t, 0, len(recorder.statements))
+}
+
+func TestUpdateJobRetryCount(t *testing.T) {
+	spDB, recorder := setupDryRunSpDB(t)
+	// no row is affected in dry run, so the update is reported as failed
+	assert.NotNil(t, spDB.UpdateJobRetryCount(10, 2))
+	assert.Equal(t, 1, len(recorder.statements))
+	assert.True(t, strings.HasPrefix(recorder.statements[0], "UPDATE `job` SET `retry_count`=2"))
+	assert.Contains(t, recorder.statements[0], "WHERE `job_id` = 10")
+}
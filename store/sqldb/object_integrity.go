@@ -56,3 +56,60 @@ func (s *SpDBImpl) SetObjectIntegrity(meta *IntegrityMeta) error {
 	}
 	return nil
 }
+
+// SetPieceChecksum put(overwrite) the checksum of an uploaded segment piece to db
+func (s *SpDBImpl) SetPieceChecksum(objectID uint64, segmentIndex uint32, checksum []byte) error {
+	queryReturn := &PieceChecksumTable{}
+	result := s.db.First(queryReturn, "object_id = ? and segment_index = ?", objectID, segmentIndex)
+	recordNotFound := errors.Is(result.Error, gorm.ErrRecordNotFound)
+	if result.Error != nil && !recordNotFound {
+		return fmt.Errorf("failed to query piece checksum table: %s", result.Error)
+	}
+
+	if recordNotFound {
+		insertPieceChecksumRecord := &PieceChecksumTable{
+			ObjectID:     objectID,
+			SegmentIndex: segmentIndex,
+			Checksum:     hex.EncodeToString(checksum),
+		}
+		result = s.db.Create(insertPieceChecksumRecord)
+		if result.Error != nil || result.RowsAffected != 1 {
+			return fmt.Errorf("failed to insert piece checksum record: %s", result.Error)
+		}
+		return nil
+	}
+	result = s.db.Model(&PieceChecksumTable{}).
+		Where("object_id = ? and segment_index = ?", objectID, segmentIndex).
+		Update("checksum", hex.EncodeToString(checksum))
+	if result.Error != nil {
+		return fmt.Errorf("failed to update piece checksum record: %s", result.Error)
+	}
+	return nil
+}
+
+// GetAllPieceChecksums return the checksums of the uploaded segment pieces by object id
+func (s *SpDBImpl) GetAllPieceChecksums(objectID uint64) (map[uint32][]byte, error) {
+	var queryReturns []PieceChecksumTable
+	result := s.db.Where("object_id = ?", objectID).Find(&queryReturns)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to query piece checksum table: %s", result.Error)
+	}
+	checksums := make(map[uint32][]byte, len(queryReturns))
+	for _, record := range queryReturns {
+		checksum, err := hex.DecodeString(record.Checksum)
+		if err != nil {
+			return nil, err
+		}
+		checksums[record.SegmentIndex] = checksum
+	}
+	return checksums, nil
+}
+
+// DeleteAllPieceChecksums delete the checksums of the uploaded segment pieces by object id
+func (s *SpDBImpl) DeleteAllPieceChecksums(objectID uint64) error {
+	result := s.db.Where("object_id = ?", objectID).Delete(&PieceChecksumTable{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete piece checksum record: %s", result.Error)
+	}
+	return nil
+}
@@ -12,3 +12,15 @@ type IntegrityMetaTable struct {
 func (IntegrityMetaTable) TableName() string {
 	return IntegrityMetaTableName
 }
+
+// PieceChecksumTable table schema
+type PieceChecksumTable struct {
+	ObjectID     uint64 `gorm:"primary_key"`
+	SegmentIndex uint32 `gorm:"primary_key"`
+	Checksum     string
+}
+
+// TableName is used to set PieceChecksumTable schema's table name in database
+func (PieceChecksumTable) TableName() string {
+	return PieceChecksumTableName
+}
@@ -0,0 +1,30 @@
+package sqldb
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// GetS3Credential get S3Credential from S3CredentialTable
+func (s *SpDBImpl) GetS3Credential(accessKeyID string) (*S3CredentialTable, error) {
+	queryReturn := &S3CredentialTable{}
+	result := s.db.First(queryReturn, "access_key_id = ?", accessKeyID)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, result.Error
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to query s3 credential table: %s", result.Error)
+	}
+	return queryReturn, nil
+}
+
+// InsertS3Credential insert a new record into S3CredentialTable
+func (s *SpDBImpl) InsertS3Credential(newRecord *S3CredentialTable) error {
+	result := s.db.Create(newRecord)
+	if result.Error != nil || result.RowsAffected != 1 {
+		return fmt.Errorf("failed to insert record in s3 credential table: %s", result.Error)
+	}
+	return nil
+}
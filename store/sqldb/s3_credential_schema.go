@@ -0,0 +1,25 @@
+package sqldb
+
+import (
+	"time"
+)
+
+// S3CredentialTable table schema
+type S3CredentialTable struct {
+	AccessKeyID string `gorm:"primary_key"`
+	SecretKey   string
+
+	UserAddress string `gorm:"index:idx_user_domain"`
+	Domain      string `gorm:"index:idx_user_domain"`
+	// AuthKeyNonce is the nonce of the off chain auth key when the credential is issued, the credential
+	// is invalidated once the off chain auth key is rotated
+	AuthKeyNonce int32
+
+	CreatedTime  time.Time
+	ModifiedTime time.Time
+}
+
+// TableName is used to set S3CredentialTable Schema's table name in database
+func (S3CredentialTable) TableName() string {
+	return S3CredentialTableName
+}
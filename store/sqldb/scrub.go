@@ -0,0 +1,132 @@
+package sqldb
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/bnb-chain/greenfield-storage-provider/util"
+)
+
+// ListObjectIntegrities return the integrity metas whose object id is greater than afterObjectID
+func (s *SpDBImpl) ListObjectIntegrities(afterObjectID uint64, limit int) ([]*IntegrityMeta, error) {
+	var (
+		result       *gorm.DB
+		err          error
+		metas        []*IntegrityMeta
+		queryReturns []IntegrityMetaTable
+	)
+
+	if limit <= 0 {
+		result = s.db.Where("object_id > ?", afterObjectID).Order("object_id asc").Find(&queryReturns)
+	} else {
+		result = s.db.Where("object_id > ?", afterObjectID).Order("object_id asc").Limit(limit).Find(&queryReturns)
+	}
+	if result.Error != nil {
+		return metas, fmt.Errorf("failed to query integrity meta table: %s", result.Error)
+	}
+	for _, record := range queryReturns {
+		meta := &IntegrityMeta{ObjectID: record.ObjectID}
+		if meta.IntegrityHash, err = hex.DecodeString(record.IntegrityHash); err != nil {
+			return metas, err
+		}
+		if meta.Signature, err = hex.DecodeString(record.Signature); err != nil {
+			return metas, err
+		}
+		if meta.Checksum, err = util.StringToBytesSlice(record.PieceHashList); err != nil {
+			return metas, err
+		}
+		metas = append(metas, meta)
+	}
+	return metas, nil
+}
+
+// GetScrubResult return the scrub outcome of an object
+func (s *SpDBImpl) GetScrubResult(objectID uint64) (*ScrubResult, error) {
+	queryReturn := &ScrubResultTable{}
+	result := s.db.First(queryReturn, "object_id = ?", objectID)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, result.Error
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to query scrub result table: %s", result.Error)
+	}
+	return toScrubResult(queryReturn), nil
+}
+
+// SetScrubResult set(maybe overwrite) the scrub outcome of an object
+func (s *SpDBImpl) SetScrubResult(scrubResult *ScrubResult) error {
+	queryReturn := &ScrubResultTable{}
+	result := s.db.First(queryReturn, "object_id = ?", scrubResult.ObjectID)
+	recordNotFound := errors.Is(result.Error, gorm.ErrRecordNotFound)
+	if result.Error != nil && !recordNotFound {
+		return fmt.Errorf("failed to query scrub result table: %s", result.Error)
+	}
+
+	if recordNotFound {
+		insertScrubResultRecord := &ScrubResultTable{
+			ObjectID:         scrubResult.ObjectID,
+			Status:           int32(scrubResult.Status),
+			ScrubbedPieces:   scrubResult.ScrubbedPieces,
+			CorruptPieceKeys: util.JoinWithComma(scrubResult.CorruptPieceKeys),
+			ErrorDescription: scrubResult.ErrorDescription,
+			CreatedTime:      time.Now(),
+			ModifiedTime:     time.Now(),
+		}
+		result = s.db.Create(insertScrubResultRecord)
+		if result.Error != nil || result.RowsAffected != 1 {
+			return fmt.Errorf("failed to insert scrub result table: %s", result.Error)
+		}
+		return nil
+	}
+	// use map to update, otherwise the cleared corrupt piece keys and error description will be ignored by gorm
+	result = s.db.Model(&ScrubResultTable{ObjectID: scrubResult.ObjectID}).Updates(map[string]interface{}{
+		"status":             int32(scrubResult.Status),
+		"scrubbed_pieces":    scrubResult.ScrubbedPieces,
+		"corrupt_piece_keys": util.JoinWithComma(scrubResult.CorruptPieceKeys),
+		"error_description":  scrubResult.ErrorDescription,
+		"modified_time":      time.Now(),
+	})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update scrub result table: %s", result.Error)
+	}
+	return nil
+}
+
+// ListScrubResults return the scrub outcomes by status, order by modified time desc
+func (s *SpDBImpl) ListScrubResults(status ScrubStatus, limit int) ([]*ScrubResult, error) {
+	var (
+		result       *gorm.DB
+		scrubResults []*ScrubResult
+		queryReturns []ScrubResultTable
+	)
+
+	if limit <= 0 {
+		result = s.db.Where("status = ?", int32(status)).Order("modified_time desc").Find(&queryReturns)
+	} else {
+		result = s.db.Where("status = ?", int32(status)).Order("modified_time desc").Limit(limit).Find(&queryReturns)
+	}
+	if result.Error != nil {
+		return scrubResults, fmt.Errorf("failed to query scrub result table: %s", result.Error)
+	}
+	for index := range queryReturns {
+		scrubResults = append(scrubResults, toScrubResult(&queryReturns[index]))
+	}
+	return scrubResults, nil
+}
+
+// toScrubResult convert ScrubResultTable record to ScrubResult
+func toScrubResult(record *ScrubResultTable) *ScrubResult {
+	return &ScrubResult{
+		ObjectID:         record.ObjectID,
+		Status:           ScrubStatus(record.Status),
+		ScrubbedPieces:   record.ScrubbedPieces,
+		CorruptPieceKeys: util.SplitByComma(record.CorruptPieceKeys),
+		ErrorDescription: record.ErrorDescription,
+		CreateTime:       record.CreatedTime.Unix(),
+		ModifyTime:       record.ModifiedTime.Unix(),
+	}
+}
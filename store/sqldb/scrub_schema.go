@@ -0,0 +1,21 @@
+package sqldb
+
+import (
+	"time"
+)
+
+// ScrubResultTable table schema
+type ScrubResultTable struct {
+	ObjectID         uint64 `gorm:"primary_key"`
+	Status           int32  `gorm:"index:status_to_scrub_result"`
+	ScrubbedPieces   uint32
+	CorruptPieceKeys string
+	ErrorDescription string
+	CreatedTime      time.Time
+	ModifiedTime     time.Time
+}
+
+// TableName is used to set ScrubResultTable Schema's table name in database
+func (ScrubResultTable) TableName() string {
+	return ScrubResultTableName
+}
@@ -0,0 +1,154 @@
+package sqldb
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// recordSPSuccessSQL updates the reputation by a successful replication in one statement, mysql assigns
+// the columns from left to right, so the moving averages are assigned before success_count is increased.
+const recordSPSuccessSQL = "UPDATE " + SPReputationTableName + " SET " +
+	"average_latency = IF(success_count = 0, ?, ROUND(? * ? + (1 - ?) * average_latency)), " +
+	"average_throughput = IF(success_count = 0, ?, ROUND(? * ? + (1 - ?) * average_throughput)), " +
+	"signature_failure_count = IF(signature_failure_count > 0 AND ? > 0 AND (success_count + 1) % ? = 0, " +
+	"signature_failure_count - 1, signature_failure_count), " +
+	"success_count = success_count + 1, consecutive_failures = 0, modified_time = ? " +
+	"WHERE operator_address = ?"
+
+// recordSPFailureSQL updates the reputation by a failed replication in one statement, the sp enters the
+// cooling-off period if its signature is invalid or it fails too many times in a row, cooling_until is
+// assigned before consecutive_failures is changed.
+const recordSPFailureSQL = "UPDATE " + SPReputationTableName + " SET " +
+	"cooling_until = IF(? OR consecutive_failures + 1 >= ?, ?, cooling_until), " +
+	"consecutive_failures = IF(? OR consecutive_failures + 1 >= ?, 0, consecutive_failures + 1), " +
+	"failure_count = failure_count + 1, " +
+	"signature_failure_count = signature_failure_count + IF(?, 1, 0), modified_time = ? " +
+	"WHERE operator_address = ?"
+
+// GetSPReputation return the reputation of a secondary sp
+func (s *SpDBImpl) GetSPReputation(operatorAddress string) (*SPReputation, error) {
+	queryReturn := &SPReputationTable{}
+	result := s.db.First(queryReturn, "operator_address = ?", operatorAddress)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, result.Error
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to query sp reputation table: %s", result.Error)
+	}
+	return toSPReputation(queryReturn), nil
+}
+
+// SetSPReputation set(maybe overwrite) the reputation of a secondary sp
+func (s *SpDBImpl) SetSPReputation(reputation *SPReputation) error {
+	queryReturn := &SPReputationTable{}
+	result := s.db.First(queryReturn, "operator_address = ?", reputation.OperatorAddress)
+	recordNotFound := errors.Is(result.Error, gorm.ErrRecordNotFound)
+	if result.Error != nil && !recordNotFound {
+		return fmt.Errorf("failed to query sp reputation table: %s", result.Error)
+	}
+
+	if recordNotFound {
+		insertReputationRecord := &SPReputationTable{
+			OperatorAddress:       reputation.OperatorAddress,
+			SuccessCount:          reputation.SuccessCount,
+			FailureCount:          reputation.FailureCount,
+			SignatureFailureCount: reputation.SignatureFailureCount,
+			ConsecutiveFailures:   reputation.ConsecutiveFailures,
+			AverageLatency:        reputation.AverageLatency,
+			AverageThroughput:     reputation.AverageThroughput,
+			CoolingUntil:          reputation.CoolingUntil,
+			CreatedTime:           time.Now(),
+			ModifiedTime:          time.Now(),
+		}
+		result = s.db.Create(insertReputationRecord)
+		if result.Error != nil || result.RowsAffected != 1 {
+			return fmt.Errorf("failed to insert sp reputation table: %s", result.Error)
+		}
+		return nil
+	}
+	// use map to update, otherwise the reset consecutive failures and cooling time will be ignored by gorm
+	result = s.db.Model(&SPReputationTable{OperatorAddress: reputation.OperatorAddress}).Updates(map[string]interface{}{
+		"success_count":           reputation.SuccessCount,
+		"failure_count":           reputation.FailureCount,
+		"signature_failure_count": reputation.SignatureFailureCount,
+		"consecutive_failures":    reputation.ConsecutiveFailures,
+		"average_latency":         reputation.AverageLatency,
+		"average_throughput":      reputation.AverageThroughput,
+		"cooling_until":           reputation.CoolingUntil,
+		"modified_time":           time.Now(),
+	})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update sp reputation table: %s", result.Error)
+	}
+	return nil
+}
+
+// RecordSPReplication atomically update the reputation of a secondary sp by the replication outcome
+func (s *SpDBImpl) RecordSPReplication(replication *SPReplication) error {
+	now := time.Now()
+	result := s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&SPReputationTable{
+		OperatorAddress: replication.OperatorAddress,
+		CreatedTime:     now,
+		ModifiedTime:    now,
+	})
+	if result.Error != nil {
+		return fmt.Errorf("failed to insert sp reputation table: %s", result.Error)
+	}
+
+	if replication.Success {
+		factor := replication.SmoothingFactor
+		result = s.db.Exec(recordSPSuccessSQL,
+			replication.Latency, factor, replication.Latency, factor,
+			replication.Throughput, factor, replication.Throughput, factor,
+			replication.SignatureFailureDecay, replication.SignatureFailureDecay,
+			now, replication.OperatorAddress)
+	} else {
+		result = s.db.Exec(recordSPFailureSQL,
+			replication.SignatureFailure, replication.MaxConsecutiveFailures, replication.CoolingUntil,
+			replication.SignatureFailure, replication.MaxConsecutiveFailures,
+			replication.SignatureFailure, now, replication.OperatorAddress)
+	}
+	if result.Error != nil {
+		return fmt.Errorf("failed to update sp reputation table: %s", result.Error)
+	}
+	return nil
+}
+
+// ListSPReputations return the reputations of the secondary sps
+func (s *SpDBImpl) ListSPReputations(operatorAddresses []string) ([]*SPReputation, error) {
+	var (
+		reputations  []*SPReputation
+		queryReturns []SPReputationTable
+	)
+
+	if len(operatorAddresses) == 0 {
+		return reputations, nil
+	}
+	result := s.db.Where("operator_address IN ?", operatorAddresses).Find(&queryReturns)
+	if result.Error != nil {
+		return reputations, fmt.Errorf("failed to query sp reputation table: %s", result.Error)
+	}
+	for index := range queryReturns {
+		reputations = append(reputations, toSPReputation(&queryReturns[index]))
+	}
+	return reputations, nil
+}
+
+// toSPReputation convert SPReputationTable record to SPReputation
+func toSPReputation(record *SPReputationTable) *SPReputation {
+	return &SPReputation{
+		OperatorAddress:       record.OperatorAddress,
+		SuccessCount:          record.SuccessCount,
+		FailureCount:          record.FailureCount,
+		SignatureFailureCount: record.SignatureFailureCount,
+		ConsecutiveFailures:   record.ConsecutiveFailures,
+		AverageLatency:        record.AverageLatency,
+		AverageThroughput:     record.AverageThroughput,
+		CoolingUntil:          record.CoolingUntil,
+		ModifyTime:            record.ModifiedTime.Unix(),
+	}
+}
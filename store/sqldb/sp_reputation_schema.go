@@ -0,0 +1,24 @@
+package sqldb
+
+import (
+	"time"
+)
+
+// SPReputationTable table schema
+type SPReputationTable struct {
+	OperatorAddress       string `gorm:"primary_key"`
+	SuccessCount          uint64
+	FailureCount          uint64
+	SignatureFailureCount uint64
+	ConsecutiveFailures   uint32
+	AverageLatency        int64
+	AverageThroughput     int64
+	CoolingUntil          int64
+	CreatedTime           time.Time
+	ModifiedTime          time.Time
+}
+
+// TableName is used to set SPReputationTable Schema's table name in database
+func (SPReputationTable) TableName() string {
+	return SPReputationTableName
+}
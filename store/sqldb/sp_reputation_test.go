@@ -0,0 +1,84 @@
+package sqldb
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// sqlRecorder records the sql statements built by gorm
+type sqlRecorder struct {
+	logger.Interface
+	statements []string
+}
+
+func (r *sqlRecorder) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	sql, _ := fc()
+	r.statements = append(r.statements, sql)
+}
+
+// setupDryRunSpDB returns a SpDBImpl which builds the mysql statements without executing them
+func setupDryRunSpDB(t *testing.T) (*SpDBImpl, *sqlRecorder) {
+	recorder := &sqlRecorder{Interface: logger.Discard}
+	db, err := gorm.Open(mysql.New(mysql.Config{DSN: "user:passwd@tcp(127.0.0.1:3306)/db", SkipInitializeWithVersion: true}),
+		&gorm.Config{DryRun: true, SkipDefaultTransaction: true, DisableAutomaticPing: true, Logger: recorder})
+	assert.Nil(t, err)
+	return &SpDBImpl{db: db}, recorder
+}
+
+func TestRecordSPReplication_Success(t *testing.T) {
+	spDB, recorder := setupDryRunSpDB(t)
+	assert.Nil(t, spDB.RecordSPReplication(&SPReplication{
+		OperatorAddress:       "sp1",
+		Success:               true,
+		Latency:               100,
+		Throughput:            2048,
+		SmoothingFactor:       0.2,
+		SignatureFailureDecay: 100,
+	}))
+	assert.Equal(t, 2, len(recorder.statements))
+	// the reputation is created if not exists, and updated in one statement without reading it
+	assert.True(t, strings.HasPrefix(recorder.statements[0], "INSERT INTO `sp_reputation`"))
+	assert.Contains(t, recorder.statements[0], "ON DUPLICATE KEY UPDATE")
+	update := recorder.statements[1]
+	assert.True(t, strings.HasPrefix(update, "UPDATE sp_reputation SET"))
+	assert.Contains(t, update, "IF(success_count = 0, 100, ROUND(0.200000 * 100 + (1 - 0.200000) * average_latency))")
+	assert.Contains(t, update, "IF(success_count = 0, 2048, ROUND(0.200000 * 2048 + (1 - 0.200000) * average_throughput))")
+	assert.Contains(t, update, "(success_count + 1) % 100 = 0")
+	assert.Contains(t, update, "success_count = success_count + 1, consecutive_failures = 0")
+	assert.True(t, strings.HasSuffix(update, "WHERE operator_address = 'sp1'"))
+	// the moving averages are assigned before success count is increased
+	assert.Less(t, strings.Index(update, "average_latency ="), strings.Index(update, "success_count = success_count + 1"))
+}
+
+func TestRecordSPReplication_Failure(t *testing.T) {
+	spDB, recorder := setupDryRunSpDB(t)
+	assert.Nil(t, spDB.RecordSPReplication(&SPReplication{
+		OperatorAddress:        "sp1",
+		SignatureFailure:       true,
+		MaxConsecutiveFailures: 3,
+		CoolingUntil:           1000,
+	}))
+	assert.Equal(t, 2, len(recorder.statements))
+	update := recorder.statements[1]
+	assert.Contains(t, update, "cooling_until = IF(true OR consecutive_failures + 1 >= 3, 1000, cooling_until)")
+	assert.Contains(t, update, "consecutive_failures = IF(true OR consecutive_failures + 1 >= 3, 0, consecutive_failures + 1)")
+	assert.Contains(t, update, "failure_count = failure_count + 1")
+	assert.Contains(t, update, "signature_failure_count = signature_failure_count + IF(true, 1, 0)")
+	// cooling until is assigned before consecutive failures is changed
+	assert.Less(t, strings.Index(update, "cooling_until ="), strings.Index(update, "consecutive_failures ="))
+}
+
+func TestListSPReputations_Empty(t *testing.T) {
+	spDB, recorder := setupDryRunSpDB(t)
+	reputations, err := spDB.ListSPReputations(nil)
+	assert.Nil(t, err)
+	assert.Empty(t, reputations)
+	assert.Empty(t, recorder.statements)
+}
@@ -70,6 +70,10 @@ func InitDB(config *config.SQLDBConfig) (*gorm.DB, error) {
 		log.Errorw("failed to create integrity meta table", "error", err)
 		return nil, err
 	}
+	if err := db.AutoMigrate(&PieceChecksumTable{}); err != nil {
+		log.Errorw("failed to create piece checksum table", "error", err)
+		return nil, err
+	}
 	if err := db.AutoMigrate(&BucketTrafficTable{}); err != nil {
 		log.Errorw("failed to create bucket traffic table", "error", err)
 		return nil, err
@@ -86,6 +90,34 @@ func InitDB(config *config.SQLDBConfig) (*gorm.DB, error) {
 		log.Errorw("failed to create off-chain authKey table", "error", err)
 		return nil, err
 	}
+	if err := db.AutoMigrate(&S3CredentialTable{}); err != nil {
+		log.Errorw("failed to create s3 credential table", "error", err)
+		return nil, err
+	}
+	if err := db.AutoMigrate(&BucketCORSTable{}); err != nil {
+		log.Errorw("failed to create bucket cors table", "error", err)
+		return nil, err
+	}
+	if err := db.AutoMigrate(&GCBlockProgressTable{}); err != nil {
+		log.Errorw("failed to create gc block progress table", "error", err)
+		return nil, err
+	}
+	if err := db.AutoMigrate(&GCObjectProgressTable{}); err != nil {
+		log.Errorw("failed to create gc object progress table", "error", err)
+		return nil, err
+	}
+	if err := db.AutoMigrate(&GCTaskTable{}); err != nil {
+		log.Errorw("failed to create gc task table", "error", err)
+		return nil, err
+	}
+	if err := db.AutoMigrate(&ScrubResultTable{}); err != nil {
+		log.Errorw("failed to create scrub result table", "error", err)
+		return nil, err
+	}
+	if err := db.AutoMigrate(&SPReputationTable{}); err != nil {
+		log.Errorw("failed to create sp reputation table", "error", err)
+		return nil, err
+	}
 	return db, nil
 }
 
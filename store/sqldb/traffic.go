@@ -264,3 +264,27 @@ func (s *SpDBImpl) GetUserReadRecord(userAddress string, timeRange *TrafficTimeR
 	}
 	return records, nil
 }
+
+// GetObjectsReadCount return the read count of every object since startTimestampUs
+func (s *SpDBImpl) GetObjectsReadCount(objectIDs []uint64, startTimestampUs int64) (map[uint64]int64, error) {
+	var queryReturns []struct {
+		ObjectID  uint64
+		ReadCount int64
+	}
+	counts := make(map[uint64]int64)
+	if len(objectIDs) == 0 {
+		return counts, nil
+	}
+	result := s.db.Model(&ReadRecordTable{}).
+		Select("object_id, count(*) as read_count").
+		Where("object_id in ? and read_timestamp_us >= ?", objectIDs, startTimestampUs).
+		Group("object_id").
+		Scan(&queryReturns)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to query read record table: %s", result.Error)
+	}
+	for _, r := range queryReturns {
+		counts[r.ObjectID] = r.ReadCount
+	}
+	return counts, nil
+}
@@ -0,0 +1,18 @@
+package piecestore_e2e
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	mpiecestore "github.com/bnb-chain/greenfield-storage-provider/model/piecestore"
+)
+
+func TestAzureBlobStore(t *testing.T) {
+	// 1. init PieceStore with azurite emulator
+	t.Setenv(mpiecestore.AzureStorageKey, azuriteAccountKey)
+	handler, err := setup(t, mpiecestore.AzureBlobStore, azuriteBucketURL, 0)
+	assert.Equal(t, err, nil)
+
+	doOperations(t, handler)
+}
@@ -0,0 +1,18 @@
+package piecestore_e2e
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	mpiecestore "github.com/bnb-chain/greenfield-storage-provider/model/piecestore"
+)
+
+func TestGCSStore(t *testing.T) {
+	// 1. init PieceStore with fake-gcs-server emulator
+	t.Setenv(mpiecestore.GCSEmulatorHost, fakeGCSHost)
+	handler, err := setup(t, mpiecestore.GCSStore, gcsBucketURL, 0)
+	assert.Equal(t, err, nil)
+
+	doOperations(t, handler)
+}
@@ -17,6 +17,16 @@ import (
 const (
 	pieceKey    = "hello.txt"
 	s3BucketURL = "https://s3.us-east-1.amazonaws.com/test"
+	// azuriteBucketURL is the container of azurite emulator started by
+	// docker run -p 10000:10000 mcr.microsoft.com/azure-storage/azurite azurite-blob --blobHost 0.0.0.0
+	azuriteBucketURL = "http://127.0.0.1:10000/devstoreaccount1/test"
+	// azuriteAccountKey is the well-known account key of azurite emulator
+	azuriteAccountKey = "Eby8vdM02xNOcqFlqUwJPLlmEtlCDXJ1OUzFT50uSRZ6IFsuFq2UVErCz4I6tq/K1SZFPTOtr/KBHBeksoGMGw=="
+	// fakeGCSHost is the host of fake-gcs-server emulator started by
+	// docker run -p 4443:4443 fsouza/fake-gcs-server -scheme http
+	fakeGCSHost  = "localhost:4443"
+	gcsBucketURL = "gs://test"
+	ossBucketURL = "https://test.oss-cn-hangzhou.aliyuncs.com"
 	// virtualPath = "https://test.s3.us-east-1.amazonaws.com"
 )
 
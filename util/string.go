@@ -96,6 +96,11 @@ func Uint32ToString(u uint32) string {
 	return Uint64ToString(uint64(u))
 }
 
+// Int32ToString converts int32 to string
+func Int32ToString(i int32) string {
+	return strconv.FormatInt(int64(i), 10)
+}
+
 // BytesSliceToString is used to serialize
 func BytesSliceToString(bytes [][]byte) string {
 	stringList := make([]string, len(bytes))